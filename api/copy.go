@@ -1,7 +1,10 @@
 package api
 
 import (
+	"fmt"
 	"netfs/api/transport"
+	"strconv"
+	"time"
 )
 
 // Status of the task.
@@ -17,26 +20,168 @@ const (
 // The task identifier.
 type TaskId string
 
+// Compression of the copied data.
+type CompressionMode string
+
+const (
+	// The default compression of the host is used.
+	CompressionDefault CompressionMode = ""
+	// The file is compressed if its type and sampled content look compressible.
+	CompressionAuto CompressionMode = "auto"
+	// The data is never compressed.
+	CompressionNone CompressionMode = "none"
+	// The data is always compressed if the target host supports a codec.
+	CompressionAlways CompressionMode = "always"
+)
+
+// Statistics of the data sent by the task.
+type TaskStats struct {
+	// Codec of the compressed data, empty if nothing is compressed.
+	Codec transport.Codec
+	// Size of the data before compression.
+	Bytes int64
+	// Size of the data sent to the target host.
+	Sent int64
+	// Size of the data which is not sent because the target file already contains it.
+	Saved int64
+}
+
+// Returns the compression ratio, the size of the data divided by the size of the sent data.
+func (stats TaskStats) Ratio() float64 {
+	if stats.Sent == 0 {
+		return 1
+	}
+	return float64(stats.Bytes) / float64(stats.Sent)
+}
+
+// Options of the copying.
+type CopyOptions struct {
+	// The existing target file is replaced.
+	Replace bool
+	// Count of the concurrent streams of one file, zero means the default of the host.
+	Streams int
+	// Count of the files of the directory which are copied concurrently, zero means the default of the host.
+	Workers int
+	// Compression of the data, empty means the default of the host.
+	Compression CompressionMode
+	// The existing target file is updated by sending only the changed blocks, the target file must be replaceable.
+	Delta bool
+	// Maximum rate of the sent data in bytes per second, zero means the task is limited only by the host limits.
+	Limit int64
+	// The source file is removed after the successful copying, the source host must support FeatureMove.
+	Move bool
+}
+
 // Netfs server task.
 type RemoteCopyTask struct {
-	Source   RemoteFile
-	Target   RemoteFile
-	Host     RemoteHost
-	Id       TaskId
-	Error    error
+	Source RemoteFile
+	Target RemoteFile
+	Host   RemoteHost
+	Id     TaskId
+	// The options are sent as the fields of the task.
+	CopyOptions
+	Error    *RemoteError
 	Progress int
 	Count    int
 	Current  int
 	Status   TaskStatus
+	Stats    TaskStats
+	// The running task doesn't send data while it's paused.
+	Paused bool
+	// Size of the source files, zero if the host doesn't report it.
+	Size int64
+	// Time of the copying without pauses, it's counted by the host.
+	Elapsed time.Duration
+}
+
+// Returns the estimated size of the copied data by the statistics and the progress of the task.
+func (tsk *RemoteCopyTask) Copied() int64 {
+	return max(tsk.Stats.Bytes+tsk.Stats.Saved, tsk.Size*int64(tsk.Progress)/100)
+}
+
+// Returns the average rate of the copying in bytes per second, zero if it's unknown.
+func (tsk *RemoteCopyTask) Throughput() float64 {
+	if tsk.Elapsed <= 0 {
+		return 0
+	}
+	return float64(tsk.Copied()) / tsk.Elapsed.Seconds()
+}
+
+// Returns the estimated time until the end of the running task, zero if it's unknown.
+func (tsk *RemoteCopyTask) Remaining() time.Duration {
+	throughput := tsk.Throughput()
+	if tsk.Status != Running || tsk.Size == 0 || throughput == 0 {
+		return 0
+	}
+	return time.Duration(float64(max(tsk.Size-tsk.Copied(), 0)) / throughput * float64(time.Second))
 }
 
 // Cancels the current task.
 func (tsk *RemoteCopyTask) Cancel(client transport.TransportSender) error {
-	params := []string{Endpoints.FileCopyCancel.TaskId, string(tsk.Id)}
-	req, err := client.NewRequest(tsk.Host.IP, Endpoints.FileCopyCancel.Name, params, nil, nil)
+	endpoint := tsk.Host.Endpoints().FileCopyCancel
+	params := []string{endpoint.TaskId, string(tsk.Id)}
+	req, err := client.NewRequest(tsk.Host.IP, endpoint.Name, params, nil, nil)
+
+	if err == nil {
+		_, err = send(client, req)
+	}
+	return err
+}
+
+// Changes the rate limit of the running task in bytes per second, zero removes the limit of the task.
+func (tsk *RemoteCopyTask) SetLimit(client transport.TransportSender, limit int64) error {
+	endpoint := tsk.Host.Endpoints().FileCopyLimit
+	params := []string{
+		endpoint.TaskId, string(tsk.Id),
+		endpoint.Limit, strconv.FormatInt(limit, decimalBase),
+	}
+	req, err := client.NewRequest(tsk.Host.IP, endpoint.Name, params, nil, nil)
+
+	if err == nil {
+		if _, err = send(client, req); err == nil {
+			tsk.Limit = limit
+		}
+	}
+	return err
+}
+
+// Pauses the running task, the host stops sending its data until the task is resumed.
+// The host must support FeatureTaskControl.
+func (tsk *RemoteCopyTask) Pause(client transport.TransportSender) error {
+	endpoint := tsk.Host.Endpoints().FileCopyPause
+	params := []string{endpoint.TaskId, string(tsk.Id)}
+	req, err := client.NewRequest(tsk.Host.IP, endpoint.Name, params, nil, nil)
 
 	if err == nil {
-		_, err = client.Send(req)
+		if _, err = send(client, req); err == nil {
+			tsk.Paused = true
+		}
 	}
 	return err
 }
+
+// Resumes the paused task, the host must support FeatureTaskControl.
+func (tsk *RemoteCopyTask) Resume(client transport.TransportSender) error {
+	endpoint := tsk.Host.Endpoints().FileCopyResume
+	params := []string{endpoint.TaskId, string(tsk.Id)}
+	req, err := client.NewRequest(tsk.Host.IP, endpoint.Name, params, nil, nil)
+
+	if err == nil {
+		if _, err = send(client, req); err == nil {
+			tsk.Paused = false
+		}
+	}
+	return err
+}
+
+// Starts a new task with the same source, target and options, the target file left by the stopped task is replaced.
+// Only the failed or cancelled task can be retried.
+func (tsk *RemoteCopyTask) Retry(client transport.TransportSender) (*RemoteCopyTask, error) {
+	if tsk.Status != Failed && tsk.Status != Cancelled {
+		return nil, fmt.Errorf("%w: task [%s] is not stopped", ErrInvalidArgument, tsk.Id)
+	}
+
+	options := tsk.CopyOptions
+	options.Replace = true
+	return tsk.Source.CopyWith(client, tsk.Target, options)
+}
@@ -0,0 +1,340 @@
+package api
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"netfs/api/transport"
+	"strconv"
+)
+
+const minBlockSize = 2048    // 2 KB
+const maxBlockSize = 1048576 // 1 MB
+const strongSize = 16
+const checksumModulus = 1 << 16
+
+// Operations of the delta stream.
+const (
+	// The block of the target file is copied, the operation is followed by the block index.
+	deltaCopy byte = 1
+	// The data is written as is, the operation is followed by the data length and the data.
+	deltaLiteral byte = 2
+)
+
+// Signature of the block of the file.
+type BlockSignature struct {
+	// Rolling checksum of the block.
+	Weak uint32
+	// Hash of the block which confirms the match of the rolling checksum.
+	Strong []byte
+	// Size of the block, only the last block of the file is smaller than the block size.
+	Size int
+}
+
+// Signatures of the blocks of the file.
+type FileSignature struct {
+	BlockSize int
+	Blocks    []BlockSignature
+}
+
+// Returns the block size of the delta transfer of the file.
+// The block size grows with the square root of the file size, so the signature stays small for large files.
+func DeltaBlockSize(size int64) int {
+	blockSize := int(math.Sqrt(float64(size))) &^ (minBlockSize - 1)
+	return min(max(blockSize, minBlockSize), maxBlockSize)
+}
+
+// Reads the file and returns signatures of its blocks.
+func NewSignature(reader io.Reader, blockSize int) (*FileSignature, error) {
+	if blockSize <= 0 || blockSize > maxBlockSize {
+		return nil, fmt.Errorf("%w: block size [%d] is incorrect", ErrInvalidArgument, blockSize)
+	}
+
+	signature := &FileSignature{BlockSize: blockSize, Blocks: []BlockSignature{}}
+	block := make([]byte, blockSize)
+	for {
+		read, err := io.ReadFull(reader, block)
+		if read > 0 {
+			signature.Blocks = append(signature.Blocks, BlockSignature{
+				Weak:   newChecksum(block[:read]).value(),
+				Strong: strongHash(block[:read]),
+				Size:   read,
+			})
+		}
+
+		if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+			return signature, nil
+		} else if err != nil {
+			return nil, err
+		}
+	}
+}
+
+func strongHash(block []byte) []byte {
+	hash := sha256.Sum256(block)
+	return hash[:strongSize]
+}
+
+// Rolling checksum of the data window.
+type checksum struct {
+	a    uint32
+	b    uint32
+	size uint32
+}
+
+func newChecksum(window []byte) checksum {
+	sum := checksum{size: uint32(len(window))}
+	for index, value := range window {
+		sum.a += uint32(value)
+		sum.b += uint32(len(window)-index) * uint32(value)
+	}
+	sum.a %= checksumModulus
+	sum.b %= checksumModulus
+	return sum
+}
+
+// Moves the window by one byte, the first byte of the window is removed and the next byte is added.
+func (sum *checksum) roll(removed byte, added byte) {
+	sum.a = (sum.a - uint32(removed) + uint32(added)) % checksumModulus
+	sum.b = (sum.b - sum.size*uint32(removed) + sum.a) % checksumModulus
+}
+
+func (sum checksum) value() uint32 {
+	return sum.a | sum.b<<16
+}
+
+// Progress of the delta computation.
+type DeltaProgress struct {
+	// Size of the read data of the source file.
+	Read int64
+	// Size of the data which matches the blocks of the target file.
+	Matched int64
+}
+
+// Reads the source file and writes the delta which transforms the file of the signature into the source file.
+// The blocks of the source file which match the signature are written as references to the blocks,
+// other data is written as is. The progress function is called after each written operation.
+func WriteDelta(reader io.Reader, signature *FileSignature, writer io.Writer, progress func(DeltaProgress)) error {
+	blocks := map[uint32][]int{}
+	for index, block := range signature.Blocks {
+		blocks[block.Weak] = append(blocks[block.Weak], index)
+	}
+
+	delta := &deltaWriter{writer: bufio.NewWriterSize(writer, maxBlockSize), blockSize: signature.BlockSize, progress: progress}
+	source := bufio.NewReaderSize(reader, maxBlockSize)
+
+	// The window is kept in the buffer of the double block size, so removing the first byte does not copy the window.
+	buffer := make([]byte, 2*signature.BlockSize)
+	start := 0
+	read, err := io.ReadFull(source, buffer[:signature.BlockSize])
+	window := buffer[:read]
+	sum := newChecksum(window)
+
+	for len(window) > 0 && (err == nil || errors.Is(err, io.ErrUnexpectedEOF)) {
+		if index, ok := findBlock(signature, blocks, sum, window); ok {
+			if err = delta.copy(index, len(window)); err == nil {
+				start = 0
+				read, err = io.ReadFull(source, buffer[:signature.BlockSize])
+				window = buffer[:read]
+				sum = newChecksum(window)
+			}
+			continue
+		}
+
+		var next byte
+		if next, err = source.ReadByte(); errors.Is(err, io.EOF) {
+			err = delta.literal(window)
+			break
+		} else if err == nil {
+			if err = delta.literal(window[:1]); err == nil {
+				sum.roll(window[0], next)
+				if start+len(window) == len(buffer) {
+					copy(buffer, window)
+					start = 0
+				}
+				start++
+				window = buffer[start : start+len(window)]
+				window[len(window)-1] = next
+			}
+		}
+	}
+
+	if err == nil || errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		err = delta.flush()
+	}
+	return err
+}
+
+// Returns index of the block of the signature which is equal to the window.
+func findBlock(signature *FileSignature, blocks map[uint32][]int, sum checksum, window []byte) (int, bool) {
+	indexes, ok := blocks[sum.value()]
+	if ok {
+		strong := strongHash(window)
+		for _, index := range indexes {
+			block := signature.Blocks[index]
+			if block.Size == len(window) && bytes.Equal(block.Strong, strong) {
+				return index, true
+			}
+		}
+	}
+	return 0, false
+}
+
+// The writer encodes the operations of the delta.
+type deltaWriter struct {
+	writer    *bufio.Writer
+	blockSize int
+	literals  []byte
+	state     DeltaProgress
+	progress  func(DeltaProgress)
+}
+
+func (delta *deltaWriter) copy(index int, size int) error {
+	err := delta.flushLiterals()
+	if err == nil {
+		operation := binary.BigEndian.AppendUint64([]byte{deltaCopy}, uint64(index))
+		if _, err = delta.writer.Write(operation); err == nil {
+			delta.state.Read += int64(size)
+			delta.state.Matched += int64(size)
+			delta.report()
+		}
+	}
+	return err
+}
+
+func (delta *deltaWriter) literal(data []byte) error {
+	delta.literals = append(delta.literals, data...)
+	if len(delta.literals) >= delta.blockSize {
+		return delta.flushLiterals()
+	}
+	return nil
+}
+
+func (delta *deltaWriter) flushLiterals() error {
+	if len(delta.literals) == 0 {
+		return nil
+	}
+
+	operation := binary.BigEndian.AppendUint32([]byte{deltaLiteral}, uint32(len(delta.literals)))
+	_, err := delta.writer.Write(operation)
+	if err == nil {
+		if _, err = delta.writer.Write(delta.literals); err == nil {
+			delta.state.Read += int64(len(delta.literals))
+			delta.literals = delta.literals[:0]
+			delta.report()
+		}
+	}
+	return err
+}
+
+func (delta *deltaWriter) flush() error {
+	err := delta.flushLiterals()
+	if err == nil {
+		err = delta.writer.Flush()
+	}
+	return err
+}
+
+func (delta *deltaWriter) report() {
+	if delta.progress != nil {
+		delta.progress(delta.state)
+	}
+}
+
+// Reads the delta and writes the file which is built from the blocks of the base file and the data of the delta.
+// Returns ErrInvalidArgument if the delta is broken or refers to the block outside of the base file.
+func ApplyDelta(base io.ReaderAt, blockSize int, reader io.Reader, writer io.Writer) (int64, error) {
+	written := int64(0)
+	delta := bufio.NewReaderSize(reader, maxBlockSize)
+	header := make([]byte, 8)
+	for {
+		operation, err := delta.ReadByte()
+		if errors.Is(err, io.EOF) {
+			return written, nil
+		} else if err != nil {
+			return written, err
+		}
+
+		var count int64
+		switch operation {
+		case deltaCopy:
+			if _, err = io.ReadFull(delta, header); err == nil {
+				count, err = copyBlock(base, blockSize, binary.BigEndian.Uint64(header), writer)
+			}
+		case deltaLiteral:
+			if _, err = io.ReadFull(delta, header[:4]); err == nil {
+				count, err = io.CopyN(writer, delta, int64(binary.BigEndian.Uint32(header[:4])))
+			}
+		default:
+			err = fmt.Errorf("%w: delta operation [%d] is unknown", ErrInvalidArgument, operation)
+		}
+
+		written += count
+		if errors.Is(err, io.EOF) {
+			err = fmt.Errorf("%w: delta is truncated", ErrInvalidArgument)
+		}
+		if err != nil {
+			return written, err
+		}
+	}
+}
+
+// The function copies the block of the base file to the writer.
+// Only the last block of the base file is shorter than the block size, the block past the end is the error.
+func copyBlock(base io.ReaderAt, blockSize int, index uint64, writer io.Writer) (int64, error) {
+	if blockSize <= 0 || index >= uint64(math.MaxInt64/int64(blockSize)) {
+		return 0, fmt.Errorf("%w: block [%d] is outside of the base file", ErrInvalidArgument, index)
+	}
+
+	count, err := io.Copy(writer, io.NewSectionReader(base, int64(index)*int64(blockSize), int64(blockSize)))
+	if err == nil && count == 0 {
+		err = fmt.Errorf("%w: block [%d] is outside of the base file", ErrInvalidArgument, index)
+	}
+	return count, err
+}
+
+// Returns signatures of the blocks of remote file.
+func (file *RemoteFile) Signature(client transport.TransportSender, blockSize int) (*FileSignature, error) {
+	endpoint := file.Host.Endpoints().FileSignature
+	params := []string{
+		endpoint.FileId, string(file.Info.Id),
+		endpoint.BlockSize, strconv.Itoa(blockSize),
+	}
+	req, err := client.NewRequest(file.Host.IP, endpoint.Name, params, nil, nil)
+	if err == nil {
+		var res transport.Response
+		if res, err = send(client, req); err == nil {
+			signature := &FileSignature{}
+			if _, err = res.Body(signature); err == nil {
+				return signature, nil
+			}
+		}
+	}
+	return nil, err
+}
+
+// Sends the delta from the reader to remote file, the host builds the new file from its blocks and the delta.
+// The file is replaced only if the whole delta is applied. The data of the reader is compressed by the codec.
+func (file *RemoteFile) WriteDelta(client transport.TransportSender, reader io.Reader, blockSize int, codec transport.Codec) error {
+	endpoint := file.Host.Endpoints().FileDelta
+	params := []string{
+		endpoint.FileId, string(file.Info.Id),
+		endpoint.BlockSize, strconv.Itoa(blockSize),
+	}
+	if codec != transport.CodecNone {
+		params = append(params, endpoint.Codec, string(codec))
+	}
+	req, err := client.NewStreamRequest(file.Host.IP, endpoint.Name, params, reader)
+	if err == nil {
+		var res transport.StreamResponse
+		if res, err = sendStream(client, req); err == nil {
+			err = res.Close()
+		}
+	}
+	return err
+}
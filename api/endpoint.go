@@ -8,6 +8,34 @@ type FileInfoEndpoint struct {
 type FileWriteEndpoint struct {
 	Name   string
 	FileId string
+	Offset string
+	Codec  string
+}
+
+type FileReadEndpoint struct {
+	Name   string
+	FileId string
+	Offset string
+	Size   string
+}
+
+type FileExtractEndpoint struct {
+	Name   string
+	FileId string
+	Codec  string
+}
+
+type FileSignatureEndpoint struct {
+	Name      string
+	FileId    string
+	BlockSize string
+}
+
+type FileDeltaEndpoint struct {
+	Name      string
+	FileId    string
+	BlockSize string
+	Codec     string
 }
 
 type FileRemoveEndpoint struct {
@@ -15,6 +43,12 @@ type FileRemoveEndpoint struct {
 	FileId string
 }
 
+type FileRenameEndpoint struct {
+	Name    string
+	FileId  string
+	NewName string
+}
+
 type FileCopyStatusEndpoint struct {
 	Name   string
 	TaskId string
@@ -25,38 +59,150 @@ type FileCopyCancelEndpoint struct {
 	TaskId string
 }
 
+type FileCopyPauseEndpoint struct {
+	Name   string
+	TaskId string
+}
+
+type FileCopyResumeEndpoint struct {
+	Name   string
+	TaskId string
+}
+
+type FileCopyLimitEndpoint struct {
+	Name   string
+	TaskId string
+	Limit  string
+}
+
 type FileChildrenEndpoint struct {
 	Name   string
 	FileId string
 }
 
+type FileSearchEndpoint struct {
+	Name    string
+	FileId  string
+	Pattern string
+	Regexp  string
+	Type    string
+	MinSize string
+	MaxSize string
+	After   string
+	Before  string
+	Limit   string
+}
+
+type FileGrepEndpoint struct {
+	Name       string
+	FileId     string
+	Pattern    string
+	Regexp     string
+	IgnoreCase string
+	Include    string
+	MaxSize    string
+	Limit      string
+}
+
+type VolumeInfoEndpoint struct {
+	Name   string
+	FileId string
+}
+
 type FileCreateEndpoint struct {
 	Name    string
 	Replace string
 }
 
-var Endpoints = struct {
+// The list of the netfs API endpoints.
+type EndpointList struct {
 	ServerHost     string
 	ServerStop     string
 	FileInfo       FileInfoEndpoint
 	FileCreate     FileCreateEndpoint
 	FileWrite      FileWriteEndpoint
+	FileRead       FileReadEndpoint
+	FileExtract    FileExtractEndpoint
+	FileSignature  FileSignatureEndpoint
+	FileDelta      FileDeltaEndpoint
 	FileRemove     FileRemoveEndpoint
+	FileRename     FileRenameEndpoint
 	FileCopy       string
 	FileCopyStart  string
 	FileCopyStatus FileCopyStatusEndpoint
 	FileCopyCancel FileCopyCancelEndpoint
+	FileCopyPause  FileCopyPauseEndpoint
+	FileCopyResume FileCopyResumeEndpoint
+	TaskHistory    string
+	FileCopyLimit  FileCopyLimitEndpoint
 	FileChildren   FileChildrenEndpoint
-}{
+	FileSearch     FileSearchEndpoint
+	FileGrep       FileGrepEndpoint
+	VolumeInfo     VolumeInfoEndpoint
+}
+
+// Endpoints of the first version of the netfs API, every endpoint is called by POST.
+var Endpoints = EndpointList{
 	ServerHost:     "/netfs/api/server/host",
 	ServerStop:     "/netfs/api/server/stop",
 	FileInfo:       FileInfoEndpoint{Name: "/netfs/api/file/info", FileId: "fileId"},
 	FileCreate:     FileCreateEndpoint{Name: "/netfs/api/file/create", Replace: "replace"},
-	FileWrite:      FileWriteEndpoint{Name: "/netfs/api/file/write", FileId: "fileId"},
+	FileWrite:      FileWriteEndpoint{Name: "/netfs/api/file/write", FileId: "fileId", Offset: "offset", Codec: "codec"},
+	FileRead:       FileReadEndpoint{Name: "/netfs/api/file/read", FileId: "fileId", Offset: "offset", Size: "size"},
+	FileExtract:    FileExtractEndpoint{Name: "/netfs/api/file/extract", FileId: "fileId", Codec: "codec"},
+	FileSignature:  FileSignatureEndpoint{Name: "/netfs/api/file/signature", FileId: "fileId", BlockSize: "blockSize"},
+	FileDelta:      FileDeltaEndpoint{Name: "/netfs/api/file/delta", FileId: "fileId", BlockSize: "blockSize", Codec: "codec"},
 	FileRemove:     FileRemoveEndpoint{Name: "/netfs/api/file/remove", FileId: "fileId"},
+	FileRename:     FileRenameEndpoint{Name: "/netfs/api/file/rename", FileId: "fileId", NewName: "name"},
 	FileCopy:       "/netfs/api/file/copy/all",
 	FileCopyStart:  "/netfs/api/file/copy/start",
 	FileCopyStatus: FileCopyStatusEndpoint{Name: "/netfs/api/file/copy/status", TaskId: "id"},
 	FileCopyCancel: FileCopyCancelEndpoint{Name: "/netfs/api/file/copy/cancel", TaskId: "id"},
+	FileCopyPause:  FileCopyPauseEndpoint{Name: "/netfs/api/file/copy/pause", TaskId: "id"},
+	FileCopyResume: FileCopyResumeEndpoint{Name: "/netfs/api/file/copy/resume", TaskId: "id"},
+	TaskHistory:    "/netfs/api/file/copy/history",
+	FileCopyLimit:  FileCopyLimitEndpoint{Name: "/netfs/api/file/copy/limit", TaskId: "id", Limit: "limit"},
 	FileChildren:   FileChildrenEndpoint{Name: "/netfs/api/file/children", FileId: "fileId"},
+	FileSearch:     FileSearchEndpoint{Name: "/netfs/api/file/search", FileId: "fileId", Pattern: "pattern", Regexp: "regexp", Type: "type", MinSize: "minSize", MaxSize: "maxSize", After: "after", Before: "before", Limit: "limit"},
+	FileGrep:       FileGrepEndpoint{Name: "/netfs/api/file/grep", FileId: "fileId", Pattern: "pattern", Regexp: "regexp", IgnoreCase: "ignoreCase", Include: "include", MaxSize: "maxSize", Limit: "limit"},
+	VolumeInfo:     VolumeInfoEndpoint{Name: "/netfs/api/volume/info", FileId: "fileId"},
+}
+
+// The API version of the EndpointsV2 list.
+const apiVersionV2 = 2
+
+// Endpoints of the REST API, the HTTP method is the prefix of the endpoint.
+var EndpointsV2 = EndpointList{
+	ServerHost:     "GET /netfs/api/v2/server/host",
+	ServerStop:     "DELETE /netfs/api/v2/server",
+	FileInfo:       FileInfoEndpoint{Name: "GET /netfs/api/v2/file", FileId: "fileId"},
+	FileCreate:     FileCreateEndpoint{Name: "PUT /netfs/api/v2/file", Replace: "replace"},
+	FileWrite:      FileWriteEndpoint{Name: "POST /netfs/api/v2/file/data", FileId: "fileId", Offset: "offset", Codec: "codec"},
+	FileRead:       FileReadEndpoint{Name: "GET /netfs/api/v2/file/data", FileId: "fileId", Offset: "offset", Size: "size"},
+	FileExtract:    FileExtractEndpoint{Name: "POST /netfs/api/v2/file/archive", FileId: "fileId", Codec: "codec"},
+	FileSignature:  FileSignatureEndpoint{Name: "GET /netfs/api/v2/file/signature", FileId: "fileId", BlockSize: "blockSize"},
+	FileDelta:      FileDeltaEndpoint{Name: "POST /netfs/api/v2/file/delta", FileId: "fileId", BlockSize: "blockSize", Codec: "codec"},
+	FileRemove:     FileRemoveEndpoint{Name: "DELETE /netfs/api/v2/file", FileId: "fileId"},
+	FileRename:     FileRenameEndpoint{Name: "PATCH /netfs/api/v2/file", FileId: "fileId", NewName: "name"},
+	FileCopy:       "GET /netfs/api/v2/copy",
+	FileCopyStart:  "POST /netfs/api/v2/copy",
+	FileCopyStatus: FileCopyStatusEndpoint{Name: "GET /netfs/api/v2/copy/task", TaskId: "id"},
+	FileCopyCancel: FileCopyCancelEndpoint{Name: "DELETE /netfs/api/v2/copy/task", TaskId: "id"},
+	FileCopyPause:  FileCopyPauseEndpoint{Name: "PUT /netfs/api/v2/copy/task/pause", TaskId: "id"},
+	FileCopyResume: FileCopyResumeEndpoint{Name: "DELETE /netfs/api/v2/copy/task/pause", TaskId: "id"},
+	TaskHistory:    "GET /netfs/api/v2/copy/history",
+	FileCopyLimit:  FileCopyLimitEndpoint{Name: "PUT /netfs/api/v2/copy/task/limit", TaskId: "id", Limit: "limit"},
+	FileChildren:   FileChildrenEndpoint{Name: "GET /netfs/api/v2/file/children", FileId: "fileId"},
+	FileSearch:     FileSearchEndpoint{Name: "GET /netfs/api/v2/file/search", FileId: "fileId", Pattern: "pattern", Regexp: "regexp", Type: "type", MinSize: "minSize", MaxSize: "maxSize", After: "after", Before: "before", Limit: "limit"},
+	FileGrep:       FileGrepEndpoint{Name: "GET /netfs/api/v2/file/grep", FileId: "fileId", Pattern: "pattern", Regexp: "regexp", IgnoreCase: "ignoreCase", Include: "include", MaxSize: "maxSize", Limit: "limit"},
+	VolumeInfo:     VolumeInfoEndpoint{Name: "GET /netfs/api/v2/volume", FileId: "fileId"},
+}
+
+// The function returns endpoints of the newest API version supported by both the host and the current API.
+// The first version is used if the host does not support the second one.
+func (host RemoteHost) Endpoints() *EndpointList {
+	if capabilities := host.Capabilities; capabilities != nil && capabilities.ApiVersion >= apiVersionV2 && capabilities.MinApiVersion <= apiVersionV2 {
+		return &EndpointsV2
+	}
+	return &Endpoints
 }
@@ -0,0 +1,108 @@
+package api
+
+import (
+	"errors"
+	"netfs/api/transport"
+)
+
+// The catalog of the errors which are transferred between hosts, the key is the code of the error.
+var errorCatalog = map[string]*transport.Error{}
+
+// Returns if the file does not exist.
+var ErrFileNotFound = registerError(transport.KindNotFound, "FILE_NOT_FOUND", "file not found")
+
+// Returns if the file already exists.
+var ErrFileAlreadyExists = registerError(transport.KindConflict, "FILE_ALREADY_EXISTS", "file already exists")
+
+// Returns if the access to the file is denied.
+var ErrAccessDenied = registerError(transport.KindForbidden, "ACCESS_DENIED", "access denied")
+
+// Returns if the target volume can't hold the copied data.
+var ErrNotEnoughSpace = registerError(transport.KindInsufficientStorage, "NOT_ENOUGH_SPACE", "not enough space")
+
+// Returns if the task does not exist.
+var ErrTaskNotFound = registerError(transport.KindNotFound, "TASK_NOT_FOUND", "task not found")
+
+// Returns if the host can't start a new task.
+var ErrTooManyActiveTasks = registerError(transport.KindUnavailable, "TOO_MANY_ACTIVE_TASKS", "too many active tasks")
+
+// Returns if the host uses an incompatible version of the netfs API.
+var ErrIncompatibleHost = registerError(transport.KindBadRequest, "INCOMPATIBLE_HOST", "incompatible host")
+
+// Returns if the request contains an incorrect value.
+var ErrInvalidArgument = registerError(transport.KindBadRequest, "INVALID_ARGUMENT", "invalid argument")
+
+// Returns if the request has no required parameter or the parameter is incorrect.
+var ErrBadRequest = registerError(transport.KindBadRequest, transport.CodeBadRequest, "bad request")
+
+// Returns if the host failed with an error without its own code.
+var ErrInternal = registerError(transport.KindInternal, transport.CodeInternal, "internal error")
+
+// Adds a new error to the catalog.
+func registerError(kind transport.ErrorKind, code string, message string) *transport.Error {
+	err := transport.NewError(kind, code, message)
+	errorCatalog[code] = err
+	return err
+}
+
+// Returns the error of the catalog by its code or nil if the code is unknown.
+func ErrorByCode(code string) error {
+	if err, ok := errorCatalog[code]; ok {
+		return err
+	}
+	return nil
+}
+
+// The error received from the remote host.
+// The error wraps the error of the catalog with the same code, so errors.Is can be used on the client.
+type RemoteError struct {
+	Code    string
+	Message string
+}
+
+// Returns the message of the error.
+func (err *RemoteError) Error() string {
+	return err.Message
+}
+
+// Returns the error of the catalog with the same code.
+func (err *RemoteError) Unwrap() error {
+	return ErrorByCode(err.Code)
+}
+
+// Converts any error to the error which can be transferred to another host.
+func ToRemoteError(err error) *RemoteError {
+	if err == nil {
+		return nil
+	}
+
+	coded := transport.ToError(err)
+	return &RemoteError{Code: coded.Code, Message: coded.Message}
+}
+
+// Reconstructs the error received from the remote host by its code.
+func receivedError(err error) error {
+	var received *transport.Error
+	if errors.As(err, &received) {
+		return &RemoteError{Code: received.Code, Message: received.Message}
+	}
+	return err
+}
+
+// Sends the request and reconstructs the received error by its code.
+func send(client transport.TransportSender, req transport.Request) (transport.Response, error) {
+	res, err := client.Send(req)
+	if err != nil {
+		return nil, receivedError(err)
+	}
+	return res, nil
+}
+
+// Sends the streaming request and reconstructs the received error by its code.
+func sendStream(client transport.TransportSender, req transport.Request) (transport.StreamResponse, error) {
+	res, err := client.SendStream(req)
+	if err != nil {
+		return nil, receivedError(err)
+	}
+	return res, nil
+}
@@ -1,13 +1,20 @@
 package api
 
 import (
+	"errors"
+	"fmt"
+	"io"
 	"netfs/api/transport"
 	"strconv"
 	"strings"
+	"time"
 )
 
 var units = [5]string{"B", "KB", "MB", "GB", "TB"}
 
+// Maximum length of the file name in bytes, most of the file systems don't support longer names.
+const maxFileNameLength = 255
+
 // Type of file.
 type FileType byte
 
@@ -59,6 +66,8 @@ type FileInfo struct {
 	Type     FileType
 	Size     FileSize
 	ParentId FileId
+	// The last modification time, zero if the host doesn't report it.
+	ModTime time.Time
 }
 
 // File on a remote host.
@@ -67,16 +76,35 @@ type RemoteFile struct {
 	Host RemoteHost
 }
 
+// Returns ErrInvalidArgument if the name can't be the name of the file in its directory.
+// The name can't be empty, a link to the directory or contain a path separator.
+func CheckFileName(name string) error {
+	switch {
+	case strings.TrimSpace(name) == "":
+		return fmt.Errorf("%w: name is empty", ErrInvalidArgument)
+	case name == "." || name == "..":
+		return fmt.Errorf("%w: name [%s] is reserved", ErrInvalidArgument, name)
+	case strings.ContainsAny(name, "/\\\x00"):
+		return fmt.Errorf("%w: name [%s] contains a path separator or a zero byte", ErrInvalidArgument, name)
+	case len(name) > maxFileNameLength:
+		return fmt.Errorf("%w: name is longer than %d bytes", ErrInvalidArgument, maxFileNameLength)
+	}
+	return nil
+}
+
 // Returns children of the directory.
 func (file *RemoteFile) Children(client transport.TransportSender) ([]RemoteFile, error) {
+	endpoint := file.Host.Endpoints().FileChildren
 	params := []string{
-		Endpoints.FileChildren.FileId, string(file.Info.Id),
+		endpoint.FileId, string(file.Info.Id),
 	}
 
-	req, err := client.NewRequest(file.Host.IP, Endpoints.FileChildren.Name, params, nil, nil)
+	req, err := client.NewRequest(file.Host.IP, endpoint.Name, params, nil, nil)
 	if err == nil {
-		var res transport.Response
-		if res, err = client.Send(req); err == nil {
+		var res transport.StreamResponse
+		if res, err = sendStream(client, req); err == nil {
+			defer res.Close()
+
 			files := []FileInfo{}
 			if _, err = res.Body(&files); err == nil {
 				result := make([]RemoteFile, len(files))
@@ -92,24 +120,198 @@ func (file *RemoteFile) Children(client transport.TransportSender) ([]RemoteFile
 
 // Writes data to remote file.
 func (file *RemoteFile) Write(client transport.TransportSender, data []byte) error {
+	endpoint := file.Host.Endpoints().FileWrite
 	params := []string{
-		Endpoints.FileWrite.FileId, string(file.Info.Id),
+		endpoint.FileId, string(file.Info.Id),
 	}
-	req, err := client.NewRequest(file.Host.IP, Endpoints.FileWrite.Name, params, data, nil)
+	req, err := client.NewRequest(file.Host.IP, endpoint.Name, params, data, nil)
 	if err == nil {
-		_, err = client.Send(req)
+		_, err = send(client, req)
+	}
+	return err
+}
+
+// Writes data from the reader to remote file without buffering.
+func (file *RemoteFile) WriteFrom(client transport.TransportSender, reader io.Reader) error {
+	endpoint := file.Host.Endpoints().FileWrite
+	params := []string{
+		endpoint.FileId, string(file.Info.Id),
+	}
+	req, err := client.NewStreamRequest(file.Host.IP, endpoint.Name, params, reader)
+	if err == nil {
+		var res transport.StreamResponse
+		if res, err = sendStream(client, req); err == nil {
+			err = res.Close()
+		}
+	}
+	return err
+}
+
+// Sends the tar archive from the reader to remote directory, the host extracts the archive into the directory.
+// The files of the archive appear in the directory only if the whole archive is extracted.
+// The data of the reader is compressed by the codec, the codec must be supported by the host.
+func (file *RemoteFile) Extract(client transport.TransportSender, reader io.Reader, codec transport.Codec) error {
+	endpoint := file.Host.Endpoints().FileExtract
+	params := []string{
+		endpoint.FileId, string(file.Info.Id),
+	}
+	if codec != transport.CodecNone {
+		params = append(params, endpoint.Codec, string(codec))
+	}
+	req, err := client.NewStreamRequest(file.Host.IP, endpoint.Name, params, reader)
+	if err == nil {
+		var res transport.StreamResponse
+		if res, err = sendStream(client, req); err == nil {
+			err = res.Close()
+		}
 	}
 	return err
 }
 
-// Copies the current file to the target file.
+// Opens the channel which writes data to the end of remote file by one stream.
+// The channel must be closed to finish writing, the host keeps the file opened until then.
+func (file *RemoteFile) Writer(client transport.TransportSender) (transport.Channel, error) {
+	endpoint := file.Host.Endpoints().FileWrite
+	params := []string{
+		endpoint.FileId, string(file.Info.Id),
+	}
+	channel, err := client.OpenChannel(file.Host.IP, endpoint.Name, params)
+	if err == nil {
+		return &fileChannel{channel}, nil
+	}
+	return nil, err
+}
+
+// Opens the channel which writes data to remote file starting at the offset.
+// The host must support FeatureRangeWrite, several channels may write different ranges of the file concurrently.
+func (file *RemoteFile) WriterAt(client transport.TransportSender, offset int64) (transport.Channel, error) {
+	endpoint := file.Host.Endpoints().FileWrite
+	params := []string{
+		endpoint.FileId, string(file.Info.Id),
+		endpoint.Offset, strconv.FormatInt(offset, decimalBase),
+	}
+	channel, err := client.OpenChannel(file.Host.IP, endpoint.Name, params)
+	if err == nil {
+		return &fileChannel{channel}, nil
+	}
+	return nil, err
+}
+
+// Opens the channel which compresses data by the codec and writes it to remote file starting at the offset.
+// If the offset is negative, the data is written to the end of the file.
+// The codec must be supported by the host, the channel reports the size of the data before and after compression.
+func (file *RemoteFile) CompressedWriter(client transport.TransportSender, offset int64, codec transport.Codec) (*transport.CompressedChannel, error) {
+	endpoint := file.Host.Endpoints().FileWrite
+	params := []string{
+		endpoint.FileId, string(file.Info.Id),
+	}
+	if offset >= 0 {
+		params = append(params, endpoint.Offset, strconv.FormatInt(offset, decimalBase))
+	}
+	if codec != transport.CodecNone {
+		params = append(params, endpoint.Codec, string(codec))
+	}
+
+	channel, err := client.OpenChannel(file.Host.IP, endpoint.Name, params)
+	if err == nil {
+		var compressed *transport.CompressedChannel
+		if compressed, err = transport.CompressChannel(&fileChannel{channel}, codec); err == nil {
+			return compressed, nil
+		}
+		channel.Abort(err)
+	}
+	return nil, err
+}
+
+// The channel reconstructs the received error by its code.
+type fileChannel struct {
+	channel transport.Channel
+}
+
+func (channel *fileChannel) Write(data []byte) (int, error) {
+	count, err := channel.channel.Write(data)
+	return count, receivedError(err)
+}
+
+func (channel *fileChannel) Close() error {
+	return receivedError(channel.channel.Close())
+}
+
+func (channel *fileChannel) Abort(err error) {
+	channel.channel.Abort(err)
+}
+
+// Reads the range of remote file, if the size is zero, the file is read to the end.
+// The returned reader must be closed.
+func (file *RemoteFile) Read(client transport.TransportSender, offset int64, size int64) (io.ReadCloser, error) {
+	endpoint := file.Host.Endpoints().FileRead
+	params := []string{
+		endpoint.FileId, string(file.Info.Id),
+		endpoint.Offset, strconv.FormatInt(offset, decimalBase),
+		endpoint.Size, strconv.FormatInt(size, decimalBase),
+	}
+	req, err := client.NewRequest(file.Host.IP, endpoint.Name, params, nil, nil)
+	if err == nil {
+		var res transport.StreamResponse
+		if res, err = sendStream(client, req); err == nil {
+			return &streamReader{res: res}, nil
+		}
+	}
+	return nil, err
+}
+
+// The reader of the streaming response.
+type streamReader struct {
+	res transport.StreamResponse
+}
+
+func (reader *streamReader) Read(data []byte) (int, error) {
+	count, err := reader.res.Reader().Read(data)
+	if err != nil && !errors.Is(err, io.EOF) {
+		err = receivedError(err)
+	}
+	return count, err
+}
+
+func (reader *streamReader) Close() error {
+	return reader.res.Close()
+}
+
+// Copies the current file to the target file, the existing target file is replaced.
 func (file *RemoteFile) CopyTo(client transport.TransportSender, target RemoteFile) (*RemoteCopyTask, error) {
-	task := &RemoteCopyTask{Source: *file, Target: target}
+	return file.CopyWith(client, target, CopyOptions{Replace: true})
+}
+
+// Moves the current file to the target file, the current file is removed after the successful copying.
+// If the target file exists and replace is false, ErrFileAlreadyExists is returned.
+// If the source host does not support FeatureMove, ErrIncompatibleHost is returned, the host would only copy the file.
+func (file *RemoteFile) MoveTo(client transport.TransportSender, target RemoteFile, replace bool) (*RemoteCopyTask, error) {
+	if !file.Host.Supports(FeatureMove) {
+		return nil, fmt.Errorf("%w: host [%s] does not support [%s]", ErrIncompatibleHost, file.Host.Name, FeatureMove)
+	}
+	return file.CopyWith(client, target, CopyOptions{Replace: replace, Move: true})
+}
+
+// Copies the current file to the target file with the options.
+// If the target file exists and the options do not replace it, ErrFileAlreadyExists is returned.
+func (file *RemoteFile) CopyWith(client transport.TransportSender, target RemoteFile, options CopyOptions) (*RemoteCopyTask, error) {
+	task := &RemoteCopyTask{Source: *file, Target: target, Host: file.Host, CopyOptions: options}
+
+	// The host without capabilities always replaces the target, so the target is checked before copying.
+	if !options.Replace && file.Host.Capabilities == nil {
+		targetId := target.Info.Id
+		if targetId == "" {
+			targetId = FileId(target.Info.Path)
+		}
+		if _, exists := target.Host.File(client, targetId); exists == nil {
+			return nil, fmt.Errorf("%w: %s", ErrFileAlreadyExists, target.Info.Path)
+		}
+	}
 
-	req, err := client.NewRequest(file.Host.IP, Endpoints.FileCopyStart, nil, nil, *task)
+	req, err := client.NewRequest(file.Host.IP, file.Host.Endpoints().FileCopyStart, nil, nil, *task)
 	if err == nil {
 		var res transport.Response
-		if res, err = client.Send(req); err == nil {
+		if res, err = send(client, req); err == nil {
 			if _, err = res.Body(task); err == nil {
 				return task, nil
 			}
@@ -120,12 +322,34 @@ func (file *RemoteFile) CopyTo(client transport.TransportSender, target RemoteFi
 
 // Removes the file from the remote host.
 func (file *RemoteFile) Remove(client transport.TransportSender) error {
+	endpoint := file.Host.Endpoints().FileRemove
 	params := []string{
-		Endpoints.FileRemove.FileId, string(file.Info.Id),
+		endpoint.FileId, string(file.Info.Id),
 	}
-	req, err := client.NewRequest(file.Host.IP, Endpoints.FileRemove.Name, params, nil, nil)
+	req, err := client.NewRequest(file.Host.IP, endpoint.Name, params, nil, nil)
 	if err == nil {
-		_, err = client.Send(req)
+		_, err = send(client, req)
 	}
 	return err
 }
+
+// Renames the file in its directory, the renamed file is returned.
+// The host must support FeatureRename, the file with the same name must not exist.
+func (file *RemoteFile) Rename(client transport.TransportSender, name string) (*RemoteFile, error) {
+	endpoint := file.Host.Endpoints().FileRename
+	params := []string{
+		endpoint.FileId, string(file.Info.Id),
+		endpoint.NewName, name,
+	}
+	req, err := client.NewRequest(file.Host.IP, endpoint.Name, params, nil, nil)
+	if err == nil {
+		var res transport.Response
+		if res, err = send(client, req); err == nil {
+			info := &FileInfo{}
+			if _, err = res.Body(info); err == nil {
+				return &RemoteFile{Info: *info, Host: file.Host}, nil
+			}
+		}
+	}
+	return nil, err
+}
@@ -0,0 +1,141 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"netfs/api/transport"
+	"strconv"
+)
+
+// The conditions of the content search.
+type GrepQuery struct {
+	// The searched text, it's the regular expression if Regexp is true.
+	Pattern    string
+	Regexp     bool
+	IgnoreCase bool
+	// The shell pattern of the names of the searched files, see filepath.Match. Empty means all files.
+	Include string
+	// The larger files are skipped, zero means the limit of the host.
+	MaxSize FileSize
+	// Maximum count of the found lines, zero means the limit of the host.
+	Limit int
+}
+
+// The line of the file which contains the searched text.
+type GrepMatch struct {
+	File FileInfo
+	// The host of the file, it isn't transferred and is set by the receiver.
+	Host RemoteHost `json:"-"`
+	// The number of the line, starting from 1.
+	Line int
+	// The text of the line, the too long line is cut.
+	Text string
+}
+
+// The running content search, the lines are received while the host reads the files.
+type FileGrep struct {
+	host    RemoteHost
+	res     transport.StreamResponse
+	decoder *json.Decoder
+}
+
+// Returns the next found line, io.EOF is returned after the last line.
+func (grep *FileGrep) Next() (*GrepMatch, error) {
+	match := GrepMatch{}
+	err := grep.decoder.Decode(&match)
+	if err == nil {
+		match.Host = grep.host
+		return &match, nil
+	}
+	return nil, err
+}
+
+// Stops the search, the host stops reading the files after the search is closed.
+func (grep *FileGrep) Close() error {
+	return grep.res.Close()
+}
+
+// Returns all found lines, the search is closed after the last line.
+func (grep *FileGrep) All() ([]GrepMatch, error) {
+	defer grep.Close()
+
+	matches := []GrepMatch{}
+	for {
+		match, err := grep.Next()
+		if errors.Is(err, io.EOF) {
+			return matches, nil
+		} else if err != nil {
+			return matches, err
+		}
+		matches = append(matches, *match)
+	}
+}
+
+// Starts the search of the text in the files of the directory and all its subdirectories, the binary files are skipped.
+// The host must support FeatureGrep, the search must be closed.
+func (file *RemoteFile) Grep(client transport.TransportSender, query GrepQuery) (*FileGrep, error) {
+	endpoint := file.Host.Endpoints().FileGrep
+	params := []string{
+		endpoint.FileId, string(file.Info.Id),
+		endpoint.Pattern, query.Pattern,
+		endpoint.Regexp, strconv.FormatBool(query.Regexp),
+		endpoint.IgnoreCase, strconv.FormatBool(query.IgnoreCase),
+	}
+	if query.Include != "" {
+		params = append(params, endpoint.Include, query.Include)
+	}
+	if query.MaxSize > 0 {
+		params = append(params, endpoint.MaxSize, strconv.FormatInt(int64(query.MaxSize), decimalBase))
+	}
+	if query.Limit > 0 {
+		params = append(params, endpoint.Limit, strconv.Itoa(query.Limit))
+	}
+
+	req, err := client.NewRequest(file.Host.IP, endpoint.Name, params, nil, nil)
+	if err == nil {
+		var res transport.StreamResponse
+		if res, err = sendStream(client, req); err == nil {
+			return &FileGrep{host: file.Host, res: res, decoder: json.NewDecoder(&streamReader{res: res})}, nil
+		}
+	}
+	return nil, err
+}
+
+// The result of the content search on one host.
+type HostGrepResult struct {
+	Host    RemoteHost
+	Matches []GrepMatch
+	Error   error
+}
+
+// Searches the text in all shared directories of the hosts concurrently.
+// The hosts which don't support FeatureGrep are skipped, the results are returned in the order of the hosts.
+func (network *Network) Grep(hosts []RemoteHost, query GrepQuery) []HostGrepResult {
+	supported := []RemoteHost{}
+	for _, host := range hosts {
+		if host.Supports(FeatureGrep) {
+			supported = append(supported, host)
+		}
+	}
+
+	results := make([]HostGrepResult, len(supported))
+	done := make(chan struct{})
+	for index, host := range supported {
+		go func(index int, host RemoteHost) {
+			defer func() { done <- struct{}{} }()
+
+			results[index].Host = host
+			grep, err := host.Root().Grep(network.client, query)
+			if err == nil {
+				results[index].Matches, err = grep.All()
+			}
+			results[index].Error = err
+		}(index, host)
+	}
+
+	for range supported {
+		<-done
+	}
+	return results
+}
@@ -1,17 +1,114 @@
 package api
 
 import (
+	"fmt"
 	"net"
 	"netfs/api/transport"
+	"slices"
 	"strconv"
+	"time"
 )
 
 const rootDirectory = "/"
 
+// The version of the netfs API.
+const ApiVersion = 2
+
+// The oldest version of the netfs API which is compatible with the current one.
+const MinApiVersion = 1
+
+// Optional feature of the host.
+type HostFeature string
+
+const (
+	// The host copies files to other hosts.
+	FeatureCopy HostFeature = "copy"
+	// The host reports information about its volumes.
+	FeatureVolume HostFeature = "volume"
+	// The host reads files by ranges.
+	FeatureRead HostFeature = "read"
+	// The host receives file data by the data channel.
+	FeatureChannel HostFeature = "channel"
+	// The host writes file data at the offset.
+	FeatureRangeWrite HostFeature = "range-write"
+	// The host extracts the tar archive of many files into the directory.
+	FeatureArchive HostFeature = "archive"
+	// The host returns block signatures of files and applies deltas to them.
+	FeatureDelta HostFeature = "delta"
+	// The host removes the source file of the copy task after the successful copying.
+	FeatureMove HostFeature = "move"
+	// The host searches files by name, size and modification time.
+	FeatureSearch HostFeature = "search"
+	// The host searches the content of text files.
+	FeatureGrep HostFeature = "grep"
+	// The host renames files in their directories.
+	FeatureRename HostFeature = "rename"
+	// The host pauses and resumes tasks and keeps the completed tasks.
+	FeatureTaskControl HostFeature = "task-control"
+)
+
+// Space of the root directory.
+type RootSpace struct {
+	Path  string
+	Total FileSize
+	Free  FileSize
+}
+
+// Capabilities of the host.
+type HostCapabilities struct {
+	Version       string
+	ApiVersion    int
+	MinApiVersion int
+	OS            string
+	Arch          string
+	Protocols     []transport.TransportProtocol
+	Features      []HostFeature
+	Codecs        []transport.Codec
+	Roots         []RootSpace
+	Uptime        time.Duration
+}
+
 // Information about host.
 type RemoteHost struct {
-	Name string
-	IP   net.IP
+	Name         string
+	IP           net.IP
+	Capabilities *HostCapabilities
+}
+
+// The function returns the server version of the host or an empty string for the host without capabilities.
+func (host RemoteHost) Version() string {
+	if host.Capabilities != nil {
+		return host.Capabilities.Version
+	}
+	return ""
+}
+
+// The function returns true if the host supports the feature.
+// The host without capabilities supports only the basic operations.
+func (host RemoteHost) Supports(feature HostFeature) bool {
+	return host.Capabilities != nil && slices.Contains(host.Capabilities.Features, feature)
+}
+
+// The function returns the most preferable codec supported by the host and the current transport.
+// The host without capabilities receives uncompressed data.
+func (host RemoteHost) Codec() transport.Codec {
+	if host.Capabilities != nil {
+		return transport.NegotiateCodec(host.Capabilities.Codecs)
+	}
+	return transport.CodecNone
+}
+
+// The function returns an error if the host can't be used with the current API.
+// The host without capabilities is an older build and is used without optional features.
+// The host is compatible if its range of API versions intersects the range of the current API.
+func (host RemoteHost) Compatible() error {
+	if capabilities := host.Capabilities; capabilities != nil && (capabilities.ApiVersion < MinApiVersion || capabilities.MinApiVersion > ApiVersion) {
+		return fmt.Errorf(
+			"%w: host [%s] supports API versions [%d-%d], current API version is [%d]",
+			ErrIncompatibleHost, host.Name, capabilities.MinApiVersion, capabilities.ApiVersion, ApiVersion,
+		)
+	}
+	return nil
 }
 
 // The function returns the root directory of the remote host.
@@ -21,14 +118,15 @@ func (host *RemoteHost) Root() *RemoteFile {
 
 // The function creates a file or directory on the remote host.
 func (host *RemoteHost) Create(client transport.TransportSender, info FileInfo, replace bool) (*RemoteFile, error) {
+	endpoint := host.Endpoints().FileCreate
 	params := []string{
-		Endpoints.FileCreate.Replace, strconv.FormatBool(replace),
+		endpoint.Replace, strconv.FormatBool(replace),
 	}
 
-	req, err := client.NewRequest(host.IP, Endpoints.FileCreate.Name, params, nil, info)
+	req, err := client.NewRequest(host.IP, endpoint.Name, params, nil, info)
 	if err == nil {
 		var res transport.Response
-		if res, err = client.Send(req); err == nil {
+		if res, err = send(client, req); err == nil {
 			info := &FileInfo{}
 			if _, err = res.Body(info); err == nil {
 				return &RemoteFile{Info: *info, Host: *host}, nil
@@ -40,13 +138,14 @@ func (host *RemoteHost) Create(client transport.TransportSender, info FileInfo,
 
 // The function returns information about a file by id.
 func (host *RemoteHost) File(client transport.TransportSender, fileId FileId) (*RemoteFile, error) {
+	endpoint := host.Endpoints().FileInfo
 	params := []string{
-		Endpoints.FileInfo.FileId, string(fileId),
+		endpoint.FileId, string(fileId),
 	}
-	req, err := client.NewRequest(host.IP, Endpoints.FileInfo.Name, params, nil, nil)
+	req, err := client.NewRequest(host.IP, endpoint.Name, params, nil, nil)
 	if err == nil {
 		var res transport.Response
-		if res, err = client.Send(req); err == nil {
+		if res, err = send(client, req); err == nil {
 			info := &FileInfo{}
 			if _, err = res.Body(info); err == nil {
 				return &RemoteFile{Info: *info, Host: *host}, nil
@@ -58,10 +157,26 @@ func (host *RemoteHost) File(client transport.TransportSender, fileId FileId) (*
 
 // The function returns information about all tasks.
 func (host RemoteHost) Tasks(client transport.TransportSender) ([]RemoteCopyTask, error) {
-	req, err := client.NewRequest(host.IP, Endpoints.FileCopy, nil, nil, nil)
+	req, err := client.NewRequest(host.IP, host.Endpoints().FileCopy, nil, nil, nil)
 	if err == nil {
 		var res transport.Response
-		if res, err = client.Send(req); err == nil {
+		if res, err = send(client, req); err == nil {
+			tasks := []RemoteCopyTask{}
+			if _, err = res.Body(&tasks); err == nil {
+				return tasks, nil
+			}
+		}
+	}
+	return nil, err
+}
+
+// The function returns information about all tasks kept by the host, including the completed and cancelled ones.
+// The host must support FeatureTaskControl.
+func (host RemoteHost) TaskHistory(client transport.TransportSender) ([]RemoteCopyTask, error) {
+	req, err := client.NewRequest(host.IP, host.Endpoints().TaskHistory, nil, nil, nil)
+	if err == nil {
+		var res transport.Response
+		if res, err = send(client, req); err == nil {
 			tasks := []RemoteCopyTask{}
 			if _, err = res.Body(&tasks); err == nil {
 				return tasks, nil
@@ -73,12 +188,13 @@ func (host RemoteHost) Tasks(client transport.TransportSender) ([]RemoteCopyTask
 
 // The function returns information about a task by id.
 func (host RemoteHost) Task(client transport.TransportSender, taskId TaskId) (*RemoteCopyTask, error) {
-	params := []string{Endpoints.FileCopyStatus.TaskId, string(taskId)}
-	req, err := client.NewRequest(host.IP, Endpoints.FileCopyStatus.Name, params, nil, nil)
+	endpoint := host.Endpoints().FileCopyStatus
+	params := []string{endpoint.TaskId, string(taskId)}
+	req, err := client.NewRequest(host.IP, endpoint.Name, params, nil, nil)
 
 	if err == nil {
 		var res transport.Response
-		if res, err = client.Send(req); err == nil {
+		if res, err = send(client, req); err == nil {
 			task := &RemoteCopyTask{}
 			if _, err = res.Body(task); err == nil {
 				return task, nil
@@ -87,3 +203,34 @@ func (host RemoteHost) Task(client transport.TransportSender, taskId TaskId) (*R
 	}
 	return nil, err
 }
+
+// The tasks of one host.
+type HostTasksResult struct {
+	Host  RemoteHost
+	Tasks []RemoteCopyTask
+	Error error
+}
+
+// Receives the tasks of the hosts concurrently, the results are returned in the order of the hosts.
+// The history of the tasks is received from the hosts which support FeatureTaskControl, only the active tasks from others.
+func (network *Network) Tasks(hosts []RemoteHost) []HostTasksResult {
+	results := make([]HostTasksResult, len(hosts))
+	done := make(chan struct{})
+	for index, host := range hosts {
+		go func(index int, host RemoteHost) {
+			defer func() { done <- struct{}{} }()
+
+			results[index].Host = host
+			if host.Supports(FeatureTaskControl) {
+				results[index].Tasks, results[index].Error = host.TaskHistory(network.client)
+			} else {
+				results[index].Tasks, results[index].Error = host.Tasks(network.client)
+			}
+		}(index, host)
+	}
+
+	for range hosts {
+		<-done
+	}
+	return results
+}
@@ -59,10 +59,12 @@ func (network *Network) Host(ip net.IP) (*RemoteHost, error) {
 	req, err := network.client.NewRequest(ip, Endpoints.ServerHost, nil, nil, nil)
 	if err == nil {
 		var res transport.Response
-		if res, err = network.client.Send(req); err == nil {
+		if res, err = send(network.client, req); err == nil {
 			host := &RemoteHost{}
 			if _, err = res.Body(host); err == nil {
-				return host, nil
+				if err = host.Compatible(); err == nil {
+					return host, nil
+				}
 			}
 		}
 	}
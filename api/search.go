@@ -0,0 +1,108 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"netfs/api/transport"
+	"strconv"
+	"time"
+)
+
+// The conditions of the file search, the empty fields are not checked.
+type SearchQuery struct {
+	// The shell pattern of the file name, see filepath.Match.
+	Pattern string
+	// The regular expression of the file name.
+	Regexp string
+	// The type of the found files, zero means files and directories.
+	Type FileType
+	// The size range of the found files, zero means the range is not limited by this side.
+	MinSize FileSize
+	MaxSize FileSize
+	// The range of the modification time of the found files.
+	After  time.Time
+	Before time.Time
+	// Maximum count of the found files, zero means the limit of the host.
+	Limit int
+}
+
+// The running search, the files are received while the host walks the directory.
+type FileSearch struct {
+	host    RemoteHost
+	res     transport.StreamResponse
+	decoder *json.Decoder
+}
+
+// Returns the next found file, io.EOF is returned after the last file.
+func (search *FileSearch) Next() (*RemoteFile, error) {
+	info := FileInfo{}
+	err := search.decoder.Decode(&info)
+	if err == nil {
+		return &RemoteFile{Info: info, Host: search.host}, nil
+	}
+	return nil, err
+}
+
+// Stops the search, the host stops walking the directory after the search is closed.
+func (search *FileSearch) Close() error {
+	return search.res.Close()
+}
+
+// Starts the search of the files in the directory and all its subdirectories.
+// The host must support FeatureSearch, the search must be closed.
+func (file *RemoteFile) Search(client transport.TransportSender, query SearchQuery) (*FileSearch, error) {
+	endpoint := file.Host.Endpoints().FileSearch
+	params := []string{
+		endpoint.FileId, string(file.Info.Id),
+	}
+	if query.Pattern != "" {
+		params = append(params, endpoint.Pattern, query.Pattern)
+	}
+	if query.Regexp != "" {
+		params = append(params, endpoint.Regexp, query.Regexp)
+	}
+	if query.Type != 0 {
+		params = append(params, endpoint.Type, query.Type.String())
+	}
+	if query.MinSize > 0 {
+		params = append(params, endpoint.MinSize, strconv.FormatInt(int64(query.MinSize), decimalBase))
+	}
+	if query.MaxSize > 0 {
+		params = append(params, endpoint.MaxSize, strconv.FormatInt(int64(query.MaxSize), decimalBase))
+	}
+	if !query.After.IsZero() {
+		params = append(params, endpoint.After, query.After.Format(time.RFC3339Nano))
+	}
+	if !query.Before.IsZero() {
+		params = append(params, endpoint.Before, query.Before.Format(time.RFC3339Nano))
+	}
+	if query.Limit > 0 {
+		params = append(params, endpoint.Limit, strconv.Itoa(query.Limit))
+	}
+
+	req, err := client.NewRequest(file.Host.IP, endpoint.Name, params, nil, nil)
+	if err == nil {
+		var res transport.StreamResponse
+		if res, err = sendStream(client, req); err == nil {
+			return &FileSearch{host: file.Host, res: res, decoder: json.NewDecoder(&streamReader{res: res})}, nil
+		}
+	}
+	return nil, err
+}
+
+// Returns all found files, the search is closed after the last file.
+func (search *FileSearch) All() ([]RemoteFile, error) {
+	defer search.Close()
+
+	files := []RemoteFile{}
+	for {
+		file, err := search.Next()
+		if errors.Is(err, io.EOF) {
+			return files, nil
+		} else if err != nil {
+			return files, err
+		}
+		files = append(files, *file)
+	}
+}
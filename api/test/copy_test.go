@@ -2,6 +2,7 @@ package api_test
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
 	"netfs/api"
 	"netfs/api/transport"
@@ -34,3 +35,74 @@ func TestCancelSuccess(t *testing.T) {
 		t.Fatalf("error should be nil, but error is [%s]", err)
 	}
 }
+
+func TestSetLimitSuccess(t *testing.T) {
+	beforeEach()
+	defer afterEach()
+
+	var limit string
+	rec.Receive(api.Endpoints.FileCopyLimit.Name, func(req transport.Request) ([]byte, any, error) {
+		var err error
+		limit, err = req.ParamRequired(api.Endpoints.FileCopyLimit.Limit)
+		return nil, nil, err
+	})
+
+	host, _ := network.Host(local.IP)
+	task := api.RemoteCopyTask{Id: api.TaskId("1"), Status: api.Running, Host: *host}
+	if err := task.SetLimit(network.Transport(), 1048576); err != nil {
+		t.Fatalf("error should be nil, but error is [%s]", err)
+	}
+	if limit != "1048576" || task.Limit != 1048576 {
+		t.Fatalf("limit should be [1048576], but received limit is [%s], task limit is [%d]", limit, task.Limit)
+	}
+}
+
+func TestPauseResumeSuccess(t *testing.T) {
+	beforeEach()
+	defer afterEach()
+
+	received := []string{}
+	rec.Receive(api.Endpoints.FileCopyPause.Name, func(req transport.Request) ([]byte, any, error) {
+		id, err := req.ParamRequired(api.Endpoints.FileCopyPause.TaskId)
+		received = append(received, "pause "+id)
+		return nil, nil, err
+	})
+	rec.Receive(api.Endpoints.FileCopyResume.Name, func(req transport.Request) ([]byte, any, error) {
+		id, err := req.ParamRequired(api.Endpoints.FileCopyResume.TaskId)
+		received = append(received, "resume "+id)
+		return nil, nil, err
+	})
+
+	host, _ := network.Host(local.IP)
+	task := api.RemoteCopyTask{Id: api.TaskId("1"), Status: api.Running, Host: *host}
+	if err := task.Pause(network.Transport()); err != nil || !task.Paused {
+		t.Fatalf("task should be paused, but error is [%v]", err)
+	}
+	if err := task.Resume(network.Transport()); err != nil || task.Paused {
+		t.Fatalf("task should be resumed, but error is [%v]", err)
+	}
+	if len(received) != 2 || received[0] != "pause 1" || received[1] != "resume 1" {
+		t.Fatalf("pause and resume should be received, but received is [%v]", received)
+	}
+}
+
+func TestRetryErrInvalidArgument(t *testing.T) {
+	for _, status := range []api.TaskStatus{api.Running, api.Completed} {
+		task := api.RemoteCopyTask{Id: api.TaskId("1"), Status: status}
+		if _, err := task.Retry(nil); !errors.Is(err, api.ErrInvalidArgument) {
+			t.Fatalf("error should be [api.ErrInvalidArgument], but error is [%v]", err)
+		}
+	}
+}
+
+func TestTaskEstimates(t *testing.T) {
+	task := api.RemoteCopyTask{Status: api.Running, Progress: 25, Size: 4000, Elapsed: time.Second}
+	if task.Copied() != 1000 || task.Throughput() != 1000 || task.Remaining() != 3*time.Second {
+		t.Fatalf("estimates are incorrect, copied [%d], throughput [%f], remaining [%s]", task.Copied(), task.Throughput(), task.Remaining())
+	}
+
+	task.Status = api.Completed
+	if task.Remaining() != 0 {
+		t.Fatalf("remaining time of the completed task should be zero, but it's [%s]", task.Remaining())
+	}
+}
@@ -0,0 +1,97 @@
+package api_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"math"
+	"math/rand"
+	"netfs/api"
+	"testing"
+)
+
+const testBlockSize = 4096
+
+func TestDeltaSuccess(t *testing.T) {
+	base := make([]byte, 256*testBlockSize+123)
+	rand.New(rand.NewSource(1)).Read(base)
+
+	// The source differs by the changed block, the inserted data and the appended data.
+	source := bytes.Clone(base)
+	copy(source[10*testBlockSize:], bytes.Repeat([]byte("CHANGED"), 100))
+	source = append(source[:100*testBlockSize+7], append([]byte("INSERTED"), source[100*testBlockSize+7:]...)...)
+	source = append(source, []byte("APPENDED")...)
+
+	signature, err := api.NewSignature(bytes.NewReader(base), testBlockSize)
+	if err != nil {
+		t.Fatalf("error should be nil, but err is [%s]", err)
+	}
+
+	delta := &bytes.Buffer{}
+	progress := api.DeltaProgress{}
+	err = api.WriteDelta(bytes.NewReader(source), signature, delta, func(current api.DeltaProgress) { progress = current })
+	if err != nil {
+		t.Fatalf("error should be nil, but err is [%s]", err)
+	}
+	if progress.Read != int64(len(source)) {
+		t.Fatalf("read size should be [%d], but size is [%d]", len(source), progress.Read)
+	}
+	if progress.Matched < int64(len(base)-4*testBlockSize) {
+		t.Fatalf("matched size should be at least [%d], but size is [%d]", len(base)-4*testBlockSize, progress.Matched)
+	}
+	if delta.Len() > 4*testBlockSize+len(signature.Blocks)*9 {
+		t.Fatalf("delta size should be small, but size is [%d]", delta.Len())
+	}
+
+	result := &bytes.Buffer{}
+	if _, err = api.ApplyDelta(bytes.NewReader(base), testBlockSize, delta, result); err != nil {
+		t.Fatalf("error should be nil, but err is [%s]", err)
+	}
+	if !bytes.Equal(result.Bytes(), source) {
+		t.Fatalf("result should be equal to the source, result size is [%d], source size is [%d]", result.Len(), len(source))
+	}
+}
+
+func TestDeltaEmptyBase(t *testing.T) {
+	source := bytes.Repeat([]byte("TEST_DATA"), 10000)
+	signature, _ := api.NewSignature(bytes.NewReader(nil), testBlockSize)
+
+	delta := &bytes.Buffer{}
+	if err := api.WriteDelta(bytes.NewReader(source), signature, delta, nil); err != nil {
+		t.Fatalf("error should be nil, but err is [%s]", err)
+	}
+
+	result := &bytes.Buffer{}
+	if _, err := api.ApplyDelta(bytes.NewReader(nil), testBlockSize, delta, result); err != nil {
+		t.Fatalf("error should be nil, but err is [%s]", err)
+	}
+	if !bytes.Equal(result.Bytes(), source) {
+		t.Fatalf("result should be equal to the source, result size is [%d], source size is [%d]", result.Len(), len(source))
+	}
+}
+
+func TestApplyDeltaErrInvalidArgument(t *testing.T) {
+	result := &bytes.Buffer{}
+	_, err := api.ApplyDelta(bytes.NewReader(nil), testBlockSize, bytes.NewReader([]byte{100}), result)
+	if !errors.Is(err, api.ErrInvalidArgument) {
+		t.Fatalf("error should be [api.ErrInvalidArgument], but err is [%v]", err)
+	}
+
+	_, err = api.ApplyDelta(bytes.NewReader(nil), testBlockSize, bytes.NewReader([]byte{2, 0, 0, 0, 10, 1}), result)
+	if !errors.Is(err, api.ErrInvalidArgument) {
+		t.Fatalf("error should be [api.ErrInvalidArgument], but err is [%v]", err)
+	}
+
+	base := bytes.NewReader(make([]byte, testBlockSize+1))
+	for _, index := range []uint64{2, math.MaxUint64} {
+		operation := binary.BigEndian.AppendUint64([]byte{1}, index)
+		_, err = api.ApplyDelta(base, testBlockSize, bytes.NewReader(operation), result)
+		if !errors.Is(err, api.ErrInvalidArgument) {
+			t.Fatalf("error of block [%d] should be [api.ErrInvalidArgument], but err is [%v]", index, err)
+		}
+	}
+	_, err = api.ApplyDelta(base, testBlockSize, bytes.NewReader([]byte{1, 0, 0, 0, 0, 0, 0, 0, 1}), result)
+	if err != nil {
+		t.Fatalf("error of the last block should be nil, but err is [%s]", err)
+	}
+}
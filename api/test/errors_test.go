@@ -0,0 +1,126 @@
+package api_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"netfs/api"
+	"netfs/api/transport"
+	"testing"
+)
+
+func TestRestFileNotFound(t *testing.T) {
+	beforeEach()
+	defer afterEach()
+
+	local.Capabilities = &api.HostCapabilities{ApiVersion: api.ApiVersion, MinApiVersion: api.MinApiVersion}
+	rec.Receive(api.EndpointsV2.FileInfo.Name, func(req transport.Request) ([]byte, any, error) {
+		return nil, nil, api.ErrFileNotFound
+	})
+
+	host, _ := network.Host(local.IP)
+	_, err := host.File(network.Transport(), testFileId)
+	if !errors.Is(err, api.ErrFileNotFound) {
+		t.Fatalf("error should be [api.ErrFileNotFound], but error is [%s]", err)
+	}
+}
+
+func TestRestFileAlreadyExists(t *testing.T) {
+	beforeEach()
+	defer afterEach()
+
+	local.Capabilities = &api.HostCapabilities{ApiVersion: api.ApiVersion, MinApiVersion: api.MinApiVersion}
+	rec.Receive(api.EndpointsV2.FileCreate.Name, func(req transport.Request) ([]byte, any, error) {
+		return nil, nil, api.ErrFileAlreadyExists
+	})
+
+	host, _ := network.Host(local.IP)
+	_, err := host.Create(network.Transport(), api.FileInfo{Path: testFileName, Type: api.FILE}, false)
+	if !errors.Is(err, api.ErrFileAlreadyExists) {
+		t.Fatalf("error should be [api.ErrFileAlreadyExists], but error is [%s]", err)
+	}
+}
+
+func TestRestInternalError(t *testing.T) {
+	beforeEach()
+	defer afterEach()
+
+	local.Capabilities = &api.HostCapabilities{ApiVersion: api.ApiVersion, MinApiVersion: api.MinApiVersion}
+	rec.Receive(api.EndpointsV2.FileRemove.Name, func(req transport.Request) ([]byte, any, error) {
+		return nil, nil, errors.New("can't submit request")
+	})
+
+	host, _ := network.Host(local.IP)
+	file := api.RemoteFile{Host: *host, Info: api.FileInfo{Id: testFileId}}
+	err := file.Remove(network.Transport())
+
+	received := &transport.Error{}
+	if !errors.As(err, &received) || received.Kind != transport.KindInternal {
+		t.Fatalf("error should be internal [transport.Error], but error is [%s]", err)
+	}
+}
+
+func TestErrorCatalogRoundTrip(t *testing.T) {
+	beforeEach()
+	defer afterEach()
+
+	catalog := []error{
+		api.ErrFileNotFound,
+		api.ErrFileAlreadyExists,
+		api.ErrAccessDenied,
+		api.ErrNotEnoughSpace,
+		api.ErrTaskNotFound,
+		api.ErrTooManyActiveTasks,
+		api.ErrIncompatibleHost,
+		api.ErrInvalidArgument,
+	}
+
+	var current error
+	rec.Receive(api.Endpoints.FileRemove.Name, func(req transport.Request) ([]byte, any, error) {
+		return nil, nil, fmt.Errorf("%w: details", current)
+	})
+
+	host, _ := network.Host(local.IP)
+	file := api.RemoteFile{Host: *host, Info: api.FileInfo{Id: testFileId}}
+	for _, expected := range catalog {
+		current = expected
+		err := file.Remove(network.Transport())
+		if !errors.Is(err, expected) {
+			t.Fatalf("error should be [%s], but error is [%s]", expected, err)
+		}
+	}
+}
+
+func TestErrorBadRequest(t *testing.T) {
+	beforeEach()
+	defer afterEach()
+
+	rec.Receive(api.Endpoints.FileRemove.Name, func(req transport.Request) ([]byte, any, error) {
+		_, err := req.ParamRequired("unknown")
+		return nil, nil, err
+	})
+
+	host, _ := network.Host(local.IP)
+	file := api.RemoteFile{Host: *host, Info: api.FileInfo{Id: testFileId}}
+	err := file.Remove(network.Transport())
+	if !errors.Is(err, api.ErrBadRequest) {
+		t.Fatalf("error should be [api.ErrBadRequest], but error is [%s]", err)
+	}
+}
+
+func TestTaskErrorSerialization(t *testing.T) {
+	task := api.RemoteCopyTask{Id: "1", Status: api.Failed, Error: api.ToRemoteError(api.ErrNotEnoughSpace)}
+
+	data, err := json.Marshal(task)
+	if err != nil {
+		t.Fatalf("error should be nil, but error is [%s]", err)
+	}
+
+	received := api.RemoteCopyTask{}
+	if err = json.Unmarshal(data, &received); err != nil {
+		t.Fatalf("error should be nil, but error is [%s]", err)
+	}
+	if !errors.Is(received.Error, api.ErrNotEnoughSpace) {
+		t.Fatalf("task error should be [api.ErrNotEnoughSpace], but error is [%s]", received.Error)
+	}
+}
@@ -1,7 +1,13 @@
 package api_test
 
 import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"io"
+	"net/http"
 	"netfs/api"
 	"netfs/api/transport"
 	"testing"
@@ -87,6 +93,53 @@ func TestCopyToResponseError(t *testing.T) {
 	}
 }
 
+func TestCopyWithErrFileAlreadyExists(t *testing.T) {
+	beforeEach()
+	defer afterEach()
+
+	started := false
+	rec.Receive(api.Endpoints.FileCopyStart, func(transport.Request) ([]byte, any, error) {
+		started = true
+		return nil, api.RemoteCopyTask{Id: api.TaskId("1"), Status: api.Running, Host: local}, nil
+	})
+
+	// The host without capabilities always replaces the target, so the existing target is checked by the client.
+	host, _ := network.Host(local.IP)
+	file, _ := host.File(network.Transport(), testFileId)
+	target := api.RemoteFile{Host: *host, Info: api.FileInfo{Path: "./test_file_1.txt"}}
+	if _, err := file.CopyWith(network.Transport(), target, api.CopyOptions{}); !errors.Is(err, api.ErrFileAlreadyExists) {
+		t.Fatalf("error should be [api.ErrFileAlreadyExists], but error is [%v]", err)
+	}
+	if started {
+		t.Fatal("copying should not be started")
+	}
+}
+
+func TestMoveToErrIncompatibleHost(t *testing.T) {
+	beforeEach()
+	defer afterEach()
+
+	started := false
+	rec.Receive(api.Endpoints.FileCopyStart, func(transport.Request) ([]byte, any, error) {
+		started = true
+		return nil, api.RemoteCopyTask{Id: api.TaskId("1"), Status: api.Running, Host: local}, nil
+	})
+
+	// The host which does not support moving would only copy the file.
+	known, _ := network.Host(local.IP)
+	for _, capabilities := range []*api.HostCapabilities{nil, {ApiVersion: 1, MinApiVersion: 1, Features: []api.HostFeature{api.FeatureCopy}}} {
+		host := api.RemoteHost{Name: known.Name, IP: known.IP, Capabilities: capabilities}
+		file := api.RemoteFile{Host: host, Info: api.FileInfo{Id: testFileId, Path: string(testFileId), Type: api.FILE}}
+		target := api.RemoteFile{Host: host, Info: api.FileInfo{Path: "./test_file_1.txt"}}
+		if _, err := file.MoveTo(network.Transport(), target, true); !errors.Is(err, api.ErrIncompatibleHost) {
+			t.Fatalf("error should be [api.ErrIncompatibleHost], but error is [%v]", err)
+		}
+	}
+	if started {
+		t.Fatal("copying should not be started")
+	}
+}
+
 func TestFileRemoveSuccess(t *testing.T) {
 	beforeEach()
 	defer afterEach()
@@ -164,3 +217,322 @@ func TestChildrenResponseError(t *testing.T) {
 		t.Fatal("error should be not nil")
 	}
 }
+
+func TestWriteFromSuccess(t *testing.T) {
+	beforeEach()
+	defer afterEach()
+
+	var received []byte
+	rec.ReceiveStream(api.Endpoints.FileWrite.Name, func(req transport.StreamRequest, writer io.Writer) error {
+		fileId, _ := req.ParamRequired(api.Endpoints.FileWrite.FileId)
+		if api.FileId(fileId) != testFileId {
+			return errors.New("can't submit request")
+		}
+
+		var err error
+		received, err = io.ReadAll(req.Reader())
+		return err
+	})
+
+	host, _ := network.Host(local.IP)
+	file, _ := host.File(network.Transport(), testFileId)
+	err := file.WriteFrom(network.Transport(), bytes.NewReader([]byte("TEST")))
+	if err != nil {
+		t.Fatalf("error should be nil, but err is [%s]", err)
+	}
+
+	if string(received) != "TEST" {
+		t.Fatalf("received data should be [TEST], but data is [%s]", received)
+	}
+}
+
+func TestExtractErrInvalidArgument(t *testing.T) {
+	beforeEach()
+	defer afterEach()
+
+	rec.ReceiveStream(api.Endpoints.FileExtract.Name, func(req transport.StreamRequest, writer io.Writer) error {
+		io.Copy(io.Discard, req.Reader())
+		return api.ErrInvalidArgument
+	})
+
+	host, _ := network.Host(local.IP)
+	file, _ := host.File(network.Transport(), testFileId)
+	err := file.Extract(network.Transport(), bytes.NewReader([]byte("TEST")), transport.CodecNone)
+	if !errors.Is(err, api.ErrInvalidArgument) {
+		t.Fatalf("error should be [api.ErrInvalidArgument], but err is [%s]", err)
+	}
+}
+
+func TestReadSuccess(t *testing.T) {
+	beforeEach()
+	defer afterEach()
+
+	rec.ReceiveStream(api.Endpoints.FileRead.Name, func(req transport.StreamRequest, writer io.Writer) error {
+		offset, _ := req.ParamUInt64(api.Endpoints.FileRead.Offset)
+		size, _ := req.ParamUInt64(api.Endpoints.FileRead.Size)
+		_, err := writer.Write([]byte("TEST_DATA")[offset : offset+size])
+		return err
+	})
+
+	host, _ := network.Host(local.IP)
+	file, _ := host.File(network.Transport(), testFileId)
+	reader, err := file.Read(network.Transport(), 5, 4)
+	if err != nil {
+		t.Fatalf("error should be nil, but err is [%s]", err)
+	}
+	defer reader.Close()
+
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		t.Fatalf("error should be nil, but err is [%s]", err)
+	}
+	if string(data) != "DATA" {
+		t.Fatalf("data should be [DATA], but data is [%s]", data)
+	}
+}
+
+func TestReadResponseError(t *testing.T) {
+	beforeEach()
+	defer afterEach()
+
+	rec.ReceiveStream(api.Endpoints.FileRead.Name, func(transport.StreamRequest, io.Writer) error {
+		return api.ErrFileNotFound
+	})
+
+	host, _ := network.Host(local.IP)
+	file, _ := host.File(network.Transport(), testFileId)
+	_, err := file.Read(network.Transport(), 0, 0)
+	if !errors.Is(err, api.ErrFileNotFound) {
+		t.Fatalf("error should be [api.ErrFileNotFound], but err is [%s]", err)
+	}
+}
+
+func TestWriterSuccess(t *testing.T) {
+	beforeEach()
+	defer afterEach()
+
+	var received []byte
+	rec.ReceiveStream(api.Endpoints.FileWrite.Name, func(req transport.StreamRequest, writer io.Writer) error {
+		var err error
+		received, err = io.ReadAll(req.Reader())
+		return err
+	})
+
+	host, _ := network.Host(local.IP)
+	file, _ := host.File(network.Transport(), testFileId)
+	writer, err := file.Writer(network.Transport())
+	if err != nil {
+		t.Fatalf("error should be nil, but err is [%s]", err)
+	}
+
+	for range 3 {
+		if _, err = writer.Write([]byte("TEST")); err != nil {
+			t.Fatalf("error should be nil, but err is [%s]", err)
+		}
+	}
+	if err = writer.Close(); err != nil {
+		t.Fatalf("error should be nil, but err is [%s]", err)
+	}
+
+	if string(received) != "TESTTESTTEST" {
+		t.Fatalf("received data should be [TESTTESTTEST], but data is [%s]", received)
+	}
+}
+
+func TestWriterResponseError(t *testing.T) {
+	beforeEach()
+	defer afterEach()
+
+	rec.ReceiveStream(api.Endpoints.FileWrite.Name, func(transport.StreamRequest, io.Writer) error {
+		return api.ErrAccessDenied
+	})
+
+	host, _ := network.Host(local.IP)
+	file, _ := host.File(network.Transport(), testFileId)
+	writer, _ := file.Writer(network.Transport())
+	writer.Write([]byte("TEST"))
+
+	if err := writer.Close(); !errors.Is(err, api.ErrAccessDenied) {
+		t.Fatalf("error should be [api.ErrAccessDenied], but err is [%s]", err)
+	}
+}
+
+func TestWriterAtSuccess(t *testing.T) {
+	beforeEach()
+	defer afterEach()
+
+	var offset uint64
+	rec.ReceiveStream(api.Endpoints.FileWrite.Name, func(req transport.StreamRequest, writer io.Writer) error {
+		var err error
+		if offset, err = req.ParamUInt64(api.Endpoints.FileWrite.Offset); err == nil {
+			_, err = io.Copy(io.Discard, req.Reader())
+		}
+		return err
+	})
+
+	host, _ := network.Host(local.IP)
+	file, _ := host.File(network.Transport(), testFileId)
+	writer, err := file.WriterAt(network.Transport(), 1024)
+	if err != nil {
+		t.Fatalf("error should be nil, but err is [%s]", err)
+	}
+	writer.Write([]byte("TEST"))
+	if err = writer.Close(); err != nil {
+		t.Fatalf("error should be nil, but err is [%s]", err)
+	}
+
+	if offset != 1024 {
+		t.Fatalf("offset should be [1024], but offset is [%d]", offset)
+	}
+}
+
+func TestCompressedWriterSuccess(t *testing.T) {
+	beforeEach()
+	defer afterEach()
+
+	var received []byte
+	rec.ReceiveStream(api.Endpoints.FileWrite.Name, func(req transport.StreamRequest, writer io.Writer) error {
+		reader, err := transport.Decompress(req.Reader(), transport.Codec(req.Param(api.Endpoints.FileWrite.Codec)))
+		if err == nil {
+			defer reader.Close()
+			received, err = io.ReadAll(reader)
+		}
+		return err
+	})
+
+	data := bytes.Repeat([]byte("TEST_DATA"), 100000)
+	for _, codec := range transport.Codecs {
+		host, _ := network.Host(local.IP)
+		file, _ := host.File(network.Transport(), testFileId)
+		writer, err := file.CompressedWriter(network.Transport(), -1, codec)
+		if err != nil {
+			t.Fatalf("error should be nil, but err is [%s]", err)
+		}
+		writer.Write(data)
+		if err = writer.Close(); err != nil {
+			t.Fatalf("error should be nil, but err is [%s]", err)
+		}
+
+		if !bytes.Equal(received, data) {
+			t.Fatalf("[%s] received data length should be [%d], but length is [%d]", codec, len(data), len(received))
+		}
+		if writer.Bytes() != int64(len(data)) || writer.Sent() >= writer.Bytes() {
+			t.Fatalf("[%s] data should be compressed, but bytes are [%d], sent bytes are [%d]", codec, writer.Bytes(), writer.Sent())
+		}
+	}
+}
+
+func TestChildrenCompressedResponse(t *testing.T) {
+	beforeEach()
+	defer afterEach()
+
+	files := make([]api.FileInfo, 1000)
+	for index := range files {
+		files[index] = api.FileInfo{Id: testFileId, Name: testFileName}
+	}
+	rec.Receive(api.Endpoints.FileChildren.Name, func(transport.Request) ([]byte, any, error) {
+		return nil, files, nil
+	})
+
+	host, _ := network.Host(local.IP)
+	file, _ := host.File(network.Transport(), testFileId)
+	children, err := file.Children(network.Transport())
+	if err != nil {
+		t.Fatalf("error should be nil, but err is [%s]", err)
+	}
+	if len(children) != len(files) {
+		t.Fatalf("children count should be [%d], but count is [%d]", len(files), len(children))
+	}
+}
+
+func TestChildrenCompressedStream(t *testing.T) {
+	beforeEach()
+	defer afterEach()
+
+	files := make([]api.FileInfo, 1000)
+	for index := range files {
+		files[index] = api.FileInfo{Id: testFileId, Name: testFileName}
+	}
+	rec.ReceiveStream(api.Endpoints.FileChildren.Name, func(req transport.StreamRequest, writer io.Writer) error {
+		return json.NewEncoder(writer).Encode(files)
+	})
+
+	host, _ := network.Host(local.IP)
+	file, _ := host.File(network.Transport(), testFileId)
+	children, err := file.Children(network.Transport())
+	if err != nil {
+		t.Fatalf("error should be nil, but err is [%s]", err)
+	}
+	if len(children) != len(files) {
+		t.Fatalf("children count should be [%d], but count is [%d]", len(files), len(children))
+	}
+
+	endpoint := fmt.Sprintf("http://127.0.0.1:%d%s", config.Port, api.Endpoints.FileChildren.Name)
+	httpReq, _ := http.NewRequest(http.MethodGet, endpoint, nil)
+	httpReq.Header.Set("Accept-Encoding", string(transport.CodecGzip))
+	httpRes, err := http.DefaultClient.Do(httpReq)
+	if err != nil {
+		t.Fatalf("error should be nil, but err is [%s]", err)
+	}
+	defer httpRes.Body.Close()
+
+	if encoding := httpRes.Header.Get("Content-Encoding"); encoding != string(transport.CodecGzip) {
+		t.Fatalf("response should be compressed by [%s], but encoding is [%s]", transport.CodecGzip, encoding)
+	}
+	reader, _ := gzip.NewReader(httpRes.Body)
+	listed := []api.FileInfo{}
+	if err = json.NewDecoder(reader).Decode(&listed); err != nil || len(listed) != len(files) {
+		t.Fatalf("children count should be [%d], but count is [%d], err is [%v]", len(files), len(listed), err)
+	}
+}
+
+func TestRenameSuccess(t *testing.T) {
+	beforeEach()
+	defer afterEach()
+
+	rec.Receive(api.Endpoints.FileRename.Name, func(req transport.Request) ([]byte, any, error) {
+		fileId, _ := req.ParamRequired(api.Endpoints.FileRename.FileId)
+		name, _ := req.ParamRequired(api.Endpoints.FileRename.NewName)
+		if api.FileId(fileId) != testFileId {
+			return nil, nil, api.ErrFileNotFound
+		}
+		return nil, api.FileInfo{Id: api.FileId("/" + name), Name: name, Type: api.FILE}, nil
+	})
+
+	host, _ := network.Host(local.IP)
+	file, _ := host.File(network.Transport(), testFileId)
+	renamed, err := file.Rename(network.Transport(), testFileName)
+	if err != nil {
+		t.Fatalf("error should be nil, but err is [%s]", err)
+	}
+	if renamed.Info.Name != testFileName || !renamed.Host.IP.Equal(host.IP) {
+		t.Fatalf("renamed file should be [%s], but file is [%s]", testFileName, renamed.Info.Name)
+	}
+}
+
+func TestRenameResponseError(t *testing.T) {
+	beforeEach()
+	defer afterEach()
+
+	rec.Receive(api.Endpoints.FileRename.Name, func(transport.Request) ([]byte, any, error) {
+		return nil, nil, api.ErrFileAlreadyExists
+	})
+
+	host, _ := network.Host(local.IP)
+	file, _ := host.File(network.Transport(), testFileId)
+	_, err := file.Rename(network.Transport(), testFileName)
+	if !errors.Is(err, api.ErrFileAlreadyExists) {
+		t.Fatalf("error should be [api.ErrFileAlreadyExists], but err is [%v]", err)
+	}
+}
+
+func TestCheckFileName(t *testing.T) {
+	if err := api.CheckFileName(testFileName); err != nil {
+		t.Fatalf("error should be nil, but err is [%s]", err)
+	}
+	for _, name := range []string{"", " ", ".", "..", "dir/file", `dir\file`, string(make([]byte, 256))} {
+		if err := api.CheckFileName(name); !errors.Is(err, api.ErrInvalidArgument) {
+			t.Fatalf("error of name [%q] should be [api.ErrInvalidArgument], but err is [%v]", name, err)
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package api_test
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"netfs/api"
+	"netfs/api/transport"
+	"testing"
+)
+
+func TestGrepSuccess(t *testing.T) {
+	beforeEach()
+	defer afterEach()
+
+	rec.ReceiveStream(api.Endpoints.FileGrep.Name, func(req transport.StreamRequest, writer io.Writer) error {
+		if req.Param(api.Endpoints.FileGrep.Pattern) != "port" || req.Param(api.Endpoints.FileGrep.IgnoreCase) != "true" || req.Param(api.Endpoints.FileGrep.Include) != "*.conf" {
+			return api.ErrInvalidArgument
+		}
+
+		encoder := json.NewEncoder(writer)
+		for line := range 2 {
+			if err := encoder.Encode(api.GrepMatch{File: api.FileInfo{Id: "/test.conf", Name: "test.conf"}, Line: line + 1, Text: "port"}); err != nil {
+				return err
+			}
+			transport.Flush(writer)
+		}
+		return nil
+	})
+
+	host, _ := network.Host(local.IP)
+	file, _ := host.File(network.Transport(), testFileId)
+	grep, err := file.Grep(network.Transport(), api.GrepQuery{Pattern: "port", IgnoreCase: true, Include: "*.conf"})
+	if err != nil {
+		t.Fatalf("error should be nil, but err is [%s]", err)
+	}
+
+	matches, err := grep.All()
+	if err != nil {
+		t.Fatalf("error should be nil, but err is [%s]", err)
+	}
+	if len(matches) != 2 || matches[1].Line != 2 || matches[1].File.Name != "test.conf" || !matches[1].Host.IP.Equal(host.IP) {
+		t.Fatalf("lines [1] and [2] of [test.conf] should be found, but lines are [%v]", matches)
+	}
+}
+
+func TestGrepResponseError(t *testing.T) {
+	beforeEach()
+	defer afterEach()
+
+	rec.ReceiveStream(api.Endpoints.FileGrep.Name, func(transport.StreamRequest, io.Writer) error {
+		return api.ErrInvalidArgument
+	})
+
+	host, _ := network.Host(local.IP)
+	file, _ := host.File(network.Transport(), testFileId)
+	_, err := file.Grep(network.Transport(), api.GrepQuery{Pattern: "("})
+	if !errors.Is(err, api.ErrInvalidArgument) {
+		t.Fatalf("error should be [api.ErrInvalidArgument], but err is [%v]", err)
+	}
+}
+
+func TestNetworkGrepSuccess(t *testing.T) {
+	beforeEach()
+	defer afterEach()
+
+	rec.ReceiveStream(api.Endpoints.FileGrep.Name, func(req transport.StreamRequest, writer io.Writer) error {
+		// The shared directories of the host are searched.
+		if req.Param(api.Endpoints.FileGrep.FileId) != "/" {
+			return api.ErrFileNotFound
+		}
+		return json.NewEncoder(writer).Encode(api.GrepMatch{File: api.FileInfo{Id: "/test.conf", Name: "test.conf"}, Line: 1, Text: "port"})
+	})
+
+	supported := api.RemoteHost{Name: "supported", IP: local.IP, Capabilities: &api.HostCapabilities{Features: []api.HostFeature{api.FeatureGrep}}}
+	unsupported := api.RemoteHost{Name: "unsupported", IP: local.IP, Capabilities: &api.HostCapabilities{}}
+	results := network.Grep([]api.RemoteHost{unsupported, supported, supported}, api.GrepQuery{Pattern: "port"})
+	if len(results) != 2 {
+		t.Fatalf("count of the results should be [2], but results are [%v]", results)
+	}
+	for _, result := range results {
+		if result.Error != nil {
+			t.Fatalf("error should be nil, but err is [%s]", result.Error)
+		}
+		if result.Host.Name != supported.Name || len(result.Matches) != 1 || result.Matches[0].Host.Name != supported.Name {
+			t.Fatalf("the only line of host [%s] should be found, but result is [%v]", supported.Name, result)
+		}
+	}
+}
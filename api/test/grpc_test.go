@@ -0,0 +1,377 @@
+package api_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net"
+	"netfs/api"
+	"netfs/api/transport"
+	"reflect"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/test/bufconn"
+)
+
+const grpcBufferSize = 1048576
+
+var grpcHost = api.RemoteHost{Name: "grpc", IP: net.IPv4(127, 0, 0, 1)}
+
+var grpcReceiver *transport.GrpcTransportReceiver
+var grpcSender *transport.GrpcTransportSender
+
+func beforeEachGrpc() {
+	listener := bufconn.Listen(grpcBufferSize)
+	dialer := func(ctx context.Context, _ string) (net.Conn, error) {
+		return listener.DialContext(ctx)
+	}
+
+	grpcReceiver = transport.NewGrpcReceiver(config.Port, listener)
+	grpcSender = transport.NewGrpcSender(config.Port, config.Timeout, dialer)
+	grpcReceiver.Start()
+}
+
+func afterEachGrpc() {
+	grpcReceiver.Stop()
+}
+
+func TestGrpcFileSuccess(t *testing.T) {
+	beforeEachGrpc()
+	defer afterEachGrpc()
+
+	grpcReceiver.Receive(api.Endpoints.FileInfo.Name, func(req transport.Request) ([]byte, any, error) {
+		fileId, err := req.ParamRequired(api.Endpoints.FileInfo.FileId)
+		return nil, api.FileInfo{Type: api.FILE, Id: api.FileId(fileId)}, err
+	})
+
+	file, err := grpcHost.File(grpcSender, testFileId)
+	if err != nil {
+		t.Fatalf("error should be nil, but err is [%s]", err)
+	}
+	if file.Info.Id != testFileId {
+		t.Fatalf("file id should be [%s], but file id is [%s]", testFileId, file.Info.Id)
+	}
+}
+
+func TestGrpcFileErrFileNotFound(t *testing.T) {
+	beforeEachGrpc()
+	defer afterEachGrpc()
+
+	grpcReceiver.Receive(api.Endpoints.FileInfo.Name, func(transport.Request) ([]byte, any, error) {
+		return nil, nil, api.ErrFileNotFound
+	})
+
+	_, err := grpcHost.File(grpcSender, testFileId)
+	if !errors.Is(err, api.ErrFileNotFound) {
+		t.Fatalf("error should be [api.ErrFileNotFound], but err is [%s]", err)
+	}
+}
+
+func TestGrpcUnknownEndpoint(t *testing.T) {
+	beforeEachGrpc()
+	defer afterEachGrpc()
+
+	_, err := grpcHost.File(grpcSender, testFileId)
+	if !errors.Is(err, transport.ErrUnexpectedAnswer) {
+		t.Fatalf("error should be [transport.ErrUnexpectedAnswer], but err is [%s]", err)
+	}
+}
+
+func TestGrpcWriteFromReadSuccess(t *testing.T) {
+	beforeEachGrpc()
+	defer afterEachGrpc()
+
+	var stored []byte
+	grpcReceiver.ReceiveStream(api.Endpoints.FileWrite.Name, func(req transport.StreamRequest, writer io.Writer) error {
+		var err error
+		stored, err = io.ReadAll(req.Reader())
+		return err
+	})
+	grpcReceiver.ReceiveStream(api.Endpoints.FileRead.Name, func(req transport.StreamRequest, writer io.Writer) error {
+		_, err := writer.Write(stored)
+		return err
+	})
+
+	data := bytes.Repeat([]byte("TEST_DATA"), grpcBufferSize)
+	file := api.RemoteFile{Host: grpcHost, Info: api.FileInfo{Id: testFileId}}
+	if err := file.WriteFrom(grpcSender, bytes.NewReader(data)); err != nil {
+		t.Fatalf("error should be nil, but err is [%s]", err)
+	}
+
+	reader, err := file.Read(grpcSender, 0, 0)
+	if err != nil {
+		t.Fatalf("error should be nil, but err is [%s]", err)
+	}
+	defer reader.Close()
+
+	received, err := io.ReadAll(reader)
+	if err != nil {
+		t.Fatalf("error should be nil, but err is [%s]", err)
+	}
+	if !bytes.Equal(received, data) {
+		t.Fatalf("received data length should be [%d], but length is [%d]", len(data), len(received))
+	}
+}
+
+func TestGrpcWriterSuccess(t *testing.T) {
+	beforeEachGrpc()
+	defer afterEachGrpc()
+
+	var received []byte
+	grpcReceiver.ReceiveStream(api.Endpoints.FileWrite.Name, func(req transport.StreamRequest, writer io.Writer) error {
+		var err error
+		received, err = io.ReadAll(req.Reader())
+		return err
+	})
+
+	file := api.RemoteFile{Host: grpcHost, Info: api.FileInfo{Id: testFileId}}
+	writer, err := file.Writer(grpcSender)
+	if err != nil {
+		t.Fatalf("error should be nil, but err is [%s]", err)
+	}
+
+	for range 3 {
+		writer.Write([]byte("TEST"))
+	}
+	if err = writer.Close(); err != nil {
+		t.Fatalf("error should be nil, but err is [%s]", err)
+	}
+
+	if string(received) != "TESTTESTTEST" {
+		t.Fatalf("received data should be [TESTTESTTEST], but data is [%s]", received)
+	}
+}
+
+func TestGrpcReadErrorAfterWriting(t *testing.T) {
+	beforeEachGrpc()
+	defer afterEachGrpc()
+
+	grpcReceiver.ReceiveStream(api.Endpoints.FileRead.Name, func(req transport.StreamRequest, writer io.Writer) error {
+		writer.Write([]byte("TEST"))
+		return api.ErrAccessDenied
+	})
+
+	file := api.RemoteFile{Host: grpcHost, Info: api.FileInfo{Id: testFileId}}
+	reader, err := file.Read(grpcSender, 0, 0)
+	if err != nil {
+		t.Fatalf("error should be nil, but err is [%s]", err)
+	}
+	defer reader.Close()
+
+	_, err = io.ReadAll(reader)
+	if !errors.Is(err, api.ErrAccessDenied) {
+		t.Fatalf("error should be [api.ErrAccessDenied], but err is [%s]", err)
+	}
+}
+
+func TestGrpcChildrenSuccess(t *testing.T) {
+	beforeEachGrpc()
+	defer afterEachGrpc()
+
+	grpcReceiver.Receive(api.Endpoints.FileChildren.Name, func(transport.Request) ([]byte, any, error) {
+		return nil, []api.FileInfo{{Id: testFileId, Name: testFileName}}, nil
+	})
+
+	file := api.RemoteFile{Host: grpcHost, Info: api.FileInfo{Id: testFileId}}
+	children, err := file.Children(grpcSender)
+	if err != nil {
+		t.Fatalf("error should be nil, but err is [%s]", err)
+	}
+	if len(children) != 1 || children[0].Info.Name != testFileName {
+		t.Fatalf("children should contain [%s], but children are [%v]", testFileName, children)
+	}
+}
+
+func TestGrpcNetworkHostSuccess(t *testing.T) {
+	grpcConfig := api.NetworkConfig{Port: config.Port, Protocol: transport.GRPC, Timeout: time.Second}
+	receiver, _ := transport.NewReceiver(grpcConfig.Protocol, grpcConfig.Port)
+	network, _ := api.NewNetwork(grpcConfig)
+	local := network.LocalHost()
+	receiver.Receive(api.Endpoints.ServerHost, func(transport.Request) ([]byte, any, error) {
+		return nil, local, nil
+	})
+	receiver.Start()
+	defer receiver.Stop()
+
+	host, err := network.Host(local.IP)
+	if err != nil {
+		t.Fatalf("error should be nil, but err is [%s]", err)
+	}
+	if network.Transport().Protocol() != transport.GRPC {
+		t.Fatalf("protocol should be [%d], but protocol is [%d]", transport.GRPC, network.Transport().Protocol())
+	}
+	if host.IP.String() != local.IP.String() {
+		t.Fatalf("[%s] and [%s] should be equals", host.IP.String(), local.IP.String())
+	}
+}
+
+func TestGrpcSearchCancel(t *testing.T) {
+	beforeEachGrpc()
+	defer afterEachGrpc()
+
+	cancelled := make(chan struct{})
+	grpcReceiver.ReceiveStream(api.Endpoints.FileSearch.Name, func(req transport.StreamRequest, writer io.Writer) error {
+		// The started response is returned to the sender before the first file is found.
+		transport.Flush(writer)
+		<-req.Context().Done()
+		close(cancelled)
+		return req.Context().Err()
+	})
+
+	file := api.RemoteFile{Host: grpcHost, Info: api.FileInfo{Id: testFileId}}
+	search, err := file.Search(grpcSender, api.SearchQuery{Pattern: "*"})
+	if err != nil {
+		t.Fatalf("error should be nil, but err is [%s]", err)
+	}
+	search.Close()
+
+	select {
+	case <-cancelled:
+	case <-time.After(5 * time.Second):
+		t.Fatal("the search should be cancelled after closing")
+	}
+}
+
+func TestGrpcMethodEndpoints(t *testing.T) {
+	for _, endpoints := range []api.EndpointList{api.Endpoints, api.EndpointsV2} {
+		value := reflect.ValueOf(endpoints)
+		for index := range value.NumField() {
+			endpoint := value.Field(index)
+			if endpoint.Kind() == reflect.Struct {
+				endpoint = endpoint.FieldByName("Name")
+			}
+			if _, ok := transport.GrpcMethod(endpoint.String()); !ok {
+				t.Fatalf("endpoint [%s] should be served by gRPC method", endpoint.String())
+			}
+		}
+	}
+}
+
+func TestGrpcCopyStartSuccess(t *testing.T) {
+	beforeEachGrpc()
+	defer afterEachGrpc()
+
+	var replaceSent bool
+	grpcReceiver.Receive(api.Endpoints.FileCopyStart, func(req transport.Request) ([]byte, any, error) {
+		fields := map[string]json.RawMessage{}
+		json.Unmarshal(req.RawBody(), &fields)
+		_, replaceSent = fields["Replace"]
+
+		task := &api.RemoteCopyTask{}
+		_, err := req.Body(task)
+		task.Id = "1"
+		task.Status = api.Running
+		task.Elapsed = 3 * time.Second
+		task.Error = &api.RemoteError{Code: "INTERNAL", Message: "TEST"}
+		task.Stats = api.TaskStats{Codec: transport.CodecNone, Bytes: 10, Sent: 5}
+		return nil, task, err
+	})
+
+	modTime := time.Date(2026, 1, 2, 3, 4, 5, 6, time.UTC)
+	host := grpcHost
+	host.Capabilities = &api.HostCapabilities{
+		ApiVersion: 1,
+		Protocols:  []transport.TransportProtocol{transport.GRPC},
+		Features:   []api.HostFeature{api.FeatureCopy},
+		Roots:      []api.RootSpace{{Path: "/", Total: 100, Free: 50}},
+		Uptime:     time.Minute,
+	}
+	source := api.RemoteFile{Host: host, Info: api.FileInfo{Id: testFileId, Type: api.FILE, Size: 10, ModTime: modTime}}
+	target := api.RemoteFile{Host: host, Info: api.FileInfo{Path: testFileName, Type: api.FILE}}
+
+	task, err := source.CopyWith(grpcSender, target, api.CopyOptions{Streams: 2, Compression: api.CompressionAuto})
+	if err != nil {
+		t.Fatalf("error should be nil, but err is [%s]", err)
+	}
+	if !replaceSent || task.Replace || task.Streams != 2 || task.Compression != api.CompressionAuto {
+		t.Fatalf("options should be sent, but task is [%+v]", task.CopyOptions)
+	}
+	if !task.Source.Info.ModTime.Equal(modTime) || task.Source.Info.Type != api.FILE || task.Target.Info.Path != testFileName {
+		t.Fatalf("files should be sent, but source is [%+v], target is [%+v]", task.Source.Info, task.Target.Info)
+	}
+	if !reflect.DeepEqual(task.Host.Capabilities, host.Capabilities) || !task.Host.IP.Equal(host.IP) {
+		t.Fatalf("host should be [%+v], but host is [%+v]", host, task.Host)
+	}
+	if task.Id != "1" || task.Status != api.Running || task.Elapsed != 3*time.Second || task.Stats.Sent != 5 {
+		t.Fatalf("state of the task should be received, but task is [%+v]", task)
+	}
+	if task.Error == nil || task.Error.Code != "INTERNAL" {
+		t.Fatalf("error of the task should be received, but error is [%v]", task.Error)
+	}
+}
+
+func TestGrpcSignatureSuccess(t *testing.T) {
+	beforeEachGrpc()
+	defer afterEachGrpc()
+
+	expected, _ := api.NewSignature(bytes.NewReader([]byte("TEST_DATA_OF_SIGNATURE")), 4)
+	grpcReceiver.Receive(api.Endpoints.FileSignature.Name, func(req transport.Request) ([]byte, any, error) {
+		blockSize, err := req.ParamInt(api.Endpoints.FileSignature.BlockSize)
+		if blockSize != expected.BlockSize {
+			err = api.ErrInvalidArgument
+		}
+		return nil, expected, err
+	})
+
+	file := api.RemoteFile{Host: grpcHost, Info: api.FileInfo{Id: testFileId}}
+	signature, err := file.Signature(grpcSender, expected.BlockSize)
+	if err != nil {
+		t.Fatalf("error should be nil, but err is [%s]", err)
+	}
+	if !reflect.DeepEqual(signature, expected) {
+		t.Fatalf("signature should be [%+v], but signature is [%+v]", expected, signature)
+	}
+}
+
+func TestGrpcSearchSuccess(t *testing.T) {
+	beforeEachGrpc()
+	defer afterEachGrpc()
+
+	grpcReceiver.ReceiveStream(api.Endpoints.FileSearch.Name, func(req transport.StreamRequest, writer io.Writer) error {
+		encoder := json.NewEncoder(writer)
+		for _, name := range []string{"first.txt", "second.txt"} {
+			if err := encoder.Encode(api.FileInfo{Name: name, Type: api.FILE}); err != nil {
+				return err
+			}
+			transport.Flush(writer)
+		}
+		return nil
+	})
+
+	file := api.RemoteFile{Host: grpcHost, Info: api.FileInfo{Id: testFileId}}
+	search, err := file.Search(grpcSender, api.SearchQuery{Pattern: "*.txt", After: time.Now().Add(-time.Hour), MinSize: 1})
+	if err != nil {
+		t.Fatalf("error should be nil, but err is [%s]", err)
+	}
+
+	files, err := search.All()
+	if err != nil {
+		t.Fatalf("error should be nil, but err is [%s]", err)
+	}
+	if len(files) != 2 || files[0].Info.Name != "first.txt" || files[1].Info.Name != "second.txt" {
+		t.Fatalf("files should be [first.txt second.txt], but files are [%v]", files)
+	}
+}
+
+func TestGrpcReadTimeout(t *testing.T) {
+	listener := bufconn.Listen(grpcBufferSize)
+	receiver := transport.NewGrpcReceiver(config.Port, listener)
+	sender := transport.NewGrpcSender(config.Port, 100*time.Millisecond, func(ctx context.Context, _ string) (net.Conn, error) {
+		return listener.DialContext(ctx)
+	})
+	receiver.ReceiveStream(api.Endpoints.FileRead.Name, func(req transport.StreamRequest, writer io.Writer) error {
+		<-req.Context().Done()
+		return req.Context().Err()
+	})
+	receiver.Start()
+	defer receiver.Stop()
+
+	file := api.RemoteFile{Host: grpcHost, Info: api.FileInfo{Id: testFileId}}
+	_, err := file.Read(sender, 0, 0)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("error should be [context.DeadlineExceeded], but err is [%v]", err)
+	}
+}
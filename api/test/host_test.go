@@ -39,3 +39,81 @@ func TestTaskResponseError(t *testing.T) {
 		t.Fatalf("error should be not nil, but error is nil")
 	}
 }
+
+func TestHostSupports(t *testing.T) {
+	host := api.RemoteHost{Capabilities: &api.HostCapabilities{Features: []api.HostFeature{api.FeatureCopy}}}
+	if !host.Supports(api.FeatureCopy) {
+		t.Fatalf("host should support [%s]", api.FeatureCopy)
+	}
+
+	legacy := api.RemoteHost{}
+	if legacy.Supports(api.FeatureCopy) {
+		t.Fatalf("host without capabilities should not support [%s]", api.FeatureCopy)
+	}
+	if err := legacy.Compatible(); err != nil {
+		t.Fatalf("error should be nil, but error is [%s]", err)
+	}
+}
+
+func TestHostCompatible(t *testing.T) {
+	// The host which supports the first version is used by the first version endpoints.
+	legacy := api.RemoteHost{Capabilities: &api.HostCapabilities{ApiVersion: 1, MinApiVersion: 1}}
+	if err := legacy.Compatible(); err != nil || legacy.Endpoints() != &api.Endpoints {
+		t.Fatalf("host should use the first version, but error is [%v]", err)
+	}
+
+	newer := api.RemoteHost{Capabilities: &api.HostCapabilities{ApiVersion: api.ApiVersion + 2, MinApiVersion: 1}}
+	if err := newer.Compatible(); err != nil || newer.Endpoints() != &api.EndpointsV2 {
+		t.Fatalf("host should use the second version, but error is [%v]", err)
+	}
+
+	incompatible := []api.HostCapabilities{
+		{ApiVersion: api.ApiVersion + 2, MinApiVersion: api.ApiVersion + 1},
+		{ApiVersion: api.MinApiVersion - 1, MinApiVersion: api.MinApiVersion - 1},
+	}
+	for _, capabilities := range incompatible {
+		host := api.RemoteHost{Capabilities: &capabilities}
+		if err := host.Compatible(); !errors.Is(err, api.ErrIncompatibleHost) {
+			t.Fatalf("error of versions [%d-%d] should be [api.ErrIncompatibleHost], but error is [%v]", capabilities.MinApiVersion, capabilities.ApiVersion, err)
+		}
+	}
+}
+
+func TestNetworkTasksSuccess(t *testing.T) {
+	beforeEach()
+	defer afterEach()
+
+	rec.Receive(api.Endpoints.FileCopy, func(transport.Request) ([]byte, any, error) {
+		return nil, []api.RemoteCopyTask{{Id: "1", Status: api.Running}}, nil
+	})
+	rec.Receive(api.Endpoints.TaskHistory, func(transport.Request) ([]byte, any, error) {
+		return nil, []api.RemoteCopyTask{{Id: "1", Status: api.Running}, {Id: "2", Status: api.Completed}}, nil
+	})
+
+	controlled := api.RemoteHost{Name: "controlled", IP: local.IP, Capabilities: &api.HostCapabilities{Features: []api.HostFeature{api.FeatureTaskControl}}}
+	uncontrolled := api.RemoteHost{Name: "uncontrolled", IP: local.IP, Capabilities: &api.HostCapabilities{}}
+	results := network.Tasks([]api.RemoteHost{controlled, uncontrolled})
+	if len(results) != 2 {
+		t.Fatalf("count of the results should be [2], but results are [%v]", results)
+	}
+	for index, count := range []int{2, 1} {
+		if results[index].Error != nil || len(results[index].Tasks) != count {
+			t.Fatalf("host [%s] should have [%d] tasks, but result is [%v]", results[index].Host.Name, count, results[index])
+		}
+	}
+}
+
+func TestNetworkTasksResponseError(t *testing.T) {
+	beforeEach()
+	defer afterEach()
+
+	rec.Receive(api.Endpoints.FileCopy, func(transport.Request) ([]byte, any, error) {
+		return nil, nil, errors.New("can't submit request")
+	})
+
+	host, _ := network.Host(local.IP)
+	results := network.Tasks([]api.RemoteHost{*host})
+	if len(results) != 1 || results[0].Error == nil {
+		t.Fatalf("error should be not nil, but results are [%v]", results)
+	}
+}
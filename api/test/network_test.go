@@ -131,3 +131,26 @@ func TestGetHostsSuccess(t *testing.T) {
 		t.Fatal("hosts should be not empty")
 	}
 }
+
+func TestGetHostIncompatible(t *testing.T) {
+	config := api.NetworkConfig{Port: 5, Protocol: transport.HTTP, Timeout: 5 * time.Second}
+	network, _ := api.NewNetwork(config)
+	local := network.LocalHost()
+	local.Capabilities = &api.HostCapabilities{ApiVersion: api.ApiVersion + 2, MinApiVersion: api.ApiVersion + 1}
+
+	go func() {
+		mux := http.NewServeMux()
+		mux.HandleFunc(api.Endpoints.ServerHost, func(w http.ResponseWriter, r *http.Request) {
+			data, _ := json.Marshal(local)
+
+			w.Write(data)
+		})
+		http.ListenAndServe(":"+strconv.Itoa(int(config.Port)), mux)
+	}()
+	time.Sleep(2 * time.Second)
+
+	_, err := network.Host(local.IP)
+	if !errors.Is(err, api.ErrIncompatibleHost) {
+		t.Fatalf("error should be [api.ErrIncompatibleHost], but error is [%s]", err)
+	}
+}
@@ -0,0 +1,96 @@
+package api_test
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"netfs/api"
+	"netfs/api/transport"
+	"testing"
+	"time"
+)
+
+func TestSearchSuccess(t *testing.T) {
+	beforeEach()
+	defer afterEach()
+
+	rec.ReceiveStream(api.Endpoints.FileSearch.Name, func(req transport.StreamRequest, writer io.Writer) error {
+		if req.Param(api.Endpoints.FileSearch.Pattern) != "*.txt" || req.Param(api.Endpoints.FileSearch.MinSize) != "10" {
+			return api.ErrInvalidArgument
+		}
+
+		encoder := json.NewEncoder(writer)
+		for _, name := range []string{"first.txt", "second.txt"} {
+			if err := encoder.Encode(api.FileInfo{Id: api.FileId("/" + name), Name: name, Type: api.FILE}); err != nil {
+				return err
+			}
+			transport.Flush(writer)
+		}
+		return nil
+	})
+
+	host, _ := network.Host(local.IP)
+	file, _ := host.File(network.Transport(), testFileId)
+	search, err := file.Search(network.Transport(), api.SearchQuery{Pattern: "*.txt", MinSize: 10})
+	if err != nil {
+		t.Fatalf("error should be nil, but err is [%s]", err)
+	}
+	defer search.Close()
+
+	for _, name := range []string{"first.txt", "second.txt"} {
+		found, err := search.Next()
+		if err != nil {
+			t.Fatalf("error should be nil, but err is [%s]", err)
+		}
+		if found.Info.Name != name || !found.Host.IP.Equal(host.IP) {
+			t.Fatalf("found file should be [%s] of host [%s], but file is [%s] of host [%s]", name, host.IP, found.Info.Name, found.Host.IP)
+		}
+	}
+	if _, err = search.Next(); !errors.Is(err, io.EOF) {
+		t.Fatalf("error should be [io.EOF], but err is [%v]", err)
+	}
+}
+
+func TestSearchResponseError(t *testing.T) {
+	beforeEach()
+	defer afterEach()
+
+	rec.ReceiveStream(api.Endpoints.FileSearch.Name, func(transport.StreamRequest, io.Writer) error {
+		return api.ErrFileNotFound
+	})
+
+	host, _ := network.Host(local.IP)
+	file, _ := host.File(network.Transport(), testFileId)
+	_, err := file.Search(network.Transport(), api.SearchQuery{Pattern: "*"})
+	if !errors.Is(err, api.ErrFileNotFound) {
+		t.Fatalf("error should be [api.ErrFileNotFound], but err is [%v]", err)
+	}
+}
+
+func TestSearchCancel(t *testing.T) {
+	beforeEach()
+	defer afterEach()
+
+	cancelled := make(chan struct{})
+	rec.ReceiveStream(api.Endpoints.FileSearch.Name, func(req transport.StreamRequest, writer io.Writer) error {
+		// The started response is returned to the sender before the first file is found.
+		transport.Flush(writer)
+		<-req.Context().Done()
+		close(cancelled)
+		return req.Context().Err()
+	})
+
+	host, _ := network.Host(local.IP)
+	file, _ := host.File(network.Transport(), testFileId)
+	search, err := file.Search(network.Transport(), api.SearchQuery{Pattern: "*"})
+	if err != nil {
+		t.Fatalf("error should be nil, but err is [%s]", err)
+	}
+	search.Close()
+
+	select {
+	case <-cancelled:
+	case <-time.After(5 * time.Second):
+		t.Fatal("the search should be cancelled after closing")
+	}
+}
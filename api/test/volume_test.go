@@ -0,0 +1,62 @@
+package api_test
+
+import (
+	"errors"
+	"netfs/api"
+	"netfs/api/transport"
+	"testing"
+)
+
+func TestVolumesSuccess(t *testing.T) {
+	beforeEach()
+	defer afterEach()
+
+	rec.Receive(api.Endpoints.VolumeInfo.Name, func(transport.Request) ([]byte, any, error) {
+		return nil, []api.VolumeInfo{{Path: "./", Total: 100, Free: 40, Used: 60}}, nil
+	})
+
+	host, _ := network.Host(local.IP)
+	volumes, err := host.Volumes(network.Transport())
+	if err != nil {
+		t.Fatalf("error should be nil, but error is [%s]", err)
+	}
+	if len(volumes) != 1 || volumes[0].Free != 40 {
+		t.Fatalf("volumes should contain one volume with free space [40], but volumes are [%v]", volumes)
+	}
+}
+
+func TestVolumeSuccess(t *testing.T) {
+	beforeEach()
+	defer afterEach()
+
+	rec.Receive(api.Endpoints.VolumeInfo.Name, func(req transport.Request) ([]byte, any, error) {
+		fileId, err := req.ParamRequired(api.Endpoints.VolumeInfo.FileId)
+		return nil, []api.VolumeInfo{{Path: fileId, Total: 100, Free: 40, Used: 60}}, err
+	})
+
+	host, _ := network.Host(local.IP)
+	file, _ := host.File(network.Transport(), testFileId)
+	volume, err := file.Volume(network.Transport())
+	if err != nil {
+		t.Fatalf("error should be nil, but error is [%s]", err)
+	}
+	if volume.Path != string(testFileId) {
+		t.Fatalf("volume path should be [%s], but path is [%s]", testFileId, volume.Path)
+	}
+}
+
+func TestVolumeResponseError(t *testing.T) {
+	beforeEach()
+	defer afterEach()
+
+	rec.Receive(api.Endpoints.VolumeInfo.Name, func(transport.Request) ([]byte, any, error) {
+		return nil, nil, errors.New("can't submit request")
+	})
+
+	host, _ := network.Host(local.IP)
+	file, _ := host.File(network.Transport(), testFileId)
+	_, err := file.Volume(network.Transport())
+	if err == nil {
+		t.Fatal("error should be not nil")
+	}
+}
@@ -0,0 +1,139 @@
+package api_test
+
+import (
+	"netfs/api"
+	"testing"
+	"time"
+)
+
+var watcherConfig = api.HostWatcherConfig{Heartbeat: 200 * time.Millisecond, Discovery: time.Minute, Expiry: time.Second}
+
+func waitHostEvent(events <-chan api.HostEvent, eventType api.HostEventType, timeout time.Duration) (*api.HostEvent, bool) {
+	deadline := time.After(timeout)
+	for {
+		select {
+		case event, ok := <-events:
+			if !ok {
+				return nil, false
+			}
+			if event.Type == eventType {
+				return &event, true
+			}
+		case <-deadline:
+			return nil, false
+		}
+	}
+}
+
+func TestHostWatcherAdded(t *testing.T) {
+	beforeEach()
+	defer afterEach()
+
+	watcher := api.NewHostWatcher(network, watcherConfig)
+	events := watcher.Subscribe()
+	watcher.Start()
+	defer watcher.Stop()
+
+	event, ok := waitHostEvent(events, api.HostAdded, 10*time.Second)
+	if !ok {
+		t.Fatal("host should be added")
+	}
+	if event.Host.IP.String() != local.IP.String() {
+		t.Fatalf("[%s] and [%s] should be equals", event.Host.IP.String(), local.IP.String())
+	}
+
+	hosts := watcher.Hosts()
+	if len(hosts) != 1 {
+		t.Fatalf("hosts count should be [1], but count is [%d]", len(hosts))
+	}
+	if hosts[0].LastSeen.IsZero() {
+		t.Fatal("last seen time should be not zero")
+	}
+}
+
+func TestHostWatcherRemoved(t *testing.T) {
+	beforeEach()
+
+	watcher := api.NewHostWatcher(network, watcherConfig)
+	events := watcher.Subscribe()
+	watcher.Start()
+	defer watcher.Stop()
+
+	if _, ok := waitHostEvent(events, api.HostAdded, 10*time.Second); !ok {
+		afterEach()
+		t.Fatal("host should be added")
+	}
+
+	afterEach()
+	event, ok := waitHostEvent(events, api.HostRemoved, 10*time.Second)
+	if !ok {
+		t.Fatal("host should be removed")
+	}
+	if event.Host.IP.String() != local.IP.String() {
+		t.Fatalf("[%s] and [%s] should be equals", event.Host.IP.String(), local.IP.String())
+	}
+	if len(watcher.Hosts()) != 0 {
+		t.Fatal("hosts should be empty")
+	}
+}
+
+func TestHostWatcherStop(t *testing.T) {
+	beforeEach()
+	defer afterEach()
+
+	watcher := api.NewHostWatcher(network, watcherConfig)
+	events := watcher.Subscribe()
+	watcher.Start()
+	watcher.Stop()
+
+	for range events {
+	}
+}
+
+func TestHostWatcherSubscribeAfterStop(t *testing.T) {
+	watcher := api.NewHostWatcher(network, watcherConfig)
+	watcher.Stop()
+
+	if _, ok := <-watcher.Subscribe(); ok {
+		t.Fatal("channel of the stopped watcher should be closed")
+	}
+}
+
+func TestHostWatcherSlowSubscriber(t *testing.T) {
+	beforeEach()
+
+	watcher := api.NewHostWatcher(network, watcherConfig)
+	events := watcher.Subscribe()
+	watcher.Start()
+	defer watcher.Stop()
+
+	// The subscriber does not read the events until the host is added and removed.
+	if !waitHosts(watcher, 1, 10*time.Second) {
+		afterEach()
+		t.Fatal("host should be added")
+	}
+	afterEach()
+	if !waitHosts(watcher, 0, 10*time.Second) {
+		t.Fatal("host should be removed")
+	}
+
+	event, ok := waitHostEvent(events, api.HostRemoved, time.Second)
+	if !ok {
+		t.Fatal("slow subscriber should receive the removed event")
+	}
+	if event.Host.IP.String() != local.IP.String() {
+		t.Fatalf("[%s] and [%s] should be equals", event.Host.IP.String(), local.IP.String())
+	}
+}
+
+// Waits until the watcher knows the count of the hosts.
+func waitHosts(watcher *api.HostWatcher, count int, timeout time.Duration) bool {
+	deadline := time.Now().Add(timeout)
+	for time.Now().Before(deadline) {
+		if len(watcher.Hosts()) == count {
+			return true
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+	return false
+}
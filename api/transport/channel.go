@@ -0,0 +1,48 @@
+package transport
+
+import (
+	"io"
+	"net"
+)
+
+// The data channel which writes to the body of the streaming request.
+type pipeChannel struct {
+	writer *io.PipeWriter
+	done   chan error
+}
+
+func (channel *pipeChannel) Write(data []byte) (int, error) {
+	return channel.writer.Write(data)
+}
+
+func (channel *pipeChannel) Close() error {
+	channel.writer.Close()
+	return <-channel.done
+}
+
+func (channel *pipeChannel) Abort(err error) {
+	channel.writer.CloseWithError(err)
+	<-channel.done
+}
+
+// Opens the channel which sends the written data as the body of one streaming request.
+// If the receiver fails, the next writing returns its error.
+func openChannel(ip net.IP, endpoint string, parameters []string, sender TransportSender, send func(Request) (StreamResponse, error)) (Channel, error) {
+	reader, writer := io.Pipe()
+	req, err := sender.NewStreamRequest(ip, endpoint, parameters, reader)
+	if err != nil {
+		return nil, err
+	}
+
+	channel := &pipeChannel{writer: writer, done: make(chan error, 1)}
+	go func() {
+		res, err := send(req)
+		if err == nil {
+			_, err = io.Copy(io.Discard, res.Reader())
+			res.Close()
+		}
+		reader.CloseWithError(err)
+		channel.done <- err
+	}()
+	return channel, nil
+}
@@ -0,0 +1,204 @@
+package transport
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+const codecSeparator = ","
+const codecWeightSeparator = ";"
+
+// Compression algorithm of the transferred data.
+type Codec string
+
+const (
+	// The data is not compressed.
+	CodecNone Codec = ""
+	// The data is compressed by gzip.
+	CodecGzip Codec = "gzip"
+	// The data is compressed by zstd.
+	CodecZstd Codec = "zstd"
+)
+
+// The codecs supported by the transport in the order of preference.
+var Codecs = []Codec{CodecZstd, CodecGzip}
+
+// The encoder of the small messages, it is safe for concurrent use.
+var messageEncoder, _ = zstd.NewWriter(nil, zstd.WithEncoderLevel(zstd.SpeedFastest))
+
+// Returns the most preferable codec from the list or CodecNone if the list does not contain supported codecs.
+func NegotiateCodec(codecs []Codec) Codec {
+	for _, codec := range Codecs {
+		if slices.Contains(codecs, codec) {
+			return codec
+		}
+	}
+	return CodecNone
+}
+
+// Returns the codecs of the header value, for example "zstd, gzip;q=0.5".
+func parseCodecs(header string) []Codec {
+	codecs := []Codec{}
+	for _, value := range strings.Split(header, codecSeparator) {
+		name, _, _ := strings.Cut(value, codecWeightSeparator)
+		codecs = append(codecs, Codec(strings.TrimSpace(name)))
+	}
+	return codecs
+}
+
+// Returns the header value of the codecs.
+func formatCodecs(codecs []Codec) string {
+	values := make([]string, len(codecs))
+	for index, codec := range codecs {
+		values[index] = string(codec)
+	}
+	return strings.Join(values, codecSeparator+" ")
+}
+
+// Compresses the message by the codec.
+func compressMessage(message []byte, codec Codec) ([]byte, error) {
+	switch codec {
+	case CodecNone:
+		return message, nil
+	case CodecZstd:
+		return messageEncoder.EncodeAll(message, nil), nil
+	case CodecGzip:
+		buffer := &bytes.Buffer{}
+		encoder := gzip.NewWriter(buffer)
+		_, err := encoder.Write(message)
+		err = errors.Join(err, encoder.Close())
+		return buffer.Bytes(), err
+	}
+	return nil, unknownCodec(codec)
+}
+
+// Returns the reader which decompresses data of the reader by the codec.
+// The returned reader must be closed, it does not close the source reader.
+func Decompress(reader io.Reader, codec Codec) (io.ReadCloser, error) {
+	switch codec {
+	case CodecNone:
+		return io.NopCloser(reader), nil
+	case CodecZstd:
+		decoder, err := zstd.NewReader(reader, zstd.WithDecoderConcurrency(1))
+		if err == nil {
+			return decoder.IOReadCloser(), nil
+		}
+		return nil, err
+	case CodecGzip:
+		return gzip.NewReader(reader)
+	}
+	return nil, unknownCodec(codec)
+}
+
+func unknownCodec(codec Codec) error {
+	return fmt.Errorf("codec [%s] %w", codec, ErrIncorrectParamValue)
+}
+
+// The writer compresses data by the codec and counts the data before and after compression.
+type CompressWriter struct {
+	encoder io.WriteCloser
+	counter *countWriter
+	bytes   int64
+}
+
+// Creates new writer which writes data compressed by the codec to the writer.
+// The writer must be closed to flush the compressed data, it does not close the target writer.
+func NewCompressWriter(writer io.Writer, codec Codec) (*CompressWriter, error) {
+	var err error
+	counter := &countWriter{writer: writer}
+	compressor := &CompressWriter{counter: counter}
+	switch codec {
+	case CodecNone:
+		compressor.encoder = counter
+	case CodecZstd:
+		compressor.encoder, err = zstd.NewWriter(counter, zstd.WithEncoderLevel(zstd.SpeedFastest), zstd.WithEncoderConcurrency(1))
+	case CodecGzip:
+		compressor.encoder, err = gzip.NewWriterLevel(counter, gzip.BestSpeed)
+	default:
+		err = unknownCodec(codec)
+	}
+
+	if err == nil {
+		return compressor, nil
+	}
+	return nil, err
+}
+
+func (writer *CompressWriter) Write(data []byte) (int, error) {
+	count, err := writer.encoder.Write(data)
+	writer.bytes += int64(count)
+	return count, err
+}
+
+func (writer *CompressWriter) Close() error {
+	return writer.encoder.Close()
+}
+
+// Writes the data buffered by the encoder to the target writer.
+func (writer *CompressWriter) Flush() error {
+	if flusher, ok := writer.encoder.(interface{ Flush() error }); ok {
+		return flusher.Flush()
+	}
+	return nil
+}
+
+// Returns size of the data before compression.
+func (writer *CompressWriter) Bytes() int64 {
+	return writer.bytes
+}
+
+// Returns size of the compressed data written to the target writer.
+func (writer *CompressWriter) Sent() int64 {
+	return writer.counter.count
+}
+
+// The writer counts the written bytes.
+type countWriter struct {
+	writer io.Writer
+	count  int64
+}
+
+func (writer *countWriter) Write(data []byte) (int, error) {
+	count, err := writer.writer.Write(data)
+	writer.count += int64(count)
+	return count, err
+}
+
+func (writer *countWriter) Close() error {
+	return nil
+}
+
+// The channel compresses data by the codec before sending.
+type CompressedChannel struct {
+	*CompressWriter
+	channel Channel
+}
+
+// Creates new channel which compresses data by the codec and sends it to the channel.
+func CompressChannel(channel Channel, codec Codec) (*CompressedChannel, error) {
+	writer, err := NewCompressWriter(channel, codec)
+	if err == nil {
+		return &CompressedChannel{CompressWriter: writer, channel: channel}, nil
+	}
+	return nil, err
+}
+
+// Flushes the compressed data and closes the channel.
+func (channel *CompressedChannel) Close() error {
+	if err := channel.CompressWriter.Close(); err != nil {
+		channel.channel.Abort(err)
+		return err
+	}
+	return channel.channel.Close()
+}
+
+func (channel *CompressedChannel) Abort(err error) {
+	channel.channel.Abort(err)
+}
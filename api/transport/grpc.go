@@ -0,0 +1,668 @@
+package transport
+
+// The code of the service is generated by the pinned versions of the tools, the plugins must be found in PATH.
+//go:generate go install google.golang.org/protobuf/cmd/protoc-gen-go@v1.36.11
+//go:generate go install google.golang.org/grpc/cmd/protoc-gen-go-grpc@v1.5.1
+//go:generate go run github.com/bufbuild/buf/cmd/buf@v1.50.0 alpha protoc -I pb --go_out=pb --go_opt=paths=source_relative --go-grpc_out=pb --go-grpc_opt=paths=source_relative pb/transport.proto
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+
+	"netfs/api/transport/pb"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/backoff"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/encoding/gzip"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+const grpcScheme = "passthrough:///"
+const grpcErrorDomain = "netfs"
+const grpcChunkSize = 1048576        // 1 MB
+const grpcMaxMessageSize = 134217728 // 128 MB
+
+// gRPC codes of the error kinds.
+var errorCodes = map[ErrorKind]codes.Code{
+	KindInternal:            codes.Internal,
+	KindBadRequest:          codes.InvalidArgument,
+	KindNotFound:            codes.NotFound,
+	KindForbidden:           codes.PermissionDenied,
+	KindConflict:            codes.AlreadyExists,
+	KindInsufficientStorage: codes.ResourceExhausted,
+	KindUnavailable:         codes.Unavailable,
+}
+
+// Converts any error to the gRPC status, the code of the error is sent in the details.
+func grpcError(err error) error {
+	envelope := ToError(err)
+	result := status.New(errorCodes[envelope.Kind], envelope.Message)
+	if detailed, detailsErr := result.WithDetails(&errdetails.ErrorInfo{Reason: envelope.Code, Domain: grpcErrorDomain}); detailsErr == nil {
+		result = detailed
+	}
+	return result.Err()
+}
+
+// Converts the gRPC status to the transferred error.
+// The second result is true if the error was returned by the receiver, not by the connection.
+func fromGrpcError(err error) (error, bool) {
+	if errors.Is(err, io.EOF) {
+		return err, true
+	}
+
+	result, ok := status.FromError(err)
+	if !ok {
+		return err, false
+	}
+
+	for _, detail := range result.Details() {
+		if info, ok := detail.(*errdetails.ErrorInfo); ok && info.Domain == grpcErrorDomain {
+			kind := KindInternal
+			for errorKind, code := range errorCodes {
+				if code == result.Code() {
+					kind = errorKind
+				}
+			}
+			return &Error{Kind: kind, Code: info.Reason, Message: result.Message()}, true
+		}
+	}
+	return errors.Join(ErrUnexpectedAnswer, fmt.Errorf("status code is [%s], message is [%s]", result.Code(), result.Message())), false
+}
+
+// Reads the body which is transferred by chunks.
+type grpcStreamReader struct {
+	recv func() ([]byte, error)
+	data []byte
+	err  error
+}
+
+func (reader *grpcStreamReader) Read(data []byte) (int, error) {
+	for len(reader.data) == 0 {
+		if reader.err != nil {
+			return 0, reader.err
+		}
+		reader.data, reader.err = reader.recv()
+	}
+
+	count := copy(data, reader.data)
+	reader.data = reader.data[count:]
+	return count, nil
+}
+
+// The body of the streaming response, closing cancels the call.
+type grpcStreamBody struct {
+	grpcStreamReader
+	cancel context.CancelCauseFunc
+}
+
+func (body *grpcStreamBody) Close() error {
+	body.cancel(nil)
+	return nil
+}
+
+// Writes the body by chunks.
+type grpcStreamWriter struct {
+	send    func([]byte) error
+	started bool
+}
+
+func (writer *grpcStreamWriter) Write(data []byte) (int, error) {
+	writer.started = true
+	written := 0
+	for written < len(data) {
+		chunk := data[written:min(written+grpcChunkSize, len(data))]
+		if err := writer.send(chunk); err != nil {
+			return written, err
+		}
+		written += len(chunk)
+	}
+	return written, nil
+}
+
+// Every chunk is sent by Write, so only the empty chunk is sent to start the response.
+func (writer *grpcStreamWriter) Flush() {
+	if !writer.started {
+		writer.started = writer.send(nil) == nil
+	}
+}
+
+// Cancels the call if the receiver does not answer in time after the whole request is sent.
+type grpcAnswerTimer struct {
+	lock     sync.Mutex
+	timer    *time.Timer
+	answered bool
+}
+
+// Starts waiting for the answer.
+func (answer *grpcAnswerTimer) start(timeout time.Duration, cancel context.CancelCauseFunc) {
+	answer.lock.Lock()
+	defer answer.lock.Unlock()
+
+	if !answer.answered {
+		answer.timer = time.AfterFunc(timeout, func() {
+			cancel(fmt.Errorf("%w: the host has not answered in %s", context.DeadlineExceeded, timeout))
+		})
+	}
+}
+
+// Stops waiting, the answer is received.
+func (answer *grpcAnswerTimer) stop() {
+	answer.lock.Lock()
+	defer answer.lock.Unlock()
+
+	answer.answered = true
+	if answer.timer != nil {
+		answer.timer.Stop()
+	}
+}
+
+// Sending data via the gRPC protocol.
+type GrpcTransportSender struct {
+	port    uint16
+	timeout time.Duration
+	dialer  func(context.Context, string) (net.Conn, error)
+	lock    sync.Mutex
+	conns   map[string]*grpc.ClientConn
+}
+
+// Creates new request instance by parameters.
+func (tr *GrpcTransportSender) NewRequest(ip net.IP, endpoint string, parameters []string, rawBody []byte, body any) (Request, error) {
+	var err error
+	req := &request{ip: ip, endpoint: endpoint, params: parameters, rawBody: rawBody}
+	if body != nil {
+		req.rawBody, err = json.Marshal(body)
+	}
+	return req, err
+}
+
+// Creates new streaming request instance by parameters.
+func (tr *GrpcTransportSender) NewStreamRequest(ip net.IP, endpoint string, parameters []string, reader io.Reader) (StreamRequest, error) {
+	return &streamRequest{request: request{ip: ip, endpoint: endpoint, params: parameters}, reader: reader}, nil
+}
+
+// Sends request.
+func (tr *GrpcTransportSender) Send(req Request) (Response, error) {
+	method, err := grpcMethodOf(req.Endpoint())
+	if err != nil {
+		return nil, err
+	}
+
+	var conn *grpc.ClientConn
+	if conn, err = tr.conn(req.IP()); err == nil {
+		ctx, cancel := context.WithTimeout(context.Background(), tr.timeout)
+		defer cancel()
+
+		var message proto.Message
+		if message, err = method.newRequest(req.Params(), req.RawBody()); err != nil {
+			return nil, err
+		}
+
+		var rawBody []byte
+		if method.desc.IsStreamingClient() || method.desc.IsStreamingServer() {
+			var stream grpc.ClientStream
+			if stream, err = conn.NewStream(ctx, method.streamDesc(), method.name, grpc.UseCompressor(gzip.Name)); err == nil {
+				if err = stream.SendMsg(message); err == nil {
+					err = stream.CloseSend()
+				}
+			}
+			if err == nil {
+				body := &grpcResponseBody{method: method, recv: stream.RecvMsg}
+				rawBody, err = io.ReadAll(&grpcStreamReader{recv: body.next})
+			}
+		} else {
+			resMessage := method.response.New()
+			if err = conn.Invoke(ctx, method.name, message, resMessage.Interface(), grpc.UseCompressor(gzip.Name)); err == nil {
+				rawBody, err = (&grpcResponseBody{method: method}).convert(resMessage)
+			}
+		}
+
+		if err == nil {
+			return &response{ip: req.IP(), endpoint: req.Endpoint(), rawBody: rawBody}, nil
+		}
+		err = tr.receivedError(req.IP(), err)
+	}
+	return nil, err
+}
+
+// Sends request and returns the response without reading its body.
+// The body of the streaming request is sent by chunks while the response is received.
+// The receiver must answer in time after the whole request is sent, otherwise the call is cancelled.
+func (tr *GrpcTransportSender) SendStream(req Request) (StreamResponse, error) {
+	method, err := grpcMethodOf(req.Endpoint())
+	if err != nil {
+		return nil, err
+	}
+
+	// The method without streams returns the whole response at once.
+	if !method.desc.IsStreamingClient() && !method.desc.IsStreamingServer() {
+		var res Response
+		if res, err = tr.Send(req); err == nil {
+			return &streamResponse{ip: res.IP(), endpoint: res.Endpoint(), body: io.NopCloser(bytes.NewReader(res.RawBody()))}, nil
+		}
+		return nil, err
+	}
+
+	var conn *grpc.ClientConn
+	if conn, err = tr.conn(req.IP()); err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithCancelCause(context.Background())
+	answer := &grpcAnswerTimer{}
+	streamReq, streamed := req.(StreamRequest)
+	streamed = streamed && streamReq.Reader() != nil && method.desc.IsStreamingClient()
+
+	var stream grpc.ClientStream
+	if stream, err = conn.NewStream(ctx, method.streamDesc(), method.name); err == nil {
+		var message proto.Message
+		if streamed {
+			if message, err = method.newRequest(req.Params(), nil); err == nil {
+				if err = stream.SendMsg(message); err == nil {
+					go tr.sendBody(stream, method, streamReq.Reader(), cancel, answer)
+				}
+			}
+		} else if message, err = method.newRequest(req.Params(), req.RawBody()); err == nil {
+			if err = stream.SendMsg(message); err == nil {
+				if err = stream.CloseSend(); err == nil {
+					answer.start(tr.timeout, cancel)
+				}
+			}
+		}
+	}
+
+	if err == nil {
+		body := &grpcStreamBody{cancel: cancel}
+		responseBody := &grpcResponseBody{method: method, recv: stream.RecvMsg}
+		body.recv = func() ([]byte, error) {
+			data, err := responseBody.next()
+			if err != nil {
+				err, _ = fromGrpcError(err)
+			}
+			return data, err
+		}
+
+		// The first message is waited to return the error of the receiver as the result of sending.
+		var data []byte
+		data, err = responseBody.next()
+		answer.stop()
+		if err == nil || errors.Is(err, io.EOF) {
+			body.data = data
+			body.err = err
+			return &streamResponse{ip: req.IP(), endpoint: req.Endpoint(), body: body}, nil
+		}
+	}
+
+	// The connection is closed if the host has not answered.
+	if cause := context.Cause(ctx); errors.Is(cause, context.DeadlineExceeded) {
+		err = tr.receivedError(req.IP(), cause)
+	} else if cause != nil && !errors.Is(cause, context.Canceled) {
+		err = cause
+	} else {
+		err = tr.receivedError(req.IP(), err)
+	}
+	cancel(nil)
+	return nil, err
+}
+
+// Opens the data channel to the endpoint.
+// The channel is the gRPC stream, the streams to the same host share one connection.
+func (tr *GrpcTransportSender) OpenChannel(ip net.IP, endpoint string, parameters []string) (Channel, error) {
+	return openChannel(ip, endpoint, parameters, tr, tr.SendStream)
+}
+
+// Sends the body of the streaming request by chunks, the call is canceled if the body can't be read.
+// If the receiver has finished the call before reading the whole body, the rest of the body is not sent.
+func (tr *GrpcTransportSender) sendBody(stream grpc.ClientStream, method *grpcMethod, reader io.Reader, cancel context.CancelCauseFunc, answer *grpcAnswerTimer) {
+	writer := &grpcStreamWriter{send: func(data []byte) error {
+		return stream.SendMsg(method.dataRequest(data))
+	}}
+	_, err := io.CopyBuffer(writer, reader, make([]byte, grpcChunkSize))
+	if err == nil {
+		if err = stream.CloseSend(); err == nil {
+			answer.start(tr.timeout, cancel)
+		}
+	}
+	if err != nil && !errors.Is(err, io.EOF) {
+		cancel(err)
+	}
+}
+
+// Returns the connection to the host, the connection is created once and used by all requests to the host.
+func (tr *GrpcTransportSender) conn(ip net.IP) (*grpc.ClientConn, error) {
+	tr.lock.Lock()
+	defer tr.lock.Unlock()
+
+	target := net.JoinHostPort(ip.String(), strconv.Itoa(int(tr.port)))
+	conn, ok := tr.conns[target]
+	if !ok {
+		options := []grpc.DialOption{
+			grpc.WithTransportCredentials(insecure.NewCredentials()),
+			grpc.WithConnectParams(grpc.ConnectParams{Backoff: backoff.DefaultConfig, MinConnectTimeout: tr.timeout}),
+			grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(grpcMaxMessageSize), grpc.MaxCallSendMsgSize(grpcMaxMessageSize)),
+		}
+		if tr.dialer != nil {
+			options = append(options, grpc.WithContextDialer(tr.dialer))
+		}
+
+		var err error
+		if conn, err = grpc.NewClient(grpcScheme+target, options...); err != nil {
+			return nil, err
+		}
+		tr.conns[target] = conn
+	}
+	return conn, nil
+}
+
+// Converts the received error, the connection is closed if the host is not available.
+func (tr *GrpcTransportSender) receivedError(ip net.IP, err error) error {
+	err, received := fromGrpcError(err)
+	if !received {
+		tr.lock.Lock()
+		defer tr.lock.Unlock()
+
+		target := net.JoinHostPort(ip.String(), strconv.Itoa(int(tr.port)))
+		if conn, ok := tr.conns[target]; ok {
+			delete(tr.conns, target)
+			conn.Close()
+		}
+	}
+	return err
+}
+
+// Returns protocol.
+func (tr *GrpcTransportSender) Protocol() TransportProtocol {
+	return GRPC
+}
+
+// Returns port.
+func (tr *GrpcTransportSender) Port() uint16 {
+	return tr.port
+}
+
+// Creates new instance of GrpcTransportSender.
+// The dialer is used to connect to the hosts, if the dialer is nil, TCP is used.
+func NewGrpcSender(port uint16, timeout time.Duration, dialer func(context.Context, string) (net.Conn, error)) *GrpcTransportSender {
+	return &GrpcTransportSender{port: port, timeout: timeout, dialer: dialer, conns: map[string]*grpc.ClientConn{}}
+}
+
+// Receiving data via the gRPC protocol.
+type GrpcTransportReceiver struct {
+	port           uint16
+	listener       net.Listener
+	server         *grpc.Server
+	lock           sync.RWMutex
+	handlers       map[string]func(Request) ([]byte, any, error)
+	streamHandlers map[string]func(StreamRequest, io.Writer) error
+}
+
+// Creates new request instance by parameters.
+func (tr *GrpcTransportReceiver) NewRequest(ip net.IP, endpoint string, parameters []string, rawBody []byte, body any) (Request, error) {
+	var err error
+	req := &request{ip: ip, endpoint: endpoint, params: parameters, rawBody: rawBody}
+	if body != nil {
+		req.rawBody, err = json.Marshal(body)
+	}
+	return req, err
+}
+
+// Receives request.
+func (tr *GrpcTransportReceiver) Receive(endpoint string, handle func(Request) ([]byte, any, error)) {
+	tr.lock.Lock()
+	defer tr.lock.Unlock()
+
+	tr.handlers[endpoint] = handle
+}
+
+// Receives request and writes the response body to the writer by chunks.
+// If the handler fails after writing a part of the response, the error is returned to the reader of the response.
+func (tr *GrpcTransportReceiver) ReceiveStream(endpoint string, handle func(StreamRequest, io.Writer) error) {
+	tr.lock.Lock()
+	defer tr.lock.Unlock()
+
+	tr.streamHandlers[endpoint] = handle
+}
+
+// Returns the handler of the endpoint, the streaming handler is called with the buffered body and the response.
+func (tr *GrpcTransportReceiver) handler(endpoint string) (func(Request) ([]byte, any, error), bool) {
+	tr.lock.RLock()
+	defer tr.lock.RUnlock()
+
+	if handle, ok := tr.handlers[endpoint]; ok {
+		return handle, true
+	}
+	if handle, ok := tr.streamHandlers[endpoint]; ok {
+		return func(req Request) ([]byte, any, error) {
+			streamReq := &streamRequest{
+				request: request{ip: req.IP(), endpoint: req.Endpoint(), params: req.Params(), rawBody: req.RawBody()},
+				reader:  bytes.NewReader(req.RawBody()),
+			}
+			resBody := &bytes.Buffer{}
+			err := handle(streamReq, resBody)
+			return resBody.Bytes(), nil, err
+		}, true
+	}
+	return nil, false
+}
+
+// Returns the streaming handler of the endpoint, the handler is called with the whole body and writes the whole response.
+func (tr *GrpcTransportReceiver) streamHandler(endpoint string) (func(StreamRequest, io.Writer) error, bool) {
+	tr.lock.RLock()
+	defer tr.lock.RUnlock()
+
+	if handle, ok := tr.streamHandlers[endpoint]; ok {
+		return handle, true
+	}
+	if handle, ok := tr.handlers[endpoint]; ok {
+		return func(req StreamRequest, writer io.Writer) error {
+			rawResBody, resBody, err := handle(req)
+			if err == nil && resBody != nil {
+				rawResBody, err = json.Marshal(resBody)
+			}
+			if err == nil {
+				_, err = writer.Write(rawResBody)
+			}
+			return err
+		}, true
+	}
+	return nil, false
+}
+
+// Returns the first endpoint of the method which is received.
+func (tr *GrpcTransportReceiver) endpoint(method *grpcMethod) (string, bool) {
+	tr.lock.RLock()
+	defer tr.lock.RUnlock()
+
+	for _, endpoint := range method.endpoints {
+		_, received := tr.handlers[endpoint]
+		_, streamReceived := tr.streamHandlers[endpoint]
+		if received || streamReceived {
+			return endpoint, true
+		}
+	}
+	return "", false
+}
+
+// Starts receiver.
+func (tr *GrpcTransportReceiver) Start() error {
+	var err error
+	listener := tr.listener
+	if listener == nil {
+		listener, err = net.Listen("tcp", portSeparator+strconv.Itoa(int(tr.port)))
+	}
+	if err == nil {
+		go func() { tr.server.Serve(listener) }()
+	}
+	return err
+}
+
+// Stops receiver.
+func (tr *GrpcTransportReceiver) Stop() error {
+	tr.server.GracefulStop()
+	return nil
+}
+
+// Returns protocol.
+func (tr *GrpcTransportReceiver) Protocol() TransportProtocol {
+	return GRPC
+}
+
+// Returns port.
+func (tr *GrpcTransportReceiver) Port() uint16 {
+	return tr.port
+}
+
+// Creates new instance of GrpcTransportReceiver.
+// The listener is used to accept the connections, if the listener is nil, the port is listened via TCP.
+func NewGrpcReceiver(port uint16, listener net.Listener) *GrpcTransportReceiver {
+	receiver := &GrpcTransportReceiver{
+		port:           port,
+		listener:       listener,
+		server:         grpc.NewServer(grpc.MaxRecvMsgSize(grpcMaxMessageSize), grpc.MaxSendMsgSize(grpcMaxMessageSize)),
+		handlers:       map[string]func(Request) ([]byte, any, error){},
+		streamHandlers: map[string]func(StreamRequest, io.Writer) error{},
+	}
+	receiver.server.RegisterService(grpcServiceDesc(&grpcService{receiver: receiver}), nil)
+	return receiver
+}
+
+// Returns the description of the netfs service whose methods call the handlers of the service.
+// The generated description is used for the names and the kinds of the methods.
+func grpcServiceDesc(service *grpcService) *grpc.ServiceDesc {
+	desc := &grpc.ServiceDesc{ServiceName: pb.Netfs_ServiceDesc.ServiceName, HandlerType: (*any)(nil), Metadata: pb.Netfs_ServiceDesc.Metadata}
+	for _, method := range grpcMethods {
+		if method.desc.IsStreamingClient() || method.desc.IsStreamingServer() {
+			streamDesc := method.streamDesc()
+			streamDesc.Handler = func(_ any, stream grpc.ServerStream) error {
+				return service.stream(method, stream)
+			}
+			desc.Streams = append(desc.Streams, *streamDesc)
+		} else {
+			desc.Methods = append(desc.Methods, grpc.MethodDesc{
+				MethodName: string(method.desc.Name()),
+				Handler: func(_ any, ctx context.Context, decode func(any) error, _ grpc.UnaryServerInterceptor) (any, error) {
+					return service.call(ctx, method, decode)
+				},
+			})
+		}
+	}
+	return desc
+}
+
+// Implementation of the gRPC service which calls the handlers of the receiver.
+type grpcService struct {
+	receiver *GrpcTransportReceiver
+}
+
+// Handles the call of the method without streams.
+func (service *grpcService) call(ctx context.Context, method *grpcMethod, decode func(any) error) (any, error) {
+	message := method.request.New()
+	if err := decode(message.Interface()); err != nil {
+		return nil, err
+	}
+
+	endpoint, ok := service.receiver.endpoint(method)
+	if !ok {
+		return nil, status.Errorf(codes.Unimplemented, "method [%s] is not received", method.name)
+	}
+	handle, _ := service.receiver.handler(endpoint)
+
+	params, body, err := method.requestParams(message)
+	if err == nil {
+		req := &request{ip: peerIP(ctx), endpoint: endpoint, params: params, rawBody: body}
+
+		var rawResBody []byte
+		var resBody any
+		if rawResBody, resBody, err = handle(req); err == nil && resBody != nil {
+			rawResBody, err = json.Marshal(resBody)
+		}
+		if err == nil {
+			var response proto.Message
+			if response, err = method.newResponse(rawResBody); err == nil {
+				return response, nil
+			}
+		}
+	}
+	return nil, grpcError(err)
+}
+
+// Handles the call of the streaming method, the first message of the request contains the parameters.
+func (service *grpcService) stream(method *grpcMethod, stream grpc.ServerStream) error {
+	message := method.request.New()
+	if err := stream.RecvMsg(message.Interface()); err != nil {
+		return err
+	}
+
+	endpoint, ok := service.receiver.endpoint(method)
+	if !ok {
+		return status.Errorf(codes.Unimplemented, "method [%s] is not received", method.name)
+	}
+	handle, _ := service.receiver.streamHandler(endpoint)
+
+	params, body, err := method.requestParams(message)
+	if err != nil {
+		return grpcError(err)
+	}
+
+	req := &streamRequest{request: request{ip: peerIP(stream.Context()), endpoint: endpoint, params: params}, ctx: stream.Context()}
+	if method.desc.IsStreamingClient() {
+		req.reader = &grpcStreamReader{data: body, recv: func() ([]byte, error) {
+			message := method.request.New()
+			err := stream.RecvMsg(message.Interface())
+			return message.Get(message.Descriptor().Fields().ByName(grpcDataField)).Bytes(), err
+		}}
+	} else {
+		req.rawBody = body
+		req.reader = bytes.NewReader(body)
+	}
+
+	// The response of the method without the server stream is sent by one message after handling.
+	switch {
+	case !method.desc.IsStreamingServer():
+		buffer := &bytes.Buffer{}
+		if err = handle(req, buffer); err == nil {
+			var response proto.Message
+			if response, err = method.newResponse(buffer.Bytes()); err == nil {
+				err = stream.SendMsg(response)
+			}
+		}
+	case method.recordArray() || method.jsonLines:
+		writer := &grpcRecordWriter{method: method, send: stream.SendMsg}
+		if err = handle(req, writer); err == nil {
+			err = writer.end()
+		}
+	default:
+		err = handle(req, &grpcStreamWriter{send: func(data []byte) error {
+			return stream.SendMsg(method.dataResponse(data))
+		}})
+	}
+
+	if err != nil {
+		return grpcError(err)
+	}
+	return nil
+}
+
+// Returns IP of the remote side of the call.
+func peerIP(ctx context.Context) net.IP {
+	if remote, ok := peer.FromContext(ctx); ok && remote.Addr != nil {
+		host, _, err := net.SplitHostPort(remote.Addr.String())
+		if err != nil {
+			host = remote.Addr.String()
+		}
+		return net.ParseIP(host)
+	}
+	return nil
+}
@@ -0,0 +1,608 @@
+package transport
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"netfs/api/transport/pb"
+
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+const grpcServiceName = "Netfs"
+const grpcBodyField = "body"
+const grpcDataField = "data"
+const grpcTimestamp = "google.protobuf.Timestamp"
+const grpcDuration = "google.protobuf.Duration"
+
+// The characters which separate the records of the streamed response.
+const grpcRecordSeparators = " \t\r\n[,]"
+
+// The method of the netfs service, the fields of its messages define how the requests and the responses are converted.
+type grpcMethod struct {
+	name      string
+	desc      protoreflect.MethodDescriptor
+	request   protoreflect.MessageType
+	response  protoreflect.MessageType
+	endpoints []string
+	jsonLines bool
+}
+
+// The methods of the netfs service and the methods by the names of their endpoints.
+var grpcMethods, grpcEndpoints = newGrpcMethods()
+
+// Reads the methods and their options from the descriptor of the service.
+func newGrpcMethods() ([]*grpcMethod, map[string]*grpcMethod) {
+	service := pb.File_transport_proto.Services().ByName(grpcServiceName)
+	methods := make([]*grpcMethod, service.Methods().Len())
+	endpoints := map[string]*grpcMethod{}
+	for index := range methods {
+		desc := service.Methods().Get(index)
+		method := &grpcMethod{
+			name:      fmt.Sprintf("/%s/%s", service.FullName(), desc.Name()),
+			desc:      desc,
+			request:   dynamicpb.NewMessageType(desc.Input()),
+			response:  dynamicpb.NewMessageType(desc.Output()),
+			endpoints: proto.GetExtension(desc.Options(), pb.E_Endpoints).([]string),
+			jsonLines: proto.GetExtension(desc.Options(), pb.E_JsonLines).(bool),
+		}
+		for _, endpoint := range method.endpoints {
+			endpoints[endpoint] = method
+		}
+		methods[index] = method
+	}
+	return methods, endpoints
+}
+
+// Returns the method which serves the endpoint.
+func grpcMethodOf(endpoint string) (*grpcMethod, error) {
+	if method, ok := grpcEndpoints[endpoint]; ok {
+		return method, nil
+	}
+	return nil, fmt.Errorf("%w: endpoint [%s] is not served by gRPC", ErrUnsupportedProtocol, endpoint)
+}
+
+// Returns the full name of the gRPC method which serves the endpoint.
+func GrpcMethod(endpoint string) (string, bool) {
+	method, err := grpcMethodOf(endpoint)
+	if err != nil {
+		return "", false
+	}
+	return method.name, true
+}
+
+// Returns the description of the streams of the method.
+func (method *grpcMethod) streamDesc() *grpc.StreamDesc {
+	return &grpc.StreamDesc{
+		StreamName:    string(method.desc.Name()),
+		ServerStreams: method.desc.IsStreamingServer(),
+		ClientStreams: method.desc.IsStreamingClient(),
+	}
+}
+
+// Creates the request message by the parameters and the body of the request.
+// The body is JSON if the message has the body field, otherwise the body is raw data.
+func (method *grpcMethod) newRequest(params []string, body []byte) (proto.Message, error) {
+	message := method.request.New()
+	fields := message.Descriptor().Fields()
+	for index := 0; index+1 < len(params); index += 2 {
+		field := fields.ByJSONName(params[index])
+		if field == nil || field.Name() == grpcBodyField || field.Name() == grpcDataField {
+			return nil, fmt.Errorf("[%s] %w: parameter is not supported by [%s]", params[index], ErrIncorrectParamValue, method.name)
+		}
+
+		value, err := paramValue(message, field, params[index+1])
+		if err != nil {
+			return nil, err
+		}
+		message.Set(field, value)
+	}
+
+	var err error
+	if len(body) > 0 {
+		if field := fields.ByName(grpcBodyField); field != nil {
+			err = setJSON(message, field, body)
+		} else if field := fields.ByName(grpcDataField); field != nil {
+			message.Set(field, protoreflect.ValueOfBytes(body))
+		} else {
+			err = fmt.Errorf("%w: body is not supported by [%s]", ErrIncorrectParamValue, method.name)
+		}
+	}
+	return message.Interface(), err
+}
+
+// Creates the request message which contains only the chunk of the body.
+func (method *grpcMethod) dataRequest(data []byte) proto.Message {
+	message := method.request.New()
+	message.Set(message.Descriptor().Fields().ByName(grpcDataField), protoreflect.ValueOfBytes(data))
+	return message.Interface()
+}
+
+// Returns the parameters and the body of the request message.
+func (method *grpcMethod) requestParams(message protoreflect.Message) ([]string, []byte, error) {
+	var body []byte
+	params := []string{}
+	fields := message.Descriptor().Fields()
+	for index := range fields.Len() {
+		field := fields.Get(index)
+		switch {
+		case field.Name() == grpcDataField:
+			body = message.Get(field).Bytes()
+		case !message.Has(field):
+		case field.Name() == grpcBodyField:
+			var err error
+			if body, err = appendValue(nil, message, field); err != nil {
+				return nil, nil, err
+			}
+		default:
+			params = append(params, field.JSONName(), paramText(field, message.Get(field)))
+		}
+	}
+	return params, body, nil
+}
+
+// Creates the response message by the body of the response.
+// The body is JSON if the message has the body field, otherwise the body is raw data.
+func (method *grpcMethod) newResponse(body []byte) (proto.Message, error) {
+	var err error
+	message := method.response.New()
+	fields := message.Descriptor().Fields()
+	if len(body) > 0 {
+		if field := fields.ByName(grpcBodyField); field != nil {
+			err = setJSON(message, field, body)
+		} else if field := fields.ByName(grpcDataField); field != nil {
+			message.Set(field, protoreflect.ValueOfBytes(body))
+		}
+	}
+	return message.Interface(), err
+}
+
+// Creates the response message which contains only the chunk of the body.
+func (method *grpcMethod) dataResponse(data []byte) proto.Message {
+	message := method.response.New()
+	message.Set(message.Descriptor().Fields().ByName(grpcDataField), protoreflect.ValueOfBytes(data))
+	return message.Interface()
+}
+
+// Returns true if the streamed response contains the records which are joined to JSON array.
+func (method *grpcMethod) recordArray() bool {
+	field := method.desc.Output().Fields().ByName(grpcBodyField)
+	return method.desc.IsStreamingServer() && !method.jsonLines && field != nil && field.IsList()
+}
+
+// Receives the body of the response by messages.
+type grpcResponseBody struct {
+	method  *grpcMethod
+	recv    func(any) error
+	started bool
+	ended   bool
+}
+
+// Returns the next part of the body, the records of the streamed response are joined as the method defines.
+func (body *grpcResponseBody) next() ([]byte, error) {
+	if body.ended {
+		return nil, io.EOF
+	}
+
+	message := body.method.response.New()
+	err := body.recv(message.Interface())
+	if errors.Is(err, io.EOF) && body.method.recordArray() {
+		body.ended = true
+		if !body.started {
+			return []byte("[]"), nil
+		}
+		return []byte("]"), nil
+	} else if err != nil {
+		return nil, err
+	}
+	return body.convert(message)
+}
+
+// Returns the body of the response message.
+func (body *grpcResponseBody) convert(message protoreflect.Message) ([]byte, error) {
+	fields := message.Descriptor().Fields()
+	if field := fields.ByName(grpcDataField); field != nil {
+		return message.Get(field).Bytes(), nil
+	}
+
+	field := fields.ByName(grpcBodyField)
+	if field == nil || (!field.IsList() && !message.Has(field)) {
+		return nil, nil
+	} else if !field.IsList() || !body.method.desc.IsStreamingServer() {
+		return appendValue(nil, message, field)
+	}
+
+	var data []byte
+	list := message.Get(field).List()
+	for index := range list.Len() {
+		if !body.method.jsonLines {
+			if body.started {
+				data = append(data, ',')
+			} else {
+				data = append(data, '[')
+			}
+		}
+		body.started = true
+
+		var err error
+		if data, err = appendMessage(data, list.Get(index).Message()); err != nil {
+			return nil, err
+		}
+		if body.method.jsonLines {
+			data = append(data, '\n')
+		}
+	}
+	return data, nil
+}
+
+// Converts the JSON records written by the handler to the messages of the streamed response.
+// The records may be written as JSON array or as JSON lines, every writing sends all complete records.
+type grpcRecordWriter struct {
+	method  *grpcMethod
+	send    func(any) error
+	buffer  []byte
+	started bool
+}
+
+func (writer *grpcRecordWriter) Write(data []byte) (int, error) {
+	writer.buffer = append(writer.buffer, data...)
+
+	message := writer.method.response.New()
+	list := message.Mutable(message.Descriptor().Fields().ByName(grpcBodyField)).List()
+	for {
+		writer.buffer = bytes.TrimLeft(writer.buffer, grpcRecordSeparators)
+		if len(writer.buffer) == 0 {
+			break
+		}
+
+		// The incomplete record is kept until the next writing.
+		var value any
+		decoder := json.NewDecoder(bytes.NewReader(writer.buffer))
+		decoder.UseNumber()
+		if err := decoder.Decode(&value); errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return 0, err
+		}
+
+		element := list.NewElement()
+		if err := setMessage(element.Message(), value); err != nil {
+			return 0, err
+		}
+		list.Append(element)
+		writer.buffer = writer.buffer[decoder.InputOffset():]
+	}
+
+	if list.Len() > 0 {
+		writer.started = true
+		if err := writer.send(message.Interface()); err != nil {
+			return 0, err
+		}
+	}
+	return len(data), nil
+}
+
+// The message without records is sent to start the response.
+func (writer *grpcRecordWriter) Flush() {
+	if !writer.started {
+		writer.started = writer.send(writer.method.response.New().Interface()) == nil
+	}
+}
+
+// Returns the error if the last record is not complete.
+func (writer *grpcRecordWriter) end() error {
+	if len(bytes.TrimLeft(writer.buffer, grpcRecordSeparators)) > 0 {
+		return fmt.Errorf("%w: the last record of [%s] is not complete", ErrUnexpectedAnswer, writer.method.name)
+	}
+	return nil
+}
+
+// Converts the text of the parameter to the value of the field.
+func paramValue(message protoreflect.Message, field protoreflect.FieldDescriptor, text string) (protoreflect.Value, error) {
+	var err error
+	var value protoreflect.Value
+	switch field.Kind() {
+	case protoreflect.StringKind:
+		value = protoreflect.ValueOfString(text)
+	case protoreflect.BoolKind:
+		var param bool
+		param, err = strconv.ParseBool(text)
+		value = protoreflect.ValueOfBool(param)
+	case protoreflect.Int64Kind:
+		var param int64
+		param, err = strconv.ParseInt(text, decimalBase, uint64BitSize)
+		value = protoreflect.ValueOfInt64(param)
+	case protoreflect.Uint64Kind:
+		var param uint64
+		param, err = strconv.ParseUint(text, decimalBase, uint64BitSize)
+		value = protoreflect.ValueOfUint64(param)
+	case protoreflect.MessageKind:
+		var param time.Time
+		if param, err = time.Parse(time.RFC3339Nano, text); err == nil && field.Message().FullName() == grpcTimestamp {
+			value = message.NewField(field)
+			setTime(value.Message(), param)
+		}
+	}
+
+	if err == nil && !value.IsValid() {
+		err = fmt.Errorf("kind [%s] is not supported", field.Kind())
+	}
+	if err != nil {
+		err = errors.Join(fmt.Errorf("[%s] %w", field.JSONName(), ErrIncorrectParamValue), err)
+	}
+	return value, err
+}
+
+// Returns the text of the parameter by the value of the field.
+func paramText(field protoreflect.FieldDescriptor, value protoreflect.Value) string {
+	switch field.Kind() {
+	case protoreflect.BoolKind:
+		return strconv.FormatBool(value.Bool())
+	case protoreflect.Int64Kind:
+		return strconv.FormatInt(value.Int(), decimalBase)
+	case protoreflect.Uint64Kind:
+		return strconv.FormatUint(value.Uint(), decimalBase)
+	case protoreflect.MessageKind:
+		return timeOf(value.Message()).Format(time.RFC3339Nano)
+	}
+	return value.String()
+}
+
+// Sets the field of the message by the JSON value.
+func setJSON(message protoreflect.Message, field protoreflect.FieldDescriptor, data []byte) error {
+	var value any
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	err := decoder.Decode(&value)
+	if err == nil {
+		err = setField(message, field, value)
+	}
+	return err
+}
+
+// Sets the field of the message by the decoded JSON value, null leaves the field unset.
+func setField(message protoreflect.Message, field protoreflect.FieldDescriptor, value any) error {
+	if value == nil {
+		return nil
+	}
+
+	if field.IsList() {
+		items, ok := value.([]any)
+		if !ok {
+			return fmt.Errorf("[%s] %w: array is expected", field.JSONName(), ErrIncorrectParamValue)
+		}
+
+		list := message.Mutable(field).List()
+		for _, item := range items {
+			var err error
+			var element protoreflect.Value
+			if field.Message() != nil {
+				element = list.NewElement()
+				err = setMessage(element.Message(), item)
+			} else {
+				element, err = jsonValue(field, item)
+			}
+			if err != nil {
+				return err
+			}
+			list.Append(element)
+		}
+		return nil
+	}
+
+	if field.Message() != nil {
+		return setMessage(message.Mutable(field).Message(), value)
+	}
+
+	fieldValue, err := jsonValue(field, value)
+	if err == nil {
+		message.Set(field, fieldValue)
+	}
+	return err
+}
+
+// Sets the fields of the message by the decoded JSON object.
+// The time is RFC 3339 text and the duration is the count of nanoseconds as the Go types are encoded.
+func setMessage(message protoreflect.Message, value any) error {
+	if value == nil {
+		return nil
+	}
+
+	switch message.Descriptor().FullName() {
+	case grpcTimestamp:
+		text, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("[%s] %w: time is expected", message.Descriptor().Name(), ErrIncorrectParamValue)
+		}
+		parsed, err := time.Parse(time.RFC3339Nano, text)
+		if err == nil {
+			setTime(message, parsed)
+		}
+		return err
+	case grpcDuration:
+		number, ok := value.(json.Number)
+		if !ok {
+			return fmt.Errorf("[%s] %w: duration is expected", message.Descriptor().Name(), ErrIncorrectParamValue)
+		}
+		nanoseconds, err := number.Int64()
+		if err == nil {
+			setDuration(message, time.Duration(nanoseconds))
+		}
+		return err
+	}
+
+	object, ok := value.(map[string]any)
+	if !ok {
+		return fmt.Errorf("[%s] %w: object is expected", message.Descriptor().Name(), ErrIncorrectParamValue)
+	}
+
+	// The unknown fields are skipped as encoding/json does.
+	fields := message.Descriptor().Fields()
+	for name, item := range object {
+		if field := fields.ByJSONName(name); field != nil {
+			if err := setField(message, field, item); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Converts the decoded JSON value to the value of the scalar field.
+func jsonValue(field protoreflect.FieldDescriptor, value any) (protoreflect.Value, error) {
+	var err error
+	var result protoreflect.Value
+
+	text, isText := value.(string)
+	number, isNumber := value.(json.Number)
+	flag, isFlag := value.(bool)
+	switch kind := field.Kind(); {
+	case kind == protoreflect.StringKind && isText:
+		result = protoreflect.ValueOfString(text)
+	case kind == protoreflect.BytesKind && isText:
+		var data []byte
+		data, err = base64.StdEncoding.DecodeString(text)
+		result = protoreflect.ValueOfBytes(data)
+	case kind == protoreflect.BoolKind && isFlag:
+		result = protoreflect.ValueOfBool(flag)
+	case kind == protoreflect.Int64Kind && isNumber:
+		var parsed int64
+		parsed, err = strconv.ParseInt(number.String(), decimalBase, uint64BitSize)
+		result = protoreflect.ValueOfInt64(parsed)
+	case kind == protoreflect.EnumKind && isNumber:
+		var parsed int64
+		parsed, err = strconv.ParseInt(number.String(), decimalBase, 32)
+		result = protoreflect.ValueOfEnum(protoreflect.EnumNumber(parsed))
+	case kind == protoreflect.Uint64Kind && isNumber:
+		var parsed uint64
+		parsed, err = strconv.ParseUint(number.String(), decimalBase, uint64BitSize)
+		result = protoreflect.ValueOfUint64(parsed)
+	case kind == protoreflect.Uint32Kind && isNumber:
+		var parsed uint64
+		parsed, err = strconv.ParseUint(number.String(), decimalBase, 32)
+		result = protoreflect.ValueOfUint32(uint32(parsed))
+	default:
+		err = fmt.Errorf("[%s] value of kind [%s] is expected", field.JSONName(), kind)
+	}
+
+	if err != nil {
+		err = errors.Join(fmt.Errorf("[%s] %w", field.JSONName(), ErrIncorrectParamValue), err)
+	}
+	return result, err
+}
+
+// Appends the message as JSON object, the names of the fields are their JSON names.
+func appendMessage(data []byte, message protoreflect.Message) ([]byte, error) {
+	switch message.Descriptor().FullName() {
+	case grpcTimestamp:
+		return appendJSON(data, timeOf(message))
+	case grpcDuration:
+		return strconv.AppendInt(data, int64(durationOf(message)), decimalBase), nil
+	}
+
+	var err error
+	data = append(data, '{')
+	fields := message.Descriptor().Fields()
+	for index := range fields.Len() {
+		field := fields.Get(index)
+		if index > 0 {
+			data = append(data, ',')
+		}
+		if data, err = appendJSON(data, field.JSONName()); err != nil {
+			return nil, err
+		}
+		data = append(data, ':')
+		if data, err = appendValue(data, message, field); err != nil {
+			return nil, err
+		}
+	}
+	return append(data, '}'), nil
+}
+
+// Appends the value of the field as JSON, the unset message is null and the unset list is empty array.
+func appendValue(data []byte, message protoreflect.Message, field protoreflect.FieldDescriptor) ([]byte, error) {
+	var err error
+	if field.IsList() {
+		data = append(data, '[')
+		list := message.Get(field).List()
+		for index := range list.Len() {
+			if index > 0 {
+				data = append(data, ',')
+			}
+			if data, err = appendScalar(data, field, list.Get(index)); err != nil {
+				return nil, err
+			}
+		}
+		return append(data, ']'), nil
+	}
+
+	if field.Message() != nil && !message.Has(field) {
+		return append(data, "null"...), nil
+	}
+	return appendScalar(data, field, message.Get(field))
+}
+
+// Appends the single value of the field as JSON.
+func appendScalar(data []byte, field protoreflect.FieldDescriptor, value protoreflect.Value) ([]byte, error) {
+	switch field.Kind() {
+	case protoreflect.MessageKind:
+		return appendMessage(data, value.Message())
+	case protoreflect.StringKind:
+		return appendJSON(data, value.String())
+	case protoreflect.BytesKind:
+		return appendJSON(data, value.Bytes())
+	case protoreflect.BoolKind:
+		return strconv.AppendBool(data, value.Bool()), nil
+	case protoreflect.Int64Kind:
+		return strconv.AppendInt(data, value.Int(), decimalBase), nil
+	case protoreflect.EnumKind:
+		return strconv.AppendInt(data, int64(value.Enum()), decimalBase), nil
+	case protoreflect.Uint64Kind, protoreflect.Uint32Kind:
+		return strconv.AppendUint(data, value.Uint(), decimalBase), nil
+	}
+	return nil, fmt.Errorf("[%s] kind [%s] is not supported", field.JSONName(), field.Kind())
+}
+
+// Appends the value encoded by encoding/json.
+func appendJSON(data []byte, value any) ([]byte, error) {
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	return append(data, encoded...), nil
+}
+
+// Sets the time to google.protobuf.Timestamp.
+func setTime(message protoreflect.Message, value time.Time) {
+	fields := message.Descriptor().Fields()
+	message.Set(fields.ByName("seconds"), protoreflect.ValueOfInt64(value.Unix()))
+	message.Set(fields.ByName("nanos"), protoreflect.ValueOfInt32(int32(value.Nanosecond())))
+}
+
+// Returns the time of google.protobuf.Timestamp in UTC.
+func timeOf(message protoreflect.Message) time.Time {
+	fields := message.Descriptor().Fields()
+	return time.Unix(message.Get(fields.ByName("seconds")).Int(), message.Get(fields.ByName("nanos")).Int()).UTC()
+}
+
+// Sets the duration to google.protobuf.Duration.
+func setDuration(message protoreflect.Message, value time.Duration) {
+	fields := message.Descriptor().Fields()
+	message.Set(fields.ByName("seconds"), protoreflect.ValueOfInt64(int64(value/time.Second)))
+	message.Set(fields.ByName("nanos"), protoreflect.ValueOfInt32(int32(value%time.Second)))
+}
+
+// Returns the duration of google.protobuf.Duration.
+func durationOf(message protoreflect.Message) time.Duration {
+	fields := message.Descriptor().Fields()
+	return time.Duration(message.Get(fields.ByName("seconds")).Int())*time.Second + time.Duration(message.Get(fields.ByName("nanos")).Int())
+}
@@ -16,116 +16,168 @@ import (
 
 const portSeparator = ":"
 const paramsSeparator = "?"
+const methodSeparator = " "
 const httpProtocol = "http://"
-const uint64BitSize = 64
-const decimalBase = 10
-
-type httpRequest struct {
-	ip       net.IP
-	endpoint string
-	params   []string
-	rawBody  []byte
+const contentType = "Content-Type"
+const jsonContentType = "application/json"
+const acceptEncoding = "Accept-Encoding"
+const contentEncoding = "Content-Encoding"
+const compressThreshold = 1024 // The smaller responses are not compressed.
+
+// HTTP statuses of the error kinds.
+var errorStatuses = map[ErrorKind]int{
+	KindInternal:            http.StatusInternalServerError,
+	KindBadRequest:          http.StatusBadRequest,
+	KindNotFound:            http.StatusNotFound,
+	KindForbidden:           http.StatusForbidden,
+	KindConflict:            http.StatusConflict,
+	KindInsufficientStorage: http.StatusInsufficientStorage,
+	KindUnavailable:         http.StatusServiceUnavailable,
 }
 
-func (req *httpRequest) IP() net.IP {
-	return req.ip
-}
-
-func (req *httpRequest) Endpoint() string {
-	return req.endpoint
+// Splits the endpoint to the HTTP method and the path.
+// The endpoint without the method, for example "/netfs/api/server/host", uses POST.
+func splitEndpoint(endpoint string) (string, string) {
+	if method, path, found := strings.Cut(endpoint, methodSeparator); found {
+		return method, path
+	}
+	return http.MethodPost, endpoint
 }
 
-func (req *httpRequest) Param(name string) string {
-	for index, param := range req.params {
-		if param == name && index < len(req.params)-1 {
-			return req.params[index+1]
+// Returns the error kind by the HTTP status.
+func errorKind(status int) ErrorKind {
+	for kind, kindStatus := range errorStatuses {
+		if kindStatus == status {
+			return kind
 		}
 	}
-	return ""
+	return KindInternal
 }
 
-func (req *httpRequest) ParamRequired(name string) (string, error) {
-	param := req.Param(name)
-	if param == "" {
-		return param, ErrRequiredParam
+// Returns IP of the remote side of the request.
+func remoteIP(httpReq *http.Request) net.IP {
+	host, _, err := net.SplitHostPort(httpReq.RemoteAddr)
+	if err != nil {
+		host = httpReq.RemoteAddr
 	}
-	return param, nil
+	return net.ParseIP(host)
 }
 
-func (req *httpRequest) ParamInt(name string) (int, error) {
-	param := 0
-	value, err := req.ParamRequired(name)
-	if err == nil {
-		param, err = strconv.Atoi(value)
-		if err != nil {
-			err = errors.Join(fmt.Errorf("[%s] %w", name, ErrIncorrectParamValue), err)
-		}
+// Returns parameters of the request.
+func requestParams(httpReq *http.Request) []string {
+	query := httpReq.URL.Query()
+	parameters := []string{}
+	for key := range query {
+		parameters = append(parameters, key)
+		parameters = append(parameters, query.Get(key))
 	}
-	return param, err
+	return parameters
 }
 
-func (req *httpRequest) ParamUInt64(name string) (uint64, error) {
-	param := uint64(0)
-	value, err := req.ParamRequired(name)
-	if err == nil {
-		param, err = strconv.ParseUint(value, decimalBase, uint64BitSize)
-		if err != nil {
-			err = errors.Join(fmt.Errorf("[%s] %w", name, ErrIncorrectParamValue), err)
+// Writes the error to the response.
+func writeError(httpRes http.ResponseWriter, err error) {
+	envelope := ToError(err)
+	rawResBody, _ := json.Marshal(envelope)
+
+	httpRes.Header().Set(contentType, jsonContentType)
+	httpRes.WriteHeader(errorStatuses[envelope.Kind])
+	httpRes.Write(rawResBody)
+}
+
+// Writes the body to the response, the large body is compressed by the codec accepted by the client.
+func writeBody(httpRes http.ResponseWriter, httpReq *http.Request, rawResBody []byte) {
+	if len(rawResBody) >= compressThreshold {
+		if codec := NegotiateCodec(parseCodecs(httpReq.Header.Get(acceptEncoding))); codec != CodecNone {
+			if compressed, err := compressMessage(rawResBody, codec); err == nil {
+				httpRes.Header().Set(contentEncoding, string(codec))
+				rawResBody = compressed
+			}
 		}
 	}
-	return param, err
+	httpRes.Write(rawResBody)
 }
 
-func (req *httpRequest) Params() []string {
-	return req.params
+// The body decompresses the response and closes the source body.
+type decompressedBody struct {
+	io.ReadCloser
+	body io.ReadCloser
 }
 
-func (req *httpRequest) RawBody() []byte {
-	return req.rawBody
+func (body *decompressedBody) Close() error {
+	return errors.Join(body.ReadCloser.Close(), body.body.Close())
 }
 
-func (req *httpRequest) Body(target any) (any, error) {
-	return target, json.Unmarshal(req.rawBody, target)
+// The writer remembers if the response has been started and compresses it by the negotiated codec.
+type httpStreamWriter struct {
+	httpRes    http.ResponseWriter
+	codec      Codec
+	compressor *CompressWriter
+	written    bool
 }
 
-type httpResponse struct {
-	ip       net.IP
-	endpoint string
-	rawBody  []byte
-}
+// Starts the response, the encoding header is set before the first data.
+func (writer *httpStreamWriter) start() io.Writer {
+	if !writer.written {
+		writer.written = true
+		if writer.codec != CodecNone {
+			if compressor, err := NewCompressWriter(writer.httpRes, writer.codec); err == nil {
+				writer.httpRes.Header().Set(contentEncoding, string(writer.codec))
+				writer.compressor = compressor
+			}
+		}
+	}
 
-func (res *httpResponse) IP() net.IP {
-	return res.ip
+	if writer.compressor != nil {
+		return writer.compressor
+	}
+	return writer.httpRes
 }
 
-func (res *httpResponse) Endpoint() string {
-	return res.endpoint
+func (writer *httpStreamWriter) Write(data []byte) (int, error) {
+	return writer.start().Write(data)
 }
 
-func (res *httpResponse) RawBody() []byte {
-	return res.rawBody
+// The flushed response is started, so the later error aborts the connection.
+func (writer *httpStreamWriter) Flush() {
+	if compressor, ok := writer.start().(*CompressWriter); ok {
+		compressor.Flush()
+	}
+	if flusher, ok := writer.httpRes.(http.Flusher); ok {
+		flusher.Flush()
+	}
 }
 
-func (res *httpResponse) Body(target any) (any, error) {
-	return target, json.Unmarshal(res.rawBody, target)
+// Writes the end of the compressed response.
+func (writer *httpStreamWriter) end() error {
+	if writer.compressor != nil {
+		return writer.compressor.Close()
+	}
+	return nil
 }
 
 // Sending data via the HTTP protocol.
 type HttpTransportSender struct {
-	client *http.Client
-	port   uint16
+	client        *http.Client
+	streamClient  *http.Client
+	channelClient *http.Client
+	port          uint16
 }
 
 // Creates new request instance by parameters.
 func (tr *HttpTransportSender) NewRequest(ip net.IP, endpoint string, parameters []string, rawBody []byte, body any) (Request, error) {
 	var err error
-	req := &httpRequest{ip: ip, endpoint: endpoint, params: parameters, rawBody: rawBody}
+	req := &request{ip: ip, endpoint: endpoint, params: parameters, rawBody: rawBody}
 	if body != nil {
 		req.rawBody, err = json.Marshal(body)
 	}
 	return req, err
 }
 
+// Creates new streaming request instance by parameters.
+func (tr *HttpTransportSender) NewStreamRequest(ip net.IP, endpoint string, parameters []string, reader io.Reader) (StreamRequest, error) {
+	return &streamRequest{request: request{ip: ip, endpoint: endpoint, params: parameters}, reader: reader}, nil
+}
+
 // Sends request.
 func (tr *HttpTransportSender) Send(req Request) (Response, error) {
 	var reader io.Reader
@@ -133,10 +185,52 @@ func (tr *HttpTransportSender) Send(req Request) (Response, error) {
 		reader = bytes.NewReader(body)
 	}
 
+	httpRes, err := tr.do(tr.client, req, reader)
+	if err == nil {
+		defer httpRes.Body.Close()
+
+		message, _ := io.ReadAll(httpRes.Body)
+		return &response{ip: req.IP(), endpoint: req.Endpoint(), rawBody: message}, nil
+	}
+	return nil, err
+}
+
+// Sends request and returns the response without reading its body.
+// The body of the streaming request is sent without buffering.
+func (tr *HttpTransportSender) SendStream(req Request) (StreamResponse, error) {
+	var reader io.Reader
+	if streamReq, ok := req.(StreamRequest); ok {
+		reader = streamReq.Reader()
+	} else if body := req.RawBody(); body != nil {
+		reader = bytes.NewReader(body)
+	}
+
+	httpRes, err := tr.do(tr.streamClient, req, reader)
+	if err == nil {
+		return &streamResponse{ip: req.IP(), endpoint: req.Endpoint(), body: httpRes.Body}, nil
+	}
+	return nil, err
+}
+
+// Opens the data channel to the endpoint.
+// The channel is the HTTP/2 stream, the streams to the same host share one connection.
+func (tr *HttpTransportSender) OpenChannel(ip net.IP, endpoint string, parameters []string) (Channel, error) {
+	return openChannel(ip, endpoint, parameters, tr, func(req Request) (StreamResponse, error) {
+		httpRes, err := tr.do(tr.channelClient, req, req.(StreamRequest).Reader())
+		if err == nil {
+			return &streamResponse{ip: req.IP(), endpoint: req.Endpoint(), body: httpRes.Body}, nil
+		}
+		return nil, err
+	})
+}
+
+// Sends request and returns the successful response, the body of the failed response is converted to the error.
+func (tr *HttpTransportSender) do(client *http.Client, req Request, reader io.Reader) (*http.Response, error) {
+	method, path := splitEndpoint(req.Endpoint())
 	endpoint, err := url.JoinPath(httpProtocol, req.IP().String())
 	if err == nil {
 		endpoint = strings.Join([]string{endpoint, strconv.Itoa(int(tr.Port()))}, portSeparator)
-		endpoint, err = url.JoinPath(endpoint, req.Endpoint())
+		endpoint, err = url.JoinPath(endpoint, path)
 		if params := req.Params(); err == nil && len(params) > 0 {
 			urlParams := url.Values{}
 			for index := range params {
@@ -150,16 +244,30 @@ func (tr *HttpTransportSender) Send(req Request) (Response, error) {
 
 	if err == nil {
 		var httpReq *http.Request
-		if httpReq, err = http.NewRequest(http.MethodPost, endpoint, reader); err == nil {
-			var httpRes *http.Response
-			if httpRes, err = tr.client.Do(httpReq); err == nil {
-				defer httpRes.Body.Close()
+		if httpReq, err = http.NewRequest(method, endpoint, reader); err == nil {
+			httpReq.Header.Set(acceptEncoding, formatCodecs(Codecs))
 
-				message, _ := io.ReadAll(httpRes.Body)
+			var httpRes *http.Response
+			if httpRes, err = client.Do(httpReq); err == nil {
 				if httpRes.StatusCode == http.StatusOK {
-					return &httpResponse{ip: req.IP(), endpoint: req.Endpoint(), rawBody: message}, nil
+					if codec := httpRes.Header.Get(contentEncoding); codec != "" {
+						var body io.ReadCloser
+						if body, err = Decompress(httpRes.Body, Codec(codec)); err != nil {
+							httpRes.Body.Close()
+							return nil, err
+						}
+						httpRes.Body = &decompressedBody{ReadCloser: body, body: httpRes.Body}
+					}
+					return httpRes, nil
 				} else {
-					if len(message) > 0 {
+					defer httpRes.Body.Close()
+
+					message, _ := io.ReadAll(httpRes.Body)
+					envelope := &Error{}
+					if httpRes.Header.Get(contentType) == jsonContentType && json.Unmarshal(message, envelope) == nil {
+						envelope.Kind = errorKind(httpRes.StatusCode)
+						err = envelope
+					} else if len(message) > 0 {
 						err = errors.Join(ErrUnexpectedAnswer, fmt.Errorf("status code is [%d], message is [%s]", httpRes.StatusCode, string(message)))
 					} else {
 						err = errors.Join(ErrUnexpectedAnswer, fmt.Errorf("status code is [%d]", httpRes.StatusCode))
@@ -191,7 +299,7 @@ type HttpTransportReceiver struct {
 // Creates new request instance by parameters.
 func (tr *HttpTransportReceiver) NewRequest(ip net.IP, endpoint string, parameters []string, rawBody []byte, body any) (Request, error) {
 	var err error
-	req := &httpRequest{ip: ip, endpoint: endpoint, params: parameters, rawBody: rawBody}
+	req := &request{ip: ip, endpoint: endpoint, params: parameters, rawBody: rawBody}
 	if body != nil {
 		req.rawBody, err = json.Marshal(body)
 	}
@@ -206,17 +314,8 @@ func (tr *HttpTransportReceiver) Receive(endpoint string, handle func(Request) (
 		var rawResBody []byte
 		body, err := io.ReadAll(httpReq.Body)
 		if err == nil {
-			ip := net.ParseIP(httpReq.RemoteAddr)
-
-			query := httpReq.URL.Query()
-			parameters := []string{}
-			for key := range query {
-				parameters = append(parameters, key)
-				parameters = append(parameters, query.Get(key))
-			}
-
 			var req Request
-			if req, err = tr.NewRequest(ip, endpoint, parameters, body, nil); err == nil {
+			if req, err = tr.NewRequest(remoteIP(httpReq), endpoint, requestParams(httpReq), body, nil); err == nil {
 				var resBody any
 				if rawResBody, resBody, err = handle(req); err == nil {
 					if resBody != nil {
@@ -227,18 +326,47 @@ func (tr *HttpTransportReceiver) Receive(endpoint string, handle func(Request) (
 		}
 
 		if err != nil {
-			httpRes.WriteHeader(http.StatusInternalServerError)
-			httpRes.Write([]byte(err.Error()))
+			writeError(httpRes, err)
 		} else {
-			httpRes.Write(rawResBody)
+			writeBody(httpRes, httpReq, rawResBody)
+		}
+	})
+}
+
+// Receives request and writes the response body to the writer without buffering.
+// The response is compressed by the codec negotiated by the Accept-Encoding header.
+// If the handler fails after writing a part of the response, the connection is aborted.
+func (tr *HttpTransportReceiver) ReceiveStream(endpoint string, handle func(StreamRequest, io.Writer) error) {
+	tr.mux.HandleFunc(endpoint, func(httpRes http.ResponseWriter, httpReq *http.Request) {
+		defer httpReq.Body.Close()
+
+		req := &streamRequest{
+			request: request{ip: remoteIP(httpReq), endpoint: endpoint, params: requestParams(httpReq)},
+			reader:  httpReq.Body,
+			ctx:     httpReq.Context(),
+		}
+
+		writer := &httpStreamWriter{httpRes: httpRes, codec: NegotiateCodec(parseCodecs(httpReq.Header.Get(acceptEncoding)))}
+		err := handle(req, writer)
+		if err == nil {
+			err = writer.end()
+		}
+		if err != nil {
+			if writer.written {
+				panic(http.ErrAbortHandler)
+			}
+			writeError(httpRes, err)
 		}
 	})
 }
 
 // Starts receiver.
 func (tr *HttpTransportReceiver) Start() error {
-	go func() { tr.server.ListenAndServe() }()
-	return nil
+	listener, err := net.Listen("tcp", tr.server.Addr)
+	if err == nil {
+		go func() { tr.server.Serve(listener) }()
+	}
+	return err
 }
 
 // Stops receiver.
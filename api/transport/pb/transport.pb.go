@@ -0,0 +1,3859 @@
+// The gRPC API of netfs, every method serves the endpoints of all API versions listed in its options.
+//
+// The Go code is generated by `go generate` in netfs/api/transport with the pinned tools:
+//   buf v1.50.0 (`buf alpha protoc`, compatible with protoc v5.29.3),
+//   protoc-gen-go v1.36.11,
+//   protoc-gen-go-grpc v1.5.1.
+//
+// The messages are converted to the JSON of the Go types of netfs/api:
+//   - the fields of the request, except body and data, are the parameters of the endpoint, json_name is the name of the parameter;
+//   - body is the JSON body of the request or the response, json_name of its fields is the name of the field of the Go type;
+//   - data is the raw body, the streams send it by chunks;
+//   - the streamed records are joined to JSON array, or to JSON lines if the method sets json_lines.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        v5.29.3
+// source: transport.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	descriptorpb "google.golang.org/protobuf/types/descriptorpb"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Type of the file.
+type FileType int32
+
+const (
+	FileType_FILE_TYPE_UNSPECIFIED FileType = 0
+	FileType_FILE_TYPE_FILE        FileType = 1
+	FileType_FILE_TYPE_DIRECTORY   FileType = 2
+)
+
+// Enum value maps for FileType.
+var (
+	FileType_name = map[int32]string{
+		0: "FILE_TYPE_UNSPECIFIED",
+		1: "FILE_TYPE_FILE",
+		2: "FILE_TYPE_DIRECTORY",
+	}
+	FileType_value = map[string]int32{
+		"FILE_TYPE_UNSPECIFIED": 0,
+		"FILE_TYPE_FILE":        1,
+		"FILE_TYPE_DIRECTORY":   2,
+	}
+)
+
+func (x FileType) Enum() *FileType {
+	p := new(FileType)
+	*p = x
+	return p
+}
+
+func (x FileType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (FileType) Descriptor() protoreflect.EnumDescriptor {
+	return file_transport_proto_enumTypes[0].Descriptor()
+}
+
+func (FileType) Type() protoreflect.EnumType {
+	return &file_transport_proto_enumTypes[0]
+}
+
+func (x FileType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use FileType.Descriptor instead.
+func (FileType) EnumDescriptor() ([]byte, []int) {
+	return file_transport_proto_rawDescGZIP(), []int{0}
+}
+
+// Protocol of the transport.
+type TransportProtocol int32
+
+const (
+	TransportProtocol_TRANSPORT_PROTOCOL_HTTP TransportProtocol = 0
+	TransportProtocol_TRANSPORT_PROTOCOL_CALL TransportProtocol = 1
+	TransportProtocol_TRANSPORT_PROTOCOL_GRPC TransportProtocol = 2
+)
+
+// Enum value maps for TransportProtocol.
+var (
+	TransportProtocol_name = map[int32]string{
+		0: "TRANSPORT_PROTOCOL_HTTP",
+		1: "TRANSPORT_PROTOCOL_CALL",
+		2: "TRANSPORT_PROTOCOL_GRPC",
+	}
+	TransportProtocol_value = map[string]int32{
+		"TRANSPORT_PROTOCOL_HTTP": 0,
+		"TRANSPORT_PROTOCOL_CALL": 1,
+		"TRANSPORT_PROTOCOL_GRPC": 2,
+	}
+)
+
+func (x TransportProtocol) Enum() *TransportProtocol {
+	p := new(TransportProtocol)
+	*p = x
+	return p
+}
+
+func (x TransportProtocol) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (TransportProtocol) Descriptor() protoreflect.EnumDescriptor {
+	return file_transport_proto_enumTypes[1].Descriptor()
+}
+
+func (TransportProtocol) Type() protoreflect.EnumType {
+	return &file_transport_proto_enumTypes[1]
+}
+
+func (x TransportProtocol) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use TransportProtocol.Descriptor instead.
+func (TransportProtocol) EnumDescriptor() ([]byte, []int) {
+	return file_transport_proto_rawDescGZIP(), []int{1}
+}
+
+// Status of the task.
+type TaskStatus int32
+
+const (
+	TaskStatus_TASK_STATUS_FAILED    TaskStatus = 0
+	TaskStatus_TASK_STATUS_RUNNING   TaskStatus = 1
+	TaskStatus_TASK_STATUS_CANCELLED TaskStatus = 2
+	TaskStatus_TASK_STATUS_COMPLETED TaskStatus = 3
+)
+
+// Enum value maps for TaskStatus.
+var (
+	TaskStatus_name = map[int32]string{
+		0: "TASK_STATUS_FAILED",
+		1: "TASK_STATUS_RUNNING",
+		2: "TASK_STATUS_CANCELLED",
+		3: "TASK_STATUS_COMPLETED",
+	}
+	TaskStatus_value = map[string]int32{
+		"TASK_STATUS_FAILED":    0,
+		"TASK_STATUS_RUNNING":   1,
+		"TASK_STATUS_CANCELLED": 2,
+		"TASK_STATUS_COMPLETED": 3,
+	}
+)
+
+func (x TaskStatus) Enum() *TaskStatus {
+	p := new(TaskStatus)
+	*p = x
+	return p
+}
+
+func (x TaskStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (TaskStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_transport_proto_enumTypes[2].Descriptor()
+}
+
+func (TaskStatus) Type() protoreflect.EnumType {
+	return &file_transport_proto_enumTypes[2]
+}
+
+func (x TaskStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use TaskStatus.Descriptor instead.
+func (TaskStatus) EnumDescriptor() ([]byte, []int) {
+	return file_transport_proto_rawDescGZIP(), []int{2}
+}
+
+type FileInfo struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,json=Id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,json=Name,proto3" json:"name,omitempty"`
+	Path          string                 `protobuf:"bytes,3,opt,name=path,json=Path,proto3" json:"path,omitempty"`
+	Type          FileType               `protobuf:"varint,4,opt,name=type,json=Type,proto3,enum=netfs.transport.FileType" json:"type,omitempty"`
+	Size          int64                  `protobuf:"varint,5,opt,name=size,json=Size,proto3" json:"size,omitempty"`
+	ParentId      string                 `protobuf:"bytes,6,opt,name=parent_id,json=ParentId,proto3" json:"parent_id,omitempty"`
+	ModTime       *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=mod_time,json=ModTime,proto3" json:"mod_time,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FileInfo) Reset() {
+	*x = FileInfo{}
+	mi := &file_transport_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FileInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FileInfo) ProtoMessage() {}
+
+func (x *FileInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_transport_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FileInfo.ProtoReflect.Descriptor instead.
+func (*FileInfo) Descriptor() ([]byte, []int) {
+	return file_transport_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *FileInfo) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *FileInfo) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *FileInfo) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *FileInfo) GetType() FileType {
+	if x != nil {
+		return x.Type
+	}
+	return FileType_FILE_TYPE_UNSPECIFIED
+}
+
+func (x *FileInfo) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *FileInfo) GetParentId() string {
+	if x != nil {
+		return x.ParentId
+	}
+	return ""
+}
+
+func (x *FileInfo) GetModTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ModTime
+	}
+	return nil
+}
+
+type RootSpace struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Path          string                 `protobuf:"bytes,1,opt,name=path,json=Path,proto3" json:"path,omitempty"`
+	Total         int64                  `protobuf:"varint,2,opt,name=total,json=Total,proto3" json:"total,omitempty"`
+	Free          int64                  `protobuf:"varint,3,opt,name=free,json=Free,proto3" json:"free,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RootSpace) Reset() {
+	*x = RootSpace{}
+	mi := &file_transport_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RootSpace) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RootSpace) ProtoMessage() {}
+
+func (x *RootSpace) ProtoReflect() protoreflect.Message {
+	mi := &file_transport_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RootSpace.ProtoReflect.Descriptor instead.
+func (*RootSpace) Descriptor() ([]byte, []int) {
+	return file_transport_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *RootSpace) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *RootSpace) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *RootSpace) GetFree() int64 {
+	if x != nil {
+		return x.Free
+	}
+	return 0
+}
+
+type HostCapabilities struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Version       string                 `protobuf:"bytes,1,opt,name=version,json=Version,proto3" json:"version,omitempty"`
+	ApiVersion    int64                  `protobuf:"varint,2,opt,name=api_version,json=ApiVersion,proto3" json:"api_version,omitempty"`
+	MinApiVersion int64                  `protobuf:"varint,3,opt,name=min_api_version,json=MinApiVersion,proto3" json:"min_api_version,omitempty"`
+	Os            string                 `protobuf:"bytes,4,opt,name=os,json=OS,proto3" json:"os,omitempty"`
+	Arch          string                 `protobuf:"bytes,5,opt,name=arch,json=Arch,proto3" json:"arch,omitempty"`
+	Protocols     []TransportProtocol    `protobuf:"varint,6,rep,packed,name=protocols,json=Protocols,proto3,enum=netfs.transport.TransportProtocol" json:"protocols,omitempty"`
+	Features      []string               `protobuf:"bytes,7,rep,name=features,json=Features,proto3" json:"features,omitempty"`
+	Codecs        []string               `protobuf:"bytes,8,rep,name=codecs,json=Codecs,proto3" json:"codecs,omitempty"`
+	Roots         []*RootSpace           `protobuf:"bytes,9,rep,name=roots,json=Roots,proto3" json:"roots,omitempty"`
+	Uptime        *durationpb.Duration   `protobuf:"bytes,10,opt,name=uptime,json=Uptime,proto3" json:"uptime,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HostCapabilities) Reset() {
+	*x = HostCapabilities{}
+	mi := &file_transport_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HostCapabilities) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HostCapabilities) ProtoMessage() {}
+
+func (x *HostCapabilities) ProtoReflect() protoreflect.Message {
+	mi := &file_transport_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HostCapabilities.ProtoReflect.Descriptor instead.
+func (*HostCapabilities) Descriptor() ([]byte, []int) {
+	return file_transport_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *HostCapabilities) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *HostCapabilities) GetApiVersion() int64 {
+	if x != nil {
+		return x.ApiVersion
+	}
+	return 0
+}
+
+func (x *HostCapabilities) GetMinApiVersion() int64 {
+	if x != nil {
+		return x.MinApiVersion
+	}
+	return 0
+}
+
+func (x *HostCapabilities) GetOs() string {
+	if x != nil {
+		return x.Os
+	}
+	return ""
+}
+
+func (x *HostCapabilities) GetArch() string {
+	if x != nil {
+		return x.Arch
+	}
+	return ""
+}
+
+func (x *HostCapabilities) GetProtocols() []TransportProtocol {
+	if x != nil {
+		return x.Protocols
+	}
+	return nil
+}
+
+func (x *HostCapabilities) GetFeatures() []string {
+	if x != nil {
+		return x.Features
+	}
+	return nil
+}
+
+func (x *HostCapabilities) GetCodecs() []string {
+	if x != nil {
+		return x.Codecs
+	}
+	return nil
+}
+
+func (x *HostCapabilities) GetRoots() []*RootSpace {
+	if x != nil {
+		return x.Roots
+	}
+	return nil
+}
+
+func (x *HostCapabilities) GetUptime() *durationpb.Duration {
+	if x != nil {
+		return x.Uptime
+	}
+	return nil
+}
+
+type RemoteHost struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Name  string                 `protobuf:"bytes,1,opt,name=name,json=Name,proto3" json:"name,omitempty"`
+	// The address in the text form.
+	Ip            string            `protobuf:"bytes,2,opt,name=ip,json=IP,proto3" json:"ip,omitempty"`
+	Capabilities  *HostCapabilities `protobuf:"bytes,3,opt,name=capabilities,json=Capabilities,proto3" json:"capabilities,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoteHost) Reset() {
+	*x = RemoteHost{}
+	mi := &file_transport_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoteHost) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoteHost) ProtoMessage() {}
+
+func (x *RemoteHost) ProtoReflect() protoreflect.Message {
+	mi := &file_transport_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoteHost.ProtoReflect.Descriptor instead.
+func (*RemoteHost) Descriptor() ([]byte, []int) {
+	return file_transport_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *RemoteHost) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *RemoteHost) GetIp() string {
+	if x != nil {
+		return x.Ip
+	}
+	return ""
+}
+
+func (x *RemoteHost) GetCapabilities() *HostCapabilities {
+	if x != nil {
+		return x.Capabilities
+	}
+	return nil
+}
+
+type RemoteFile struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Info          *FileInfo              `protobuf:"bytes,1,opt,name=info,json=Info,proto3" json:"info,omitempty"`
+	Host          *RemoteHost            `protobuf:"bytes,2,opt,name=host,json=Host,proto3" json:"host,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoteFile) Reset() {
+	*x = RemoteFile{}
+	mi := &file_transport_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoteFile) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoteFile) ProtoMessage() {}
+
+func (x *RemoteFile) ProtoReflect() protoreflect.Message {
+	mi := &file_transport_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoteFile.ProtoReflect.Descriptor instead.
+func (*RemoteFile) Descriptor() ([]byte, []int) {
+	return file_transport_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *RemoteFile) GetInfo() *FileInfo {
+	if x != nil {
+		return x.Info
+	}
+	return nil
+}
+
+func (x *RemoteFile) GetHost() *RemoteHost {
+	if x != nil {
+		return x.Host
+	}
+	return nil
+}
+
+type RemoteError struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          string                 `protobuf:"bytes,1,opt,name=code,json=Code,proto3" json:"code,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,json=Message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoteError) Reset() {
+	*x = RemoteError{}
+	mi := &file_transport_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoteError) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoteError) ProtoMessage() {}
+
+func (x *RemoteError) ProtoReflect() protoreflect.Message {
+	mi := &file_transport_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoteError.ProtoReflect.Descriptor instead.
+func (*RemoteError) Descriptor() ([]byte, []int) {
+	return file_transport_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *RemoteError) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *RemoteError) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type TaskStats struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Codec         string                 `protobuf:"bytes,1,opt,name=codec,json=Codec,proto3" json:"codec,omitempty"`
+	Bytes         int64                  `protobuf:"varint,2,opt,name=bytes,json=Bytes,proto3" json:"bytes,omitempty"`
+	Sent          int64                  `protobuf:"varint,3,opt,name=sent,json=Sent,proto3" json:"sent,omitempty"`
+	Saved         int64                  `protobuf:"varint,4,opt,name=saved,json=Saved,proto3" json:"saved,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TaskStats) Reset() {
+	*x = TaskStats{}
+	mi := &file_transport_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TaskStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TaskStats) ProtoMessage() {}
+
+func (x *TaskStats) ProtoReflect() protoreflect.Message {
+	mi := &file_transport_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TaskStats.ProtoReflect.Descriptor instead.
+func (*TaskStats) Descriptor() ([]byte, []int) {
+	return file_transport_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *TaskStats) GetCodec() string {
+	if x != nil {
+		return x.Codec
+	}
+	return ""
+}
+
+func (x *TaskStats) GetBytes() int64 {
+	if x != nil {
+		return x.Bytes
+	}
+	return 0
+}
+
+func (x *TaskStats) GetSent() int64 {
+	if x != nil {
+		return x.Sent
+	}
+	return 0
+}
+
+func (x *TaskStats) GetSaved() int64 {
+	if x != nil {
+		return x.Saved
+	}
+	return 0
+}
+
+type RemoteCopyTask struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Source *RemoteFile            `protobuf:"bytes,1,opt,name=source,json=Source,proto3" json:"source,omitempty"`
+	Target *RemoteFile            `protobuf:"bytes,2,opt,name=target,json=Target,proto3" json:"target,omitempty"`
+	Host   *RemoteHost            `protobuf:"bytes,3,opt,name=host,json=Host,proto3" json:"host,omitempty"`
+	Id     string                 `protobuf:"bytes,4,opt,name=id,json=Id,proto3" json:"id,omitempty"`
+	// The options of the copying.
+	Replace     bool   `protobuf:"varint,5,opt,name=replace,json=Replace,proto3" json:"replace,omitempty"`
+	Streams     int64  `protobuf:"varint,6,opt,name=streams,json=Streams,proto3" json:"streams,omitempty"`
+	Workers     int64  `protobuf:"varint,7,opt,name=workers,json=Workers,proto3" json:"workers,omitempty"`
+	Compression string `protobuf:"bytes,8,opt,name=compression,json=Compression,proto3" json:"compression,omitempty"`
+	Delta       bool   `protobuf:"varint,9,opt,name=delta,json=Delta,proto3" json:"delta,omitempty"`
+	Limit       int64  `protobuf:"varint,10,opt,name=limit,json=Limit,proto3" json:"limit,omitempty"`
+	Move        bool   `protobuf:"varint,11,opt,name=move,json=Move,proto3" json:"move,omitempty"`
+	// The state of the task.
+	Error         *RemoteError         `protobuf:"bytes,12,opt,name=error,json=Error,proto3" json:"error,omitempty"`
+	Progress      int64                `protobuf:"varint,13,opt,name=progress,json=Progress,proto3" json:"progress,omitempty"`
+	Count         int64                `protobuf:"varint,14,opt,name=count,json=Count,proto3" json:"count,omitempty"`
+	Current       int64                `protobuf:"varint,15,opt,name=current,json=Current,proto3" json:"current,omitempty"`
+	Status        TaskStatus           `protobuf:"varint,16,opt,name=status,json=Status,proto3,enum=netfs.transport.TaskStatus" json:"status,omitempty"`
+	Stats         *TaskStats           `protobuf:"bytes,17,opt,name=stats,json=Stats,proto3" json:"stats,omitempty"`
+	Paused        bool                 `protobuf:"varint,18,opt,name=paused,json=Paused,proto3" json:"paused,omitempty"`
+	Size          int64                `protobuf:"varint,19,opt,name=size,json=Size,proto3" json:"size,omitempty"`
+	Elapsed       *durationpb.Duration `protobuf:"bytes,20,opt,name=elapsed,json=Elapsed,proto3" json:"elapsed,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoteCopyTask) Reset() {
+	*x = RemoteCopyTask{}
+	mi := &file_transport_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoteCopyTask) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoteCopyTask) ProtoMessage() {}
+
+func (x *RemoteCopyTask) ProtoReflect() protoreflect.Message {
+	mi := &file_transport_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoteCopyTask.ProtoReflect.Descriptor instead.
+func (*RemoteCopyTask) Descriptor() ([]byte, []int) {
+	return file_transport_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *RemoteCopyTask) GetSource() *RemoteFile {
+	if x != nil {
+		return x.Source
+	}
+	return nil
+}
+
+func (x *RemoteCopyTask) GetTarget() *RemoteFile {
+	if x != nil {
+		return x.Target
+	}
+	return nil
+}
+
+func (x *RemoteCopyTask) GetHost() *RemoteHost {
+	if x != nil {
+		return x.Host
+	}
+	return nil
+}
+
+func (x *RemoteCopyTask) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *RemoteCopyTask) GetReplace() bool {
+	if x != nil {
+		return x.Replace
+	}
+	return false
+}
+
+func (x *RemoteCopyTask) GetStreams() int64 {
+	if x != nil {
+		return x.Streams
+	}
+	return 0
+}
+
+func (x *RemoteCopyTask) GetWorkers() int64 {
+	if x != nil {
+		return x.Workers
+	}
+	return 0
+}
+
+func (x *RemoteCopyTask) GetCompression() string {
+	if x != nil {
+		return x.Compression
+	}
+	return ""
+}
+
+func (x *RemoteCopyTask) GetDelta() bool {
+	if x != nil {
+		return x.Delta
+	}
+	return false
+}
+
+func (x *RemoteCopyTask) GetLimit() int64 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *RemoteCopyTask) GetMove() bool {
+	if x != nil {
+		return x.Move
+	}
+	return false
+}
+
+func (x *RemoteCopyTask) GetError() *RemoteError {
+	if x != nil {
+		return x.Error
+	}
+	return nil
+}
+
+func (x *RemoteCopyTask) GetProgress() int64 {
+	if x != nil {
+		return x.Progress
+	}
+	return 0
+}
+
+func (x *RemoteCopyTask) GetCount() int64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *RemoteCopyTask) GetCurrent() int64 {
+	if x != nil {
+		return x.Current
+	}
+	return 0
+}
+
+func (x *RemoteCopyTask) GetStatus() TaskStatus {
+	if x != nil {
+		return x.Status
+	}
+	return TaskStatus_TASK_STATUS_FAILED
+}
+
+func (x *RemoteCopyTask) GetStats() *TaskStats {
+	if x != nil {
+		return x.Stats
+	}
+	return nil
+}
+
+func (x *RemoteCopyTask) GetPaused() bool {
+	if x != nil {
+		return x.Paused
+	}
+	return false
+}
+
+func (x *RemoteCopyTask) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *RemoteCopyTask) GetElapsed() *durationpb.Duration {
+	if x != nil {
+		return x.Elapsed
+	}
+	return nil
+}
+
+type BlockSignature struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Weak          uint32                 `protobuf:"varint,1,opt,name=weak,json=Weak,proto3" json:"weak,omitempty"`
+	Strong        []byte                 `protobuf:"bytes,2,opt,name=strong,json=Strong,proto3" json:"strong,omitempty"`
+	Size          int64                  `protobuf:"varint,3,opt,name=size,json=Size,proto3" json:"size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BlockSignature) Reset() {
+	*x = BlockSignature{}
+	mi := &file_transport_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BlockSignature) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BlockSignature) ProtoMessage() {}
+
+func (x *BlockSignature) ProtoReflect() protoreflect.Message {
+	mi := &file_transport_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BlockSignature.ProtoReflect.Descriptor instead.
+func (*BlockSignature) Descriptor() ([]byte, []int) {
+	return file_transport_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *BlockSignature) GetWeak() uint32 {
+	if x != nil {
+		return x.Weak
+	}
+	return 0
+}
+
+func (x *BlockSignature) GetStrong() []byte {
+	if x != nil {
+		return x.Strong
+	}
+	return nil
+}
+
+func (x *BlockSignature) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+type FileSignature struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BlockSize     int64                  `protobuf:"varint,1,opt,name=block_size,json=BlockSize,proto3" json:"block_size,omitempty"`
+	Blocks        []*BlockSignature      `protobuf:"bytes,2,rep,name=blocks,json=Blocks,proto3" json:"blocks,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FileSignature) Reset() {
+	*x = FileSignature{}
+	mi := &file_transport_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FileSignature) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FileSignature) ProtoMessage() {}
+
+func (x *FileSignature) ProtoReflect() protoreflect.Message {
+	mi := &file_transport_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FileSignature.ProtoReflect.Descriptor instead.
+func (*FileSignature) Descriptor() ([]byte, []int) {
+	return file_transport_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *FileSignature) GetBlockSize() int64 {
+	if x != nil {
+		return x.BlockSize
+	}
+	return 0
+}
+
+func (x *FileSignature) GetBlocks() []*BlockSignature {
+	if x != nil {
+		return x.Blocks
+	}
+	return nil
+}
+
+type VolumeInfo struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Path          string                 `protobuf:"bytes,1,opt,name=path,json=Path,proto3" json:"path,omitempty"`
+	Total         int64                  `protobuf:"varint,2,opt,name=total,json=Total,proto3" json:"total,omitempty"`
+	Free          int64                  `protobuf:"varint,3,opt,name=free,json=Free,proto3" json:"free,omitempty"`
+	Used          int64                  `protobuf:"varint,4,opt,name=used,json=Used,proto3" json:"used,omitempty"`
+	Inodes        uint64                 `protobuf:"varint,5,opt,name=inodes,json=Inodes,proto3" json:"inodes,omitempty"`
+	InodesFree    uint64                 `protobuf:"varint,6,opt,name=inodes_free,json=InodesFree,proto3" json:"inodes_free,omitempty"`
+	InodesUsed    uint64                 `protobuf:"varint,7,opt,name=inodes_used,json=InodesUsed,proto3" json:"inodes_used,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VolumeInfo) Reset() {
+	*x = VolumeInfo{}
+	mi := &file_transport_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VolumeInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VolumeInfo) ProtoMessage() {}
+
+func (x *VolumeInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_transport_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VolumeInfo.ProtoReflect.Descriptor instead.
+func (*VolumeInfo) Descriptor() ([]byte, []int) {
+	return file_transport_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *VolumeInfo) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *VolumeInfo) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *VolumeInfo) GetFree() int64 {
+	if x != nil {
+		return x.Free
+	}
+	return 0
+}
+
+func (x *VolumeInfo) GetUsed() int64 {
+	if x != nil {
+		return x.Used
+	}
+	return 0
+}
+
+func (x *VolumeInfo) GetInodes() uint64 {
+	if x != nil {
+		return x.Inodes
+	}
+	return 0
+}
+
+func (x *VolumeInfo) GetInodesFree() uint64 {
+	if x != nil {
+		return x.InodesFree
+	}
+	return 0
+}
+
+func (x *VolumeInfo) GetInodesUsed() uint64 {
+	if x != nil {
+		return x.InodesUsed
+	}
+	return 0
+}
+
+type GrepMatch struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	File          *FileInfo              `protobuf:"bytes,1,opt,name=file,json=File,proto3" json:"file,omitempty"`
+	Line          int64                  `protobuf:"varint,2,opt,name=line,json=Line,proto3" json:"line,omitempty"`
+	Text          string                 `protobuf:"bytes,3,opt,name=text,json=Text,proto3" json:"text,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GrepMatch) Reset() {
+	*x = GrepMatch{}
+	mi := &file_transport_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GrepMatch) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GrepMatch) ProtoMessage() {}
+
+func (x *GrepMatch) ProtoReflect() protoreflect.Message {
+	mi := &file_transport_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GrepMatch.ProtoReflect.Descriptor instead.
+func (*GrepMatch) Descriptor() ([]byte, []int) {
+	return file_transport_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *GrepMatch) GetFile() *FileInfo {
+	if x != nil {
+		return x.File
+	}
+	return nil
+}
+
+func (x *GrepMatch) GetLine() int64 {
+	if x != nil {
+		return x.Line
+	}
+	return 0
+}
+
+func (x *GrepMatch) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+type ServerHostRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ServerHostRequest) Reset() {
+	*x = ServerHostRequest{}
+	mi := &file_transport_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ServerHostRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ServerHostRequest) ProtoMessage() {}
+
+func (x *ServerHostRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_transport_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ServerHostRequest.ProtoReflect.Descriptor instead.
+func (*ServerHostRequest) Descriptor() ([]byte, []int) {
+	return file_transport_proto_rawDescGZIP(), []int{12}
+}
+
+type ServerHostResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Body          *RemoteHost            `protobuf:"bytes,1,opt,name=body,proto3" json:"body,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ServerHostResponse) Reset() {
+	*x = ServerHostResponse{}
+	mi := &file_transport_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ServerHostResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ServerHostResponse) ProtoMessage() {}
+
+func (x *ServerHostResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_transport_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ServerHostResponse.ProtoReflect.Descriptor instead.
+func (*ServerHostResponse) Descriptor() ([]byte, []int) {
+	return file_transport_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ServerHostResponse) GetBody() *RemoteHost {
+	if x != nil {
+		return x.Body
+	}
+	return nil
+}
+
+type ServerStopRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ServerStopRequest) Reset() {
+	*x = ServerStopRequest{}
+	mi := &file_transport_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ServerStopRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ServerStopRequest) ProtoMessage() {}
+
+func (x *ServerStopRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_transport_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ServerStopRequest.ProtoReflect.Descriptor instead.
+func (*ServerStopRequest) Descriptor() ([]byte, []int) {
+	return file_transport_proto_rawDescGZIP(), []int{14}
+}
+
+type ServerStopResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ServerStopResponse) Reset() {
+	*x = ServerStopResponse{}
+	mi := &file_transport_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ServerStopResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ServerStopResponse) ProtoMessage() {}
+
+func (x *ServerStopResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_transport_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ServerStopResponse.ProtoReflect.Descriptor instead.
+func (*ServerStopResponse) Descriptor() ([]byte, []int) {
+	return file_transport_proto_rawDescGZIP(), []int{15}
+}
+
+type FileInfoRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FileId        *string                `protobuf:"bytes,1,opt,name=file_id,json=fileId,proto3,oneof" json:"file_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FileInfoRequest) Reset() {
+	*x = FileInfoRequest{}
+	mi := &file_transport_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FileInfoRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FileInfoRequest) ProtoMessage() {}
+
+func (x *FileInfoRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_transport_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FileInfoRequest.ProtoReflect.Descriptor instead.
+func (*FileInfoRequest) Descriptor() ([]byte, []int) {
+	return file_transport_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *FileInfoRequest) GetFileId() string {
+	if x != nil && x.FileId != nil {
+		return *x.FileId
+	}
+	return ""
+}
+
+type FileInfoResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Body          *FileInfo              `protobuf:"bytes,1,opt,name=body,proto3" json:"body,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FileInfoResponse) Reset() {
+	*x = FileInfoResponse{}
+	mi := &file_transport_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FileInfoResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FileInfoResponse) ProtoMessage() {}
+
+func (x *FileInfoResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_transport_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FileInfoResponse.ProtoReflect.Descriptor instead.
+func (*FileInfoResponse) Descriptor() ([]byte, []int) {
+	return file_transport_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *FileInfoResponse) GetBody() *FileInfo {
+	if x != nil {
+		return x.Body
+	}
+	return nil
+}
+
+type FileCreateRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Replace       *bool                  `protobuf:"varint,1,opt,name=replace,proto3,oneof" json:"replace,omitempty"`
+	Body          *FileInfo              `protobuf:"bytes,2,opt,name=body,proto3" json:"body,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FileCreateRequest) Reset() {
+	*x = FileCreateRequest{}
+	mi := &file_transport_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FileCreateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FileCreateRequest) ProtoMessage() {}
+
+func (x *FileCreateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_transport_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FileCreateRequest.ProtoReflect.Descriptor instead.
+func (*FileCreateRequest) Descriptor() ([]byte, []int) {
+	return file_transport_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *FileCreateRequest) GetReplace() bool {
+	if x != nil && x.Replace != nil {
+		return *x.Replace
+	}
+	return false
+}
+
+func (x *FileCreateRequest) GetBody() *FileInfo {
+	if x != nil {
+		return x.Body
+	}
+	return nil
+}
+
+type FileCreateResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Body          *FileInfo              `protobuf:"bytes,1,opt,name=body,proto3" json:"body,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FileCreateResponse) Reset() {
+	*x = FileCreateResponse{}
+	mi := &file_transport_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FileCreateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FileCreateResponse) ProtoMessage() {}
+
+func (x *FileCreateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_transport_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FileCreateResponse.ProtoReflect.Descriptor instead.
+func (*FileCreateResponse) Descriptor() ([]byte, []int) {
+	return file_transport_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *FileCreateResponse) GetBody() *FileInfo {
+	if x != nil {
+		return x.Body
+	}
+	return nil
+}
+
+// The first message contains the parameters, the next messages contain only data.
+type FileWriteRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FileId        *string                `protobuf:"bytes,1,opt,name=file_id,json=fileId,proto3,oneof" json:"file_id,omitempty"`
+	Offset        *uint64                `protobuf:"varint,2,opt,name=offset,proto3,oneof" json:"offset,omitempty"`
+	Codec         *string                `protobuf:"bytes,3,opt,name=codec,proto3,oneof" json:"codec,omitempty"`
+	Data          []byte                 `protobuf:"bytes,4,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FileWriteRequest) Reset() {
+	*x = FileWriteRequest{}
+	mi := &file_transport_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FileWriteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FileWriteRequest) ProtoMessage() {}
+
+func (x *FileWriteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_transport_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FileWriteRequest.ProtoReflect.Descriptor instead.
+func (*FileWriteRequest) Descriptor() ([]byte, []int) {
+	return file_transport_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *FileWriteRequest) GetFileId() string {
+	if x != nil && x.FileId != nil {
+		return *x.FileId
+	}
+	return ""
+}
+
+func (x *FileWriteRequest) GetOffset() uint64 {
+	if x != nil && x.Offset != nil {
+		return *x.Offset
+	}
+	return 0
+}
+
+func (x *FileWriteRequest) GetCodec() string {
+	if x != nil && x.Codec != nil {
+		return *x.Codec
+	}
+	return ""
+}
+
+func (x *FileWriteRequest) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type FileWriteResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FileWriteResponse) Reset() {
+	*x = FileWriteResponse{}
+	mi := &file_transport_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FileWriteResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FileWriteResponse) ProtoMessage() {}
+
+func (x *FileWriteResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_transport_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FileWriteResponse.ProtoReflect.Descriptor instead.
+func (*FileWriteResponse) Descriptor() ([]byte, []int) {
+	return file_transport_proto_rawDescGZIP(), []int{21}
+}
+
+type FileReadRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FileId        *string                `protobuf:"bytes,1,opt,name=file_id,json=fileId,proto3,oneof" json:"file_id,omitempty"`
+	Offset        *uint64                `protobuf:"varint,2,opt,name=offset,proto3,oneof" json:"offset,omitempty"`
+	Size          *uint64                `protobuf:"varint,3,opt,name=size,proto3,oneof" json:"size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FileReadRequest) Reset() {
+	*x = FileReadRequest{}
+	mi := &file_transport_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FileReadRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FileReadRequest) ProtoMessage() {}
+
+func (x *FileReadRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_transport_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FileReadRequest.ProtoReflect.Descriptor instead.
+func (*FileReadRequest) Descriptor() ([]byte, []int) {
+	return file_transport_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *FileReadRequest) GetFileId() string {
+	if x != nil && x.FileId != nil {
+		return *x.FileId
+	}
+	return ""
+}
+
+func (x *FileReadRequest) GetOffset() uint64 {
+	if x != nil && x.Offset != nil {
+		return *x.Offset
+	}
+	return 0
+}
+
+func (x *FileReadRequest) GetSize() uint64 {
+	if x != nil && x.Size != nil {
+		return *x.Size
+	}
+	return 0
+}
+
+type FileReadResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Data          []byte                 `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FileReadResponse) Reset() {
+	*x = FileReadResponse{}
+	mi := &file_transport_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FileReadResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FileReadResponse) ProtoMessage() {}
+
+func (x *FileReadResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_transport_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FileReadResponse.ProtoReflect.Descriptor instead.
+func (*FileReadResponse) Descriptor() ([]byte, []int) {
+	return file_transport_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *FileReadResponse) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+// The first message contains the parameters, the next messages contain only data.
+type FileExtractRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FileId        *string                `protobuf:"bytes,1,opt,name=file_id,json=fileId,proto3,oneof" json:"file_id,omitempty"`
+	Codec         *string                `protobuf:"bytes,2,opt,name=codec,proto3,oneof" json:"codec,omitempty"`
+	Data          []byte                 `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FileExtractRequest) Reset() {
+	*x = FileExtractRequest{}
+	mi := &file_transport_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FileExtractRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FileExtractRequest) ProtoMessage() {}
+
+func (x *FileExtractRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_transport_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FileExtractRequest.ProtoReflect.Descriptor instead.
+func (*FileExtractRequest) Descriptor() ([]byte, []int) {
+	return file_transport_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *FileExtractRequest) GetFileId() string {
+	if x != nil && x.FileId != nil {
+		return *x.FileId
+	}
+	return ""
+}
+
+func (x *FileExtractRequest) GetCodec() string {
+	if x != nil && x.Codec != nil {
+		return *x.Codec
+	}
+	return ""
+}
+
+func (x *FileExtractRequest) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type FileExtractResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FileExtractResponse) Reset() {
+	*x = FileExtractResponse{}
+	mi := &file_transport_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FileExtractResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FileExtractResponse) ProtoMessage() {}
+
+func (x *FileExtractResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_transport_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FileExtractResponse.ProtoReflect.Descriptor instead.
+func (*FileExtractResponse) Descriptor() ([]byte, []int) {
+	return file_transport_proto_rawDescGZIP(), []int{25}
+}
+
+type FileSignatureRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FileId        *string                `protobuf:"bytes,1,opt,name=file_id,json=fileId,proto3,oneof" json:"file_id,omitempty"`
+	BlockSize     *int64                 `protobuf:"varint,2,opt,name=block_size,json=blockSize,proto3,oneof" json:"block_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FileSignatureRequest) Reset() {
+	*x = FileSignatureRequest{}
+	mi := &file_transport_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FileSignatureRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FileSignatureRequest) ProtoMessage() {}
+
+func (x *FileSignatureRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_transport_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FileSignatureRequest.ProtoReflect.Descriptor instead.
+func (*FileSignatureRequest) Descriptor() ([]byte, []int) {
+	return file_transport_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *FileSignatureRequest) GetFileId() string {
+	if x != nil && x.FileId != nil {
+		return *x.FileId
+	}
+	return ""
+}
+
+func (x *FileSignatureRequest) GetBlockSize() int64 {
+	if x != nil && x.BlockSize != nil {
+		return *x.BlockSize
+	}
+	return 0
+}
+
+type FileSignatureResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Body          *FileSignature         `protobuf:"bytes,1,opt,name=body,proto3" json:"body,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FileSignatureResponse) Reset() {
+	*x = FileSignatureResponse{}
+	mi := &file_transport_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FileSignatureResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FileSignatureResponse) ProtoMessage() {}
+
+func (x *FileSignatureResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_transport_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FileSignatureResponse.ProtoReflect.Descriptor instead.
+func (*FileSignatureResponse) Descriptor() ([]byte, []int) {
+	return file_transport_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *FileSignatureResponse) GetBody() *FileSignature {
+	if x != nil {
+		return x.Body
+	}
+	return nil
+}
+
+// The first message contains the parameters, the next messages contain only data.
+type FileDeltaRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FileId        *string                `protobuf:"bytes,1,opt,name=file_id,json=fileId,proto3,oneof" json:"file_id,omitempty"`
+	BlockSize     *int64                 `protobuf:"varint,2,opt,name=block_size,json=blockSize,proto3,oneof" json:"block_size,omitempty"`
+	Codec         *string                `protobuf:"bytes,3,opt,name=codec,proto3,oneof" json:"codec,omitempty"`
+	Data          []byte                 `protobuf:"bytes,4,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FileDeltaRequest) Reset() {
+	*x = FileDeltaRequest{}
+	mi := &file_transport_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FileDeltaRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FileDeltaRequest) ProtoMessage() {}
+
+func (x *FileDeltaRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_transport_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FileDeltaRequest.ProtoReflect.Descriptor instead.
+func (*FileDeltaRequest) Descriptor() ([]byte, []int) {
+	return file_transport_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *FileDeltaRequest) GetFileId() string {
+	if x != nil && x.FileId != nil {
+		return *x.FileId
+	}
+	return ""
+}
+
+func (x *FileDeltaRequest) GetBlockSize() int64 {
+	if x != nil && x.BlockSize != nil {
+		return *x.BlockSize
+	}
+	return 0
+}
+
+func (x *FileDeltaRequest) GetCodec() string {
+	if x != nil && x.Codec != nil {
+		return *x.Codec
+	}
+	return ""
+}
+
+func (x *FileDeltaRequest) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type FileDeltaResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FileDeltaResponse) Reset() {
+	*x = FileDeltaResponse{}
+	mi := &file_transport_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FileDeltaResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FileDeltaResponse) ProtoMessage() {}
+
+func (x *FileDeltaResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_transport_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FileDeltaResponse.ProtoReflect.Descriptor instead.
+func (*FileDeltaResponse) Descriptor() ([]byte, []int) {
+	return file_transport_proto_rawDescGZIP(), []int{29}
+}
+
+type FileRemoveRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FileId        *string                `protobuf:"bytes,1,opt,name=file_id,json=fileId,proto3,oneof" json:"file_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FileRemoveRequest) Reset() {
+	*x = FileRemoveRequest{}
+	mi := &file_transport_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FileRemoveRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FileRemoveRequest) ProtoMessage() {}
+
+func (x *FileRemoveRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_transport_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FileRemoveRequest.ProtoReflect.Descriptor instead.
+func (*FileRemoveRequest) Descriptor() ([]byte, []int) {
+	return file_transport_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *FileRemoveRequest) GetFileId() string {
+	if x != nil && x.FileId != nil {
+		return *x.FileId
+	}
+	return ""
+}
+
+type FileRemoveResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FileRemoveResponse) Reset() {
+	*x = FileRemoveResponse{}
+	mi := &file_transport_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FileRemoveResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FileRemoveResponse) ProtoMessage() {}
+
+func (x *FileRemoveResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_transport_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FileRemoveResponse.ProtoReflect.Descriptor instead.
+func (*FileRemoveResponse) Descriptor() ([]byte, []int) {
+	return file_transport_proto_rawDescGZIP(), []int{31}
+}
+
+type FileRenameRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	FileId *string                `protobuf:"bytes,1,opt,name=file_id,json=fileId,proto3,oneof" json:"file_id,omitempty"`
+	// The new name of the file.
+	Name          *string `protobuf:"bytes,2,opt,name=name,proto3,oneof" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FileRenameRequest) Reset() {
+	*x = FileRenameRequest{}
+	mi := &file_transport_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FileRenameRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FileRenameRequest) ProtoMessage() {}
+
+func (x *FileRenameRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_transport_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FileRenameRequest.ProtoReflect.Descriptor instead.
+func (*FileRenameRequest) Descriptor() ([]byte, []int) {
+	return file_transport_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *FileRenameRequest) GetFileId() string {
+	if x != nil && x.FileId != nil {
+		return *x.FileId
+	}
+	return ""
+}
+
+func (x *FileRenameRequest) GetName() string {
+	if x != nil && x.Name != nil {
+		return *x.Name
+	}
+	return ""
+}
+
+type FileRenameResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Body          *FileInfo              `protobuf:"bytes,1,opt,name=body,proto3" json:"body,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FileRenameResponse) Reset() {
+	*x = FileRenameResponse{}
+	mi := &file_transport_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FileRenameResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FileRenameResponse) ProtoMessage() {}
+
+func (x *FileRenameResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_transport_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FileRenameResponse.ProtoReflect.Descriptor instead.
+func (*FileRenameResponse) Descriptor() ([]byte, []int) {
+	return file_transport_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *FileRenameResponse) GetBody() *FileInfo {
+	if x != nil {
+		return x.Body
+	}
+	return nil
+}
+
+type FileCopyRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FileCopyRequest) Reset() {
+	*x = FileCopyRequest{}
+	mi := &file_transport_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FileCopyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FileCopyRequest) ProtoMessage() {}
+
+func (x *FileCopyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_transport_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FileCopyRequest.ProtoReflect.Descriptor instead.
+func (*FileCopyRequest) Descriptor() ([]byte, []int) {
+	return file_transport_proto_rawDescGZIP(), []int{34}
+}
+
+type FileCopyResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Body          []*RemoteCopyTask      `protobuf:"bytes,1,rep,name=body,proto3" json:"body,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FileCopyResponse) Reset() {
+	*x = FileCopyResponse{}
+	mi := &file_transport_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FileCopyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FileCopyResponse) ProtoMessage() {}
+
+func (x *FileCopyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_transport_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FileCopyResponse.ProtoReflect.Descriptor instead.
+func (*FileCopyResponse) Descriptor() ([]byte, []int) {
+	return file_transport_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *FileCopyResponse) GetBody() []*RemoteCopyTask {
+	if x != nil {
+		return x.Body
+	}
+	return nil
+}
+
+type FileCopyStartRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Body          *RemoteCopyTask        `protobuf:"bytes,1,opt,name=body,proto3" json:"body,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FileCopyStartRequest) Reset() {
+	*x = FileCopyStartRequest{}
+	mi := &file_transport_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FileCopyStartRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FileCopyStartRequest) ProtoMessage() {}
+
+func (x *FileCopyStartRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_transport_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FileCopyStartRequest.ProtoReflect.Descriptor instead.
+func (*FileCopyStartRequest) Descriptor() ([]byte, []int) {
+	return file_transport_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *FileCopyStartRequest) GetBody() *RemoteCopyTask {
+	if x != nil {
+		return x.Body
+	}
+	return nil
+}
+
+type FileCopyStartResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Body          *RemoteCopyTask        `protobuf:"bytes,1,opt,name=body,proto3" json:"body,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FileCopyStartResponse) Reset() {
+	*x = FileCopyStartResponse{}
+	mi := &file_transport_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FileCopyStartResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FileCopyStartResponse) ProtoMessage() {}
+
+func (x *FileCopyStartResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_transport_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FileCopyStartResponse.ProtoReflect.Descriptor instead.
+func (*FileCopyStartResponse) Descriptor() ([]byte, []int) {
+	return file_transport_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *FileCopyStartResponse) GetBody() *RemoteCopyTask {
+	if x != nil {
+		return x.Body
+	}
+	return nil
+}
+
+type FileCopyStatusRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            *string                `protobuf:"bytes,1,opt,name=id,proto3,oneof" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FileCopyStatusRequest) Reset() {
+	*x = FileCopyStatusRequest{}
+	mi := &file_transport_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FileCopyStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FileCopyStatusRequest) ProtoMessage() {}
+
+func (x *FileCopyStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_transport_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FileCopyStatusRequest.ProtoReflect.Descriptor instead.
+func (*FileCopyStatusRequest) Descriptor() ([]byte, []int) {
+	return file_transport_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *FileCopyStatusRequest) GetId() string {
+	if x != nil && x.Id != nil {
+		return *x.Id
+	}
+	return ""
+}
+
+type FileCopyStatusResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Body          *RemoteCopyTask        `protobuf:"bytes,1,opt,name=body,proto3" json:"body,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FileCopyStatusResponse) Reset() {
+	*x = FileCopyStatusResponse{}
+	mi := &file_transport_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FileCopyStatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FileCopyStatusResponse) ProtoMessage() {}
+
+func (x *FileCopyStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_transport_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FileCopyStatusResponse.ProtoReflect.Descriptor instead.
+func (*FileCopyStatusResponse) Descriptor() ([]byte, []int) {
+	return file_transport_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *FileCopyStatusResponse) GetBody() *RemoteCopyTask {
+	if x != nil {
+		return x.Body
+	}
+	return nil
+}
+
+type FileCopyCancelRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            *string                `protobuf:"bytes,1,opt,name=id,proto3,oneof" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FileCopyCancelRequest) Reset() {
+	*x = FileCopyCancelRequest{}
+	mi := &file_transport_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FileCopyCancelRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FileCopyCancelRequest) ProtoMessage() {}
+
+func (x *FileCopyCancelRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_transport_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FileCopyCancelRequest.ProtoReflect.Descriptor instead.
+func (*FileCopyCancelRequest) Descriptor() ([]byte, []int) {
+	return file_transport_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *FileCopyCancelRequest) GetId() string {
+	if x != nil && x.Id != nil {
+		return *x.Id
+	}
+	return ""
+}
+
+type FileCopyCancelResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FileCopyCancelResponse) Reset() {
+	*x = FileCopyCancelResponse{}
+	mi := &file_transport_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FileCopyCancelResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FileCopyCancelResponse) ProtoMessage() {}
+
+func (x *FileCopyCancelResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_transport_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FileCopyCancelResponse.ProtoReflect.Descriptor instead.
+func (*FileCopyCancelResponse) Descriptor() ([]byte, []int) {
+	return file_transport_proto_rawDescGZIP(), []int{41}
+}
+
+type FileCopyPauseRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            *string                `protobuf:"bytes,1,opt,name=id,proto3,oneof" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FileCopyPauseRequest) Reset() {
+	*x = FileCopyPauseRequest{}
+	mi := &file_transport_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FileCopyPauseRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FileCopyPauseRequest) ProtoMessage() {}
+
+func (x *FileCopyPauseRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_transport_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FileCopyPauseRequest.ProtoReflect.Descriptor instead.
+func (*FileCopyPauseRequest) Descriptor() ([]byte, []int) {
+	return file_transport_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *FileCopyPauseRequest) GetId() string {
+	if x != nil && x.Id != nil {
+		return *x.Id
+	}
+	return ""
+}
+
+type FileCopyPauseResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FileCopyPauseResponse) Reset() {
+	*x = FileCopyPauseResponse{}
+	mi := &file_transport_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FileCopyPauseResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FileCopyPauseResponse) ProtoMessage() {}
+
+func (x *FileCopyPauseResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_transport_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FileCopyPauseResponse.ProtoReflect.Descriptor instead.
+func (*FileCopyPauseResponse) Descriptor() ([]byte, []int) {
+	return file_transport_proto_rawDescGZIP(), []int{43}
+}
+
+type FileCopyResumeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            *string                `protobuf:"bytes,1,opt,name=id,proto3,oneof" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FileCopyResumeRequest) Reset() {
+	*x = FileCopyResumeRequest{}
+	mi := &file_transport_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FileCopyResumeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FileCopyResumeRequest) ProtoMessage() {}
+
+func (x *FileCopyResumeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_transport_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FileCopyResumeRequest.ProtoReflect.Descriptor instead.
+func (*FileCopyResumeRequest) Descriptor() ([]byte, []int) {
+	return file_transport_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *FileCopyResumeRequest) GetId() string {
+	if x != nil && x.Id != nil {
+		return *x.Id
+	}
+	return ""
+}
+
+type FileCopyResumeResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FileCopyResumeResponse) Reset() {
+	*x = FileCopyResumeResponse{}
+	mi := &file_transport_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FileCopyResumeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FileCopyResumeResponse) ProtoMessage() {}
+
+func (x *FileCopyResumeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_transport_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FileCopyResumeResponse.ProtoReflect.Descriptor instead.
+func (*FileCopyResumeResponse) Descriptor() ([]byte, []int) {
+	return file_transport_proto_rawDescGZIP(), []int{45}
+}
+
+type TaskHistoryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TaskHistoryRequest) Reset() {
+	*x = TaskHistoryRequest{}
+	mi := &file_transport_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TaskHistoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TaskHistoryRequest) ProtoMessage() {}
+
+func (x *TaskHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_transport_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TaskHistoryRequest.ProtoReflect.Descriptor instead.
+func (*TaskHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_transport_proto_rawDescGZIP(), []int{46}
+}
+
+type TaskHistoryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Body          []*RemoteCopyTask      `protobuf:"bytes,1,rep,name=body,proto3" json:"body,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TaskHistoryResponse) Reset() {
+	*x = TaskHistoryResponse{}
+	mi := &file_transport_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TaskHistoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TaskHistoryResponse) ProtoMessage() {}
+
+func (x *TaskHistoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_transport_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TaskHistoryResponse.ProtoReflect.Descriptor instead.
+func (*TaskHistoryResponse) Descriptor() ([]byte, []int) {
+	return file_transport_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *TaskHistoryResponse) GetBody() []*RemoteCopyTask {
+	if x != nil {
+		return x.Body
+	}
+	return nil
+}
+
+type FileCopyLimitRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    *string                `protobuf:"bytes,1,opt,name=id,proto3,oneof" json:"id,omitempty"`
+	// Bytes per second.
+	Limit         *uint64 `protobuf:"varint,2,opt,name=limit,proto3,oneof" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FileCopyLimitRequest) Reset() {
+	*x = FileCopyLimitRequest{}
+	mi := &file_transport_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FileCopyLimitRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FileCopyLimitRequest) ProtoMessage() {}
+
+func (x *FileCopyLimitRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_transport_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FileCopyLimitRequest.ProtoReflect.Descriptor instead.
+func (*FileCopyLimitRequest) Descriptor() ([]byte, []int) {
+	return file_transport_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *FileCopyLimitRequest) GetId() string {
+	if x != nil && x.Id != nil {
+		return *x.Id
+	}
+	return ""
+}
+
+func (x *FileCopyLimitRequest) GetLimit() uint64 {
+	if x != nil && x.Limit != nil {
+		return *x.Limit
+	}
+	return 0
+}
+
+type FileCopyLimitResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FileCopyLimitResponse) Reset() {
+	*x = FileCopyLimitResponse{}
+	mi := &file_transport_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FileCopyLimitResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FileCopyLimitResponse) ProtoMessage() {}
+
+func (x *FileCopyLimitResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_transport_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FileCopyLimitResponse.ProtoReflect.Descriptor instead.
+func (*FileCopyLimitResponse) Descriptor() ([]byte, []int) {
+	return file_transport_proto_rawDescGZIP(), []int{49}
+}
+
+type FileChildrenRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FileId        *string                `protobuf:"bytes,1,opt,name=file_id,json=fileId,proto3,oneof" json:"file_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FileChildrenRequest) Reset() {
+	*x = FileChildrenRequest{}
+	mi := &file_transport_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FileChildrenRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FileChildrenRequest) ProtoMessage() {}
+
+func (x *FileChildrenRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_transport_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FileChildrenRequest.ProtoReflect.Descriptor instead.
+func (*FileChildrenRequest) Descriptor() ([]byte, []int) {
+	return file_transport_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *FileChildrenRequest) GetFileId() string {
+	if x != nil && x.FileId != nil {
+		return *x.FileId
+	}
+	return ""
+}
+
+type FileChildrenResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Body          []*FileInfo            `protobuf:"bytes,1,rep,name=body,proto3" json:"body,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FileChildrenResponse) Reset() {
+	*x = FileChildrenResponse{}
+	mi := &file_transport_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FileChildrenResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FileChildrenResponse) ProtoMessage() {}
+
+func (x *FileChildrenResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_transport_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FileChildrenResponse.ProtoReflect.Descriptor instead.
+func (*FileChildrenResponse) Descriptor() ([]byte, []int) {
+	return file_transport_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *FileChildrenResponse) GetBody() []*FileInfo {
+	if x != nil {
+		return x.Body
+	}
+	return nil
+}
+
+type FileSearchRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	FileId *string                `protobuf:"bytes,1,opt,name=file_id,json=fileId,proto3,oneof" json:"file_id,omitempty"`
+	// The glob pattern of the name.
+	Pattern *string `protobuf:"bytes,2,opt,name=pattern,proto3,oneof" json:"pattern,omitempty"`
+	// The regular expression of the name.
+	Regexp *string `protobuf:"bytes,3,opt,name=regexp,proto3,oneof" json:"regexp,omitempty"`
+	// "f" for files, "d" for directories.
+	Type          *string                `protobuf:"bytes,4,opt,name=type,proto3,oneof" json:"type,omitempty"`
+	MinSize       *uint64                `protobuf:"varint,5,opt,name=min_size,json=minSize,proto3,oneof" json:"min_size,omitempty"`
+	MaxSize       *uint64                `protobuf:"varint,6,opt,name=max_size,json=maxSize,proto3,oneof" json:"max_size,omitempty"`
+	After         *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=after,proto3" json:"after,omitempty"`
+	Before        *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=before,proto3" json:"before,omitempty"`
+	Limit         *int64                 `protobuf:"varint,9,opt,name=limit,proto3,oneof" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FileSearchRequest) Reset() {
+	*x = FileSearchRequest{}
+	mi := &file_transport_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FileSearchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FileSearchRequest) ProtoMessage() {}
+
+func (x *FileSearchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_transport_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FileSearchRequest.ProtoReflect.Descriptor instead.
+func (*FileSearchRequest) Descriptor() ([]byte, []int) {
+	return file_transport_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *FileSearchRequest) GetFileId() string {
+	if x != nil && x.FileId != nil {
+		return *x.FileId
+	}
+	return ""
+}
+
+func (x *FileSearchRequest) GetPattern() string {
+	if x != nil && x.Pattern != nil {
+		return *x.Pattern
+	}
+	return ""
+}
+
+func (x *FileSearchRequest) GetRegexp() string {
+	if x != nil && x.Regexp != nil {
+		return *x.Regexp
+	}
+	return ""
+}
+
+func (x *FileSearchRequest) GetType() string {
+	if x != nil && x.Type != nil {
+		return *x.Type
+	}
+	return ""
+}
+
+func (x *FileSearchRequest) GetMinSize() uint64 {
+	if x != nil && x.MinSize != nil {
+		return *x.MinSize
+	}
+	return 0
+}
+
+func (x *FileSearchRequest) GetMaxSize() uint64 {
+	if x != nil && x.MaxSize != nil {
+		return *x.MaxSize
+	}
+	return 0
+}
+
+func (x *FileSearchRequest) GetAfter() *timestamppb.Timestamp {
+	if x != nil {
+		return x.After
+	}
+	return nil
+}
+
+func (x *FileSearchRequest) GetBefore() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Before
+	}
+	return nil
+}
+
+func (x *FileSearchRequest) GetLimit() int64 {
+	if x != nil && x.Limit != nil {
+		return *x.Limit
+	}
+	return 0
+}
+
+type FileSearchResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Body          []*FileInfo            `protobuf:"bytes,1,rep,name=body,proto3" json:"body,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FileSearchResponse) Reset() {
+	*x = FileSearchResponse{}
+	mi := &file_transport_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FileSearchResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FileSearchResponse) ProtoMessage() {}
+
+func (x *FileSearchResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_transport_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FileSearchResponse.ProtoReflect.Descriptor instead.
+func (*FileSearchResponse) Descriptor() ([]byte, []int) {
+	return file_transport_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *FileSearchResponse) GetBody() []*FileInfo {
+	if x != nil {
+		return x.Body
+	}
+	return nil
+}
+
+type FileGrepRequest struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	FileId     *string                `protobuf:"bytes,1,opt,name=file_id,json=fileId,proto3,oneof" json:"file_id,omitempty"`
+	Pattern    *string                `protobuf:"bytes,2,opt,name=pattern,proto3,oneof" json:"pattern,omitempty"`
+	Regexp     *bool                  `protobuf:"varint,3,opt,name=regexp,proto3,oneof" json:"regexp,omitempty"`
+	IgnoreCase *bool                  `protobuf:"varint,4,opt,name=ignore_case,json=ignoreCase,proto3,oneof" json:"ignore_case,omitempty"`
+	// The glob pattern of the names of the read files.
+	Include       *string `protobuf:"bytes,5,opt,name=include,proto3,oneof" json:"include,omitempty"`
+	MaxSize       *uint64 `protobuf:"varint,6,opt,name=max_size,json=maxSize,proto3,oneof" json:"max_size,omitempty"`
+	Limit         *int64  `protobuf:"varint,7,opt,name=limit,proto3,oneof" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FileGrepRequest) Reset() {
+	*x = FileGrepRequest{}
+	mi := &file_transport_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FileGrepRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FileGrepRequest) ProtoMessage() {}
+
+func (x *FileGrepRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_transport_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FileGrepRequest.ProtoReflect.Descriptor instead.
+func (*FileGrepRequest) Descriptor() ([]byte, []int) {
+	return file_transport_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *FileGrepRequest) GetFileId() string {
+	if x != nil && x.FileId != nil {
+		return *x.FileId
+	}
+	return ""
+}
+
+func (x *FileGrepRequest) GetPattern() string {
+	if x != nil && x.Pattern != nil {
+		return *x.Pattern
+	}
+	return ""
+}
+
+func (x *FileGrepRequest) GetRegexp() bool {
+	if x != nil && x.Regexp != nil {
+		return *x.Regexp
+	}
+	return false
+}
+
+func (x *FileGrepRequest) GetIgnoreCase() bool {
+	if x != nil && x.IgnoreCase != nil {
+		return *x.IgnoreCase
+	}
+	return false
+}
+
+func (x *FileGrepRequest) GetInclude() string {
+	if x != nil && x.Include != nil {
+		return *x.Include
+	}
+	return ""
+}
+
+func (x *FileGrepRequest) GetMaxSize() uint64 {
+	if x != nil && x.MaxSize != nil {
+		return *x.MaxSize
+	}
+	return 0
+}
+
+func (x *FileGrepRequest) GetLimit() int64 {
+	if x != nil && x.Limit != nil {
+		return *x.Limit
+	}
+	return 0
+}
+
+type FileGrepResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Body          []*GrepMatch           `protobuf:"bytes,1,rep,name=body,proto3" json:"body,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FileGrepResponse) Reset() {
+	*x = FileGrepResponse{}
+	mi := &file_transport_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FileGrepResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FileGrepResponse) ProtoMessage() {}
+
+func (x *FileGrepResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_transport_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FileGrepResponse.ProtoReflect.Descriptor instead.
+func (*FileGrepResponse) Descriptor() ([]byte, []int) {
+	return file_transport_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *FileGrepResponse) GetBody() []*GrepMatch {
+	if x != nil {
+		return x.Body
+	}
+	return nil
+}
+
+type VolumeInfoRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FileId        *string                `protobuf:"bytes,1,opt,name=file_id,json=fileId,proto3,oneof" json:"file_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VolumeInfoRequest) Reset() {
+	*x = VolumeInfoRequest{}
+	mi := &file_transport_proto_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VolumeInfoRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VolumeInfoRequest) ProtoMessage() {}
+
+func (x *VolumeInfoRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_transport_proto_msgTypes[56]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VolumeInfoRequest.ProtoReflect.Descriptor instead.
+func (*VolumeInfoRequest) Descriptor() ([]byte, []int) {
+	return file_transport_proto_rawDescGZIP(), []int{56}
+}
+
+func (x *VolumeInfoRequest) GetFileId() string {
+	if x != nil && x.FileId != nil {
+		return *x.FileId
+	}
+	return ""
+}
+
+type VolumeInfoResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Body          []*VolumeInfo          `protobuf:"bytes,1,rep,name=body,proto3" json:"body,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VolumeInfoResponse) Reset() {
+	*x = VolumeInfoResponse{}
+	mi := &file_transport_proto_msgTypes[57]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VolumeInfoResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VolumeInfoResponse) ProtoMessage() {}
+
+func (x *VolumeInfoResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_transport_proto_msgTypes[57]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VolumeInfoResponse.ProtoReflect.Descriptor instead.
+func (*VolumeInfoResponse) Descriptor() ([]byte, []int) {
+	return file_transport_proto_rawDescGZIP(), []int{57}
+}
+
+func (x *VolumeInfoResponse) GetBody() []*VolumeInfo {
+	if x != nil {
+		return x.Body
+	}
+	return nil
+}
+
+var file_transport_proto_extTypes = []protoimpl.ExtensionInfo{
+	{
+		ExtendedType:  (*descriptorpb.MethodOptions)(nil),
+		ExtensionType: ([]string)(nil),
+		Field:         51001,
+		Name:          "netfs.transport.endpoints",
+		Tag:           "bytes,51001,rep,name=endpoints",
+		Filename:      "transport.proto",
+	},
+	{
+		ExtendedType:  (*descriptorpb.MethodOptions)(nil),
+		ExtensionType: (*bool)(nil),
+		Field:         51002,
+		Name:          "netfs.transport.json_lines",
+		Tag:           "varint,51002,opt,name=json_lines",
+		Filename:      "transport.proto",
+	},
+}
+
+// Extension fields to descriptorpb.MethodOptions.
+var (
+	// Names of the endpoints which are served by the method.
+	//
+	// repeated string endpoints = 51001;
+	E_Endpoints = &file_transport_proto_extTypes[0]
+	// The streamed records are written as JSON lines instead of JSON array.
+	//
+	// optional bool json_lines = 51002;
+	E_JsonLines = &file_transport_proto_extTypes[1]
+)
+
+var File_transport_proto protoreflect.FileDescriptor
+
+const file_transport_proto_rawDesc = "" +
+	"\n" +
+	"\x0ftransport.proto\x12\x0fnetfs.transport\x1a google/protobuf/descriptor.proto\x1a\x1egoogle/protobuf/duration.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\xd9\x01\n" +
+	"\bFileInfo\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02Id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04Name\x12\x12\n" +
+	"\x04path\x18\x03 \x01(\tR\x04Path\x12-\n" +
+	"\x04type\x18\x04 \x01(\x0e2\x19.netfs.transport.FileTypeR\x04Type\x12\x12\n" +
+	"\x04size\x18\x05 \x01(\x03R\x04Size\x12\x1b\n" +
+	"\tparent_id\x18\x06 \x01(\tR\bParentId\x125\n" +
+	"\bmod_time\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\aModTime\"I\n" +
+	"\tRootSpace\x12\x12\n" +
+	"\x04path\x18\x01 \x01(\tR\x04Path\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x03R\x05Total\x12\x12\n" +
+	"\x04free\x18\x03 \x01(\x03R\x04Free\"\xf4\x02\n" +
+	"\x10HostCapabilities\x12\x18\n" +
+	"\aversion\x18\x01 \x01(\tR\aVersion\x12\x1f\n" +
+	"\vapi_version\x18\x02 \x01(\x03R\n" +
+	"ApiVersion\x12&\n" +
+	"\x0fmin_api_version\x18\x03 \x01(\x03R\rMinApiVersion\x12\x0e\n" +
+	"\x02os\x18\x04 \x01(\tR\x02OS\x12\x12\n" +
+	"\x04arch\x18\x05 \x01(\tR\x04Arch\x12@\n" +
+	"\tprotocols\x18\x06 \x03(\x0e2\".netfs.transport.TransportProtocolR\tProtocols\x12\x1a\n" +
+	"\bfeatures\x18\a \x03(\tR\bFeatures\x12\x16\n" +
+	"\x06codecs\x18\b \x03(\tR\x06Codecs\x120\n" +
+	"\x05roots\x18\t \x03(\v2\x1a.netfs.transport.RootSpaceR\x05Roots\x121\n" +
+	"\x06uptime\x18\n" +
+	" \x01(\v2\x19.google.protobuf.DurationR\x06Uptime\"w\n" +
+	"\n" +
+	"RemoteHost\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04Name\x12\x0e\n" +
+	"\x02ip\x18\x02 \x01(\tR\x02IP\x12E\n" +
+	"\fcapabilities\x18\x03 \x01(\v2!.netfs.transport.HostCapabilitiesR\fCapabilities\"l\n" +
+	"\n" +
+	"RemoteFile\x12-\n" +
+	"\x04info\x18\x01 \x01(\v2\x19.netfs.transport.FileInfoR\x04Info\x12/\n" +
+	"\x04host\x18\x02 \x01(\v2\x1b.netfs.transport.RemoteHostR\x04Host\";\n" +
+	"\vRemoteError\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\tR\x04Code\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\aMessage\"a\n" +
+	"\tTaskStats\x12\x14\n" +
+	"\x05codec\x18\x01 \x01(\tR\x05Codec\x12\x14\n" +
+	"\x05bytes\x18\x02 \x01(\x03R\x05Bytes\x12\x12\n" +
+	"\x04sent\x18\x03 \x01(\x03R\x04Sent\x12\x14\n" +
+	"\x05saved\x18\x04 \x01(\x03R\x05Saved\"\xb3\x05\n" +
+	"\x0eRemoteCopyTask\x123\n" +
+	"\x06source\x18\x01 \x01(\v2\x1b.netfs.transport.RemoteFileR\x06Source\x123\n" +
+	"\x06target\x18\x02 \x01(\v2\x1b.netfs.transport.RemoteFileR\x06Target\x12/\n" +
+	"\x04host\x18\x03 \x01(\v2\x1b.netfs.transport.RemoteHostR\x04Host\x12\x0e\n" +
+	"\x02id\x18\x04 \x01(\tR\x02Id\x12\x18\n" +
+	"\areplace\x18\x05 \x01(\bR\aReplace\x12\x18\n" +
+	"\astreams\x18\x06 \x01(\x03R\aStreams\x12\x18\n" +
+	"\aworkers\x18\a \x01(\x03R\aWorkers\x12 \n" +
+	"\vcompression\x18\b \x01(\tR\vCompression\x12\x14\n" +
+	"\x05delta\x18\t \x01(\bR\x05Delta\x12\x14\n" +
+	"\x05limit\x18\n" +
+	" \x01(\x03R\x05Limit\x12\x12\n" +
+	"\x04move\x18\v \x01(\bR\x04Move\x122\n" +
+	"\x05error\x18\f \x01(\v2\x1c.netfs.transport.RemoteErrorR\x05Error\x12\x1a\n" +
+	"\bprogress\x18\r \x01(\x03R\bProgress\x12\x14\n" +
+	"\x05count\x18\x0e \x01(\x03R\x05Count\x12\x18\n" +
+	"\acurrent\x18\x0f \x01(\x03R\aCurrent\x123\n" +
+	"\x06status\x18\x10 \x01(\x0e2\x1b.netfs.transport.TaskStatusR\x06Status\x120\n" +
+	"\x05stats\x18\x11 \x01(\v2\x1a.netfs.transport.TaskStatsR\x05Stats\x12\x16\n" +
+	"\x06paused\x18\x12 \x01(\bR\x06Paused\x12\x12\n" +
+	"\x04size\x18\x13 \x01(\x03R\x04Size\x123\n" +
+	"\aelapsed\x18\x14 \x01(\v2\x19.google.protobuf.DurationR\aElapsed\"P\n" +
+	"\x0eBlockSignature\x12\x12\n" +
+	"\x04weak\x18\x01 \x01(\rR\x04Weak\x12\x16\n" +
+	"\x06strong\x18\x02 \x01(\fR\x06Strong\x12\x12\n" +
+	"\x04size\x18\x03 \x01(\x03R\x04Size\"g\n" +
+	"\rFileSignature\x12\x1d\n" +
+	"\n" +
+	"block_size\x18\x01 \x01(\x03R\tBlockSize\x127\n" +
+	"\x06blocks\x18\x02 \x03(\v2\x1f.netfs.transport.BlockSignatureR\x06Blocks\"\xb8\x01\n" +
+	"\n" +
+	"VolumeInfo\x12\x12\n" +
+	"\x04path\x18\x01 \x01(\tR\x04Path\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x03R\x05Total\x12\x12\n" +
+	"\x04free\x18\x03 \x01(\x03R\x04Free\x12\x12\n" +
+	"\x04used\x18\x04 \x01(\x03R\x04Used\x12\x16\n" +
+	"\x06inodes\x18\x05 \x01(\x04R\x06Inodes\x12\x1f\n" +
+	"\vinodes_free\x18\x06 \x01(\x04R\n" +
+	"InodesFree\x12\x1f\n" +
+	"\vinodes_used\x18\a \x01(\x04R\n" +
+	"InodesUsed\"b\n" +
+	"\tGrepMatch\x12-\n" +
+	"\x04file\x18\x01 \x01(\v2\x19.netfs.transport.FileInfoR\x04File\x12\x12\n" +
+	"\x04line\x18\x02 \x01(\x03R\x04Line\x12\x12\n" +
+	"\x04text\x18\x03 \x01(\tR\x04Text\"\x13\n" +
+	"\x11ServerHostRequest\"E\n" +
+	"\x12ServerHostResponse\x12/\n" +
+	"\x04body\x18\x01 \x01(\v2\x1b.netfs.transport.RemoteHostR\x04body\"\x13\n" +
+	"\x11ServerStopRequest\"\x14\n" +
+	"\x12ServerStopResponse\";\n" +
+	"\x0fFileInfoRequest\x12\x1c\n" +
+	"\afile_id\x18\x01 \x01(\tH\x00R\x06fileId\x88\x01\x01B\n" +
+	"\n" +
+	"\b_file_id\"A\n" +
+	"\x10FileInfoResponse\x12-\n" +
+	"\x04body\x18\x01 \x01(\v2\x19.netfs.transport.FileInfoR\x04body\"m\n" +
+	"\x11FileCreateRequest\x12\x1d\n" +
+	"\areplace\x18\x01 \x01(\bH\x00R\areplace\x88\x01\x01\x12-\n" +
+	"\x04body\x18\x02 \x01(\v2\x19.netfs.transport.FileInfoR\x04bodyB\n" +
+	"\n" +
+	"\b_replace\"C\n" +
+	"\x12FileCreateResponse\x12-\n" +
+	"\x04body\x18\x01 \x01(\v2\x19.netfs.transport.FileInfoR\x04body\"\x9d\x01\n" +
+	"\x10FileWriteRequest\x12\x1c\n" +
+	"\afile_id\x18\x01 \x01(\tH\x00R\x06fileId\x88\x01\x01\x12\x1b\n" +
+	"\x06offset\x18\x02 \x01(\x04H\x01R\x06offset\x88\x01\x01\x12\x19\n" +
+	"\x05codec\x18\x03 \x01(\tH\x02R\x05codec\x88\x01\x01\x12\x12\n" +
+	"\x04data\x18\x04 \x01(\fR\x04dataB\n" +
+	"\n" +
+	"\b_file_idB\t\n" +
+	"\a_offsetB\b\n" +
+	"\x06_codec\"\x13\n" +
+	"\x11FileWriteResponse\"\x85\x01\n" +
+	"\x0fFileReadRequest\x12\x1c\n" +
+	"\afile_id\x18\x01 \x01(\tH\x00R\x06fileId\x88\x01\x01\x12\x1b\n" +
+	"\x06offset\x18\x02 \x01(\x04H\x01R\x06offset\x88\x01\x01\x12\x17\n" +
+	"\x04size\x18\x03 \x01(\x04H\x02R\x04size\x88\x01\x01B\n" +
+	"\n" +
+	"\b_file_idB\t\n" +
+	"\a_offsetB\a\n" +
+	"\x05_size\"&\n" +
+	"\x10FileReadResponse\x12\x12\n" +
+	"\x04data\x18\x01 \x01(\fR\x04data\"w\n" +
+	"\x12FileExtractRequest\x12\x1c\n" +
+	"\afile_id\x18\x01 \x01(\tH\x00R\x06fileId\x88\x01\x01\x12\x19\n" +
+	"\x05codec\x18\x02 \x01(\tH\x01R\x05codec\x88\x01\x01\x12\x12\n" +
+	"\x04data\x18\x03 \x01(\fR\x04dataB\n" +
+	"\n" +
+	"\b_file_idB\b\n" +
+	"\x06_codec\"\x15\n" +
+	"\x13FileExtractResponse\"s\n" +
+	"\x14FileSignatureRequest\x12\x1c\n" +
+	"\afile_id\x18\x01 \x01(\tH\x00R\x06fileId\x88\x01\x01\x12\"\n" +
+	"\n" +
+	"block_size\x18\x02 \x01(\x03H\x01R\tblockSize\x88\x01\x01B\n" +
+	"\n" +
+	"\b_file_idB\r\n" +
+	"\v_block_size\"K\n" +
+	"\x15FileSignatureResponse\x122\n" +
+	"\x04body\x18\x01 \x01(\v2\x1e.netfs.transport.FileSignatureR\x04body\"\xa8\x01\n" +
+	"\x10FileDeltaRequest\x12\x1c\n" +
+	"\afile_id\x18\x01 \x01(\tH\x00R\x06fileId\x88\x01\x01\x12\"\n" +
+	"\n" +
+	"block_size\x18\x02 \x01(\x03H\x01R\tblockSize\x88\x01\x01\x12\x19\n" +
+	"\x05codec\x18\x03 \x01(\tH\x02R\x05codec\x88\x01\x01\x12\x12\n" +
+	"\x04data\x18\x04 \x01(\fR\x04dataB\n" +
+	"\n" +
+	"\b_file_idB\r\n" +
+	"\v_block_sizeB\b\n" +
+	"\x06_codec\"\x13\n" +
+	"\x11FileDeltaResponse\"=\n" +
+	"\x11FileRemoveRequest\x12\x1c\n" +
+	"\afile_id\x18\x01 \x01(\tH\x00R\x06fileId\x88\x01\x01B\n" +
+	"\n" +
+	"\b_file_id\"\x14\n" +
+	"\x12FileRemoveResponse\"_\n" +
+	"\x11FileRenameRequest\x12\x1c\n" +
+	"\afile_id\x18\x01 \x01(\tH\x00R\x06fileId\x88\x01\x01\x12\x17\n" +
+	"\x04name\x18\x02 \x01(\tH\x01R\x04name\x88\x01\x01B\n" +
+	"\n" +
+	"\b_file_idB\a\n" +
+	"\x05_name\"C\n" +
+	"\x12FileRenameResponse\x12-\n" +
+	"\x04body\x18\x01 \x01(\v2\x19.netfs.transport.FileInfoR\x04body\"\x11\n" +
+	"\x0fFileCopyRequest\"G\n" +
+	"\x10FileCopyResponse\x123\n" +
+	"\x04body\x18\x01 \x03(\v2\x1f.netfs.transport.RemoteCopyTaskR\x04body\"K\n" +
+	"\x14FileCopyStartRequest\x123\n" +
+	"\x04body\x18\x01 \x01(\v2\x1f.netfs.transport.RemoteCopyTaskR\x04body\"L\n" +
+	"\x15FileCopyStartResponse\x123\n" +
+	"\x04body\x18\x01 \x01(\v2\x1f.netfs.transport.RemoteCopyTaskR\x04body\"3\n" +
+	"\x15FileCopyStatusRequest\x12\x13\n" +
+	"\x02id\x18\x01 \x01(\tH\x00R\x02id\x88\x01\x01B\x05\n" +
+	"\x03_id\"M\n" +
+	"\x16FileCopyStatusResponse\x123\n" +
+	"\x04body\x18\x01 \x01(\v2\x1f.netfs.transport.RemoteCopyTaskR\x04body\"3\n" +
+	"\x15FileCopyCancelRequest\x12\x13\n" +
+	"\x02id\x18\x01 \x01(\tH\x00R\x02id\x88\x01\x01B\x05\n" +
+	"\x03_id\"\x18\n" +
+	"\x16FileCopyCancelResponse\"2\n" +
+	"\x14FileCopyPauseRequest\x12\x13\n" +
+	"\x02id\x18\x01 \x01(\tH\x00R\x02id\x88\x01\x01B\x05\n" +
+	"\x03_id\"\x17\n" +
+	"\x15FileCopyPauseResponse\"3\n" +
+	"\x15FileCopyResumeRequest\x12\x13\n" +
+	"\x02id\x18\x01 \x01(\tH\x00R\x02id\x88\x01\x01B\x05\n" +
+	"\x03_id\"\x18\n" +
+	"\x16FileCopyResumeResponse\"\x14\n" +
+	"\x12TaskHistoryRequest\"J\n" +
+	"\x13TaskHistoryResponse\x123\n" +
+	"\x04body\x18\x01 \x03(\v2\x1f.netfs.transport.RemoteCopyTaskR\x04body\"W\n" +
+	"\x14FileCopyLimitRequest\x12\x13\n" +
+	"\x02id\x18\x01 \x01(\tH\x00R\x02id\x88\x01\x01\x12\x19\n" +
+	"\x05limit\x18\x02 \x01(\x04H\x01R\x05limit\x88\x01\x01B\x05\n" +
+	"\x03_idB\b\n" +
+	"\x06_limit\"\x17\n" +
+	"\x15FileCopyLimitResponse\"?\n" +
+	"\x13FileChildrenRequest\x12\x1c\n" +
+	"\afile_id\x18\x01 \x01(\tH\x00R\x06fileId\x88\x01\x01B\n" +
+	"\n" +
+	"\b_file_id\"E\n" +
+	"\x14FileChildrenResponse\x12-\n" +
+	"\x04body\x18\x01 \x03(\v2\x19.netfs.transport.FileInfoR\x04body\"\x97\x03\n" +
+	"\x11FileSearchRequest\x12\x1c\n" +
+	"\afile_id\x18\x01 \x01(\tH\x00R\x06fileId\x88\x01\x01\x12\x1d\n" +
+	"\apattern\x18\x02 \x01(\tH\x01R\apattern\x88\x01\x01\x12\x1b\n" +
+	"\x06regexp\x18\x03 \x01(\tH\x02R\x06regexp\x88\x01\x01\x12\x17\n" +
+	"\x04type\x18\x04 \x01(\tH\x03R\x04type\x88\x01\x01\x12\x1e\n" +
+	"\bmin_size\x18\x05 \x01(\x04H\x04R\aminSize\x88\x01\x01\x12\x1e\n" +
+	"\bmax_size\x18\x06 \x01(\x04H\x05R\amaxSize\x88\x01\x01\x120\n" +
+	"\x05after\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\x05after\x122\n" +
+	"\x06before\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\x06before\x12\x19\n" +
+	"\x05limit\x18\t \x01(\x03H\x06R\x05limit\x88\x01\x01B\n" +
+	"\n" +
+	"\b_file_idB\n" +
+	"\n" +
+	"\b_patternB\t\n" +
+	"\a_regexpB\a\n" +
+	"\x05_typeB\v\n" +
+	"\t_min_sizeB\v\n" +
+	"\t_max_sizeB\b\n" +
+	"\x06_limit\"C\n" +
+	"\x12FileSearchResponse\x12-\n" +
+	"\x04body\x18\x01 \x03(\v2\x19.netfs.transport.FileInfoR\x04body\"\xc1\x02\n" +
+	"\x0fFileGrepRequest\x12\x1c\n" +
+	"\afile_id\x18\x01 \x01(\tH\x00R\x06fileId\x88\x01\x01\x12\x1d\n" +
+	"\apattern\x18\x02 \x01(\tH\x01R\apattern\x88\x01\x01\x12\x1b\n" +
+	"\x06regexp\x18\x03 \x01(\bH\x02R\x06regexp\x88\x01\x01\x12$\n" +
+	"\vignore_case\x18\x04 \x01(\bH\x03R\n" +
+	"ignoreCase\x88\x01\x01\x12\x1d\n" +
+	"\ainclude\x18\x05 \x01(\tH\x04R\ainclude\x88\x01\x01\x12\x1e\n" +
+	"\bmax_size\x18\x06 \x01(\x04H\x05R\amaxSize\x88\x01\x01\x12\x19\n" +
+	"\x05limit\x18\a \x01(\x03H\x06R\x05limit\x88\x01\x01B\n" +
+	"\n" +
+	"\b_file_idB\n" +
+	"\n" +
+	"\b_patternB\t\n" +
+	"\a_regexpB\x0e\n" +
+	"\f_ignore_caseB\n" +
+	"\n" +
+	"\b_includeB\v\n" +
+	"\t_max_sizeB\b\n" +
+	"\x06_limit\"B\n" +
+	"\x10FileGrepResponse\x12.\n" +
+	"\x04body\x18\x01 \x03(\v2\x1a.netfs.transport.GrepMatchR\x04body\"=\n" +
+	"\x11VolumeInfoRequest\x12\x1c\n" +
+	"\afile_id\x18\x01 \x01(\tH\x00R\x06fileId\x88\x01\x01B\n" +
+	"\n" +
+	"\b_file_id\"E\n" +
+	"\x12VolumeInfoResponse\x12/\n" +
+	"\x04body\x18\x01 \x03(\v2\x1b.netfs.transport.VolumeInfoR\x04body*R\n" +
+	"\bFileType\x12\x19\n" +
+	"\x15FILE_TYPE_UNSPECIFIED\x10\x00\x12\x12\n" +
+	"\x0eFILE_TYPE_FILE\x10\x01\x12\x17\n" +
+	"\x13FILE_TYPE_DIRECTORY\x10\x02*j\n" +
+	"\x11TransportProtocol\x12\x1b\n" +
+	"\x17TRANSPORT_PROTOCOL_HTTP\x10\x00\x12\x1b\n" +
+	"\x17TRANSPORT_PROTOCOL_CALL\x10\x01\x12\x1b\n" +
+	"\x17TRANSPORT_PROTOCOL_GRPC\x10\x02*s\n" +
+	"\n" +
+	"TaskStatus\x12\x16\n" +
+	"\x12TASK_STATUS_FAILED\x10\x00\x12\x17\n" +
+	"\x13TASK_STATUS_RUNNING\x10\x01\x12\x19\n" +
+	"\x15TASK_STATUS_CANCELLED\x10\x02\x12\x19\n" +
+	"\x15TASK_STATUS_COMPLETED\x10\x032\xbd\x1b\n" +
+	"\x05Netfs\x12\x92\x01\n" +
+	"\n" +
+	"ServerHost\x12\".netfs.transport.ServerHostRequest\x1a#.netfs.transport.ServerHostResponse\";\xca\xf3\x18\x16/netfs/api/server/host\xca\xf3\x18\x1dGET /netfs/api/v2/server/host\x12\x90\x01\n" +
+	"\n" +
+	"ServerStop\x12\".netfs.transport.ServerStopRequest\x1a#.netfs.transport.ServerStopResponse\"9\xca\xf3\x18\x16/netfs/api/server/stop\xca\xf3\x18\x1bDELETE /netfs/api/v2/server\x12\x83\x01\n" +
+	"\bFileInfo\x12 .netfs.transport.FileInfoRequest\x1a!.netfs.transport.FileInfoResponse\"2\xca\xf3\x18\x14/netfs/api/file/info\xca\xf3\x18\x16GET /netfs/api/v2/file\x12\x8b\x01\n" +
+	"\n" +
+	"FileCreate\x12\".netfs.transport.FileCreateRequest\x1a#.netfs.transport.FileCreateResponse\"4\xca\xf3\x18\x16/netfs/api/file/create\xca\xf3\x18\x16PUT /netfs/api/v2/file\x12\x8f\x01\n" +
+	"\tFileWrite\x12!.netfs.transport.FileWriteRequest\x1a\".netfs.transport.FileWriteResponse\"9\xca\xf3\x18\x15/netfs/api/file/write\xca\xf3\x18\x1cPOST /netfs/api/v2/file/data(\x01\x12\x8a\x01\n" +
+	"\bFileRead\x12 .netfs.transport.FileReadRequest\x1a!.netfs.transport.FileReadResponse\"7\xca\xf3\x18\x14/netfs/api/file/read\xca\xf3\x18\x1bGET /netfs/api/v2/file/data0\x01\x12\x9a\x01\n" +
+	"\vFileExtract\x12#.netfs.transport.FileExtractRequest\x1a$.netfs.transport.FileExtractResponse\">\xca\xf3\x18\x17/netfs/api/file/extract\xca\xf3\x18\x1fPOST /netfs/api/v2/file/archive(\x01\x12\xa1\x01\n" +
+	"\rFileSignature\x12%.netfs.transport.FileSignatureRequest\x1a&.netfs.transport.FileSignatureResponse\"A\xca\xf3\x18\x19/netfs/api/file/signature\xca\xf3\x18 GET /netfs/api/v2/file/signature\x12\x90\x01\n" +
+	"\tFileDelta\x12!.netfs.transport.FileDeltaRequest\x1a\".netfs.transport.FileDeltaResponse\":\xca\xf3\x18\x15/netfs/api/file/delta\xca\xf3\x18\x1dPOST /netfs/api/v2/file/delta(\x01\x12\x8e\x01\n" +
+	"\n" +
+	"FileRemove\x12\".netfs.transport.FileRemoveRequest\x1a#.netfs.transport.FileRemoveResponse\"7\xca\xf3\x18\x16/netfs/api/file/remove\xca\xf3\x18\x19DELETE /netfs/api/v2/file\x12\x8d\x01\n" +
+	"\n" +
+	"FileRename\x12\".netfs.transport.FileRenameRequest\x1a#.netfs.transport.FileRenameResponse\"6\xca\xf3\x18\x16/netfs/api/file/rename\xca\xf3\x18\x18PATCH /netfs/api/v2/file\x12\x87\x01\n" +
+	"\bFileCopy\x12 .netfs.transport.FileCopyRequest\x1a!.netfs.transport.FileCopyResponse\"6\xca\xf3\x18\x18/netfs/api/file/copy/all\xca\xf3\x18\x16GET /netfs/api/v2/copy\x12\x99\x01\n" +
+	"\rFileCopyStart\x12%.netfs.transport.FileCopyStartRequest\x1a&.netfs.transport.FileCopyStartResponse\"9\xca\xf3\x18\x1a/netfs/api/file/copy/start\xca\xf3\x18\x17POST /netfs/api/v2/copy\x12\xa1\x01\n" +
+	"\x0eFileCopyStatus\x12&.netfs.transport.FileCopyStatusRequest\x1a'.netfs.transport.FileCopyStatusResponse\">\xca\xf3\x18\x1b/netfs/api/file/copy/status\xca\xf3\x18\x1bGET /netfs/api/v2/copy/task\x12\xa4\x01\n" +
+	"\x0eFileCopyCancel\x12&.netfs.transport.FileCopyCancelRequest\x1a'.netfs.transport.FileCopyCancelResponse\"A\xca\xf3\x18\x1b/netfs/api/file/copy/cancel\xca\xf3\x18\x1eDELETE /netfs/api/v2/copy/task\x12\xa3\x01\n" +
+	"\rFileCopyPause\x12%.netfs.transport.FileCopyPauseRequest\x1a&.netfs.transport.FileCopyPauseResponse\"C\xca\xf3\x18\x1a/netfs/api/file/copy/pause\xca\xf3\x18!PUT /netfs/api/v2/copy/task/pause\x12\xaa\x01\n" +
+	"\x0eFileCopyResume\x12&.netfs.transport.FileCopyResumeRequest\x1a'.netfs.transport.FileCopyResumeResponse\"G\xca\xf3\x18\x1b/netfs/api/file/copy/resume\xca\xf3\x18$DELETE /netfs/api/v2/copy/task/pause\x12\x9c\x01\n" +
+	"\vTaskHistory\x12#.netfs.transport.TaskHistoryRequest\x1a$.netfs.transport.TaskHistoryResponse\"B\xca\xf3\x18\x1c/netfs/api/file/copy/history\xca\xf3\x18\x1eGET /netfs/api/v2/copy/history\x12\xa3\x01\n" +
+	"\rFileCopyLimit\x12%.netfs.transport.FileCopyLimitRequest\x1a&.netfs.transport.FileCopyLimitResponse\"C\xca\xf3\x18\x1a/netfs/api/file/copy/limit\xca\xf3\x18!PUT /netfs/api/v2/copy/task/limit\x12\x9e\x01\n" +
+	"\fFileChildren\x12$.netfs.transport.FileChildrenRequest\x1a%.netfs.transport.FileChildrenResponse\"?\xca\xf3\x18\x18/netfs/api/file/children\xca\xf3\x18\x1fGET /netfs/api/v2/file/children0\x01\x12\x98\x01\n" +
+	"\n" +
+	"FileSearch\x12\".netfs.transport.FileSearchRequest\x1a#.netfs.transport.FileSearchResponse\"?\xca\xf3\x18\x16/netfs/api/file/search\xca\xf3\x18\x1dGET /netfs/api/v2/file/search\xd0\xf3\x18\x010\x01\x12\x8e\x01\n" +
+	"\bFileGrep\x12 .netfs.transport.FileGrepRequest\x1a!.netfs.transport.FileGrepResponse\";\xca\xf3\x18\x14/netfs/api/file/grep\xca\xf3\x18\x1bGET /netfs/api/v2/file/grep\xd0\xf3\x18\x010\x01\x12\x8d\x01\n" +
+	"\n" +
+	"VolumeInfo\x12\".netfs.transport.VolumeInfoRequest\x1a#.netfs.transport.VolumeInfoResponse\"6\xca\xf3\x18\x16/netfs/api/volume/info\xca\xf3\x18\x18GET /netfs/api/v2/volume:>\n" +
+	"\tendpoints\x12\x1e.google.protobuf.MethodOptions\x18\xb9\x8e\x03 \x03(\tR\tendpoints:?\n" +
+	"\n" +
+	"json_lines\x12\x1e.google.protobuf.MethodOptions\x18\xba\x8e\x03 \x01(\bR\tjsonLinesB\x18Z\x16netfs/api/transport/pbb\x06proto3"
+
+var (
+	file_transport_proto_rawDescOnce sync.Once
+	file_transport_proto_rawDescData []byte
+)
+
+func file_transport_proto_rawDescGZIP() []byte {
+	file_transport_proto_rawDescOnce.Do(func() {
+		file_transport_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_transport_proto_rawDesc), len(file_transport_proto_rawDesc)))
+	})
+	return file_transport_proto_rawDescData
+}
+
+var file_transport_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
+var file_transport_proto_msgTypes = make([]protoimpl.MessageInfo, 58)
+var file_transport_proto_goTypes = []any{
+	(FileType)(0),                      // 0: netfs.transport.FileType
+	(TransportProtocol)(0),             // 1: netfs.transport.TransportProtocol
+	(TaskStatus)(0),                    // 2: netfs.transport.TaskStatus
+	(*FileInfo)(nil),                   // 3: netfs.transport.FileInfo
+	(*RootSpace)(nil),                  // 4: netfs.transport.RootSpace
+	(*HostCapabilities)(nil),           // 5: netfs.transport.HostCapabilities
+	(*RemoteHost)(nil),                 // 6: netfs.transport.RemoteHost
+	(*RemoteFile)(nil),                 // 7: netfs.transport.RemoteFile
+	(*RemoteError)(nil),                // 8: netfs.transport.RemoteError
+	(*TaskStats)(nil),                  // 9: netfs.transport.TaskStats
+	(*RemoteCopyTask)(nil),             // 10: netfs.transport.RemoteCopyTask
+	(*BlockSignature)(nil),             // 11: netfs.transport.BlockSignature
+	(*FileSignature)(nil),              // 12: netfs.transport.FileSignature
+	(*VolumeInfo)(nil),                 // 13: netfs.transport.VolumeInfo
+	(*GrepMatch)(nil),                  // 14: netfs.transport.GrepMatch
+	(*ServerHostRequest)(nil),          // 15: netfs.transport.ServerHostRequest
+	(*ServerHostResponse)(nil),         // 16: netfs.transport.ServerHostResponse
+	(*ServerStopRequest)(nil),          // 17: netfs.transport.ServerStopRequest
+	(*ServerStopResponse)(nil),         // 18: netfs.transport.ServerStopResponse
+	(*FileInfoRequest)(nil),            // 19: netfs.transport.FileInfoRequest
+	(*FileInfoResponse)(nil),           // 20: netfs.transport.FileInfoResponse
+	(*FileCreateRequest)(nil),          // 21: netfs.transport.FileCreateRequest
+	(*FileCreateResponse)(nil),         // 22: netfs.transport.FileCreateResponse
+	(*FileWriteRequest)(nil),           // 23: netfs.transport.FileWriteRequest
+	(*FileWriteResponse)(nil),          // 24: netfs.transport.FileWriteResponse
+	(*FileReadRequest)(nil),            // 25: netfs.transport.FileReadRequest
+	(*FileReadResponse)(nil),           // 26: netfs.transport.FileReadResponse
+	(*FileExtractRequest)(nil),         // 27: netfs.transport.FileExtractRequest
+	(*FileExtractResponse)(nil),        // 28: netfs.transport.FileExtractResponse
+	(*FileSignatureRequest)(nil),       // 29: netfs.transport.FileSignatureRequest
+	(*FileSignatureResponse)(nil),      // 30: netfs.transport.FileSignatureResponse
+	(*FileDeltaRequest)(nil),           // 31: netfs.transport.FileDeltaRequest
+	(*FileDeltaResponse)(nil),          // 32: netfs.transport.FileDeltaResponse
+	(*FileRemoveRequest)(nil),          // 33: netfs.transport.FileRemoveRequest
+	(*FileRemoveResponse)(nil),         // 34: netfs.transport.FileRemoveResponse
+	(*FileRenameRequest)(nil),          // 35: netfs.transport.FileRenameRequest
+	(*FileRenameResponse)(nil),         // 36: netfs.transport.FileRenameResponse
+	(*FileCopyRequest)(nil),            // 37: netfs.transport.FileCopyRequest
+	(*FileCopyResponse)(nil),           // 38: netfs.transport.FileCopyResponse
+	(*FileCopyStartRequest)(nil),       // 39: netfs.transport.FileCopyStartRequest
+	(*FileCopyStartResponse)(nil),      // 40: netfs.transport.FileCopyStartResponse
+	(*FileCopyStatusRequest)(nil),      // 41: netfs.transport.FileCopyStatusRequest
+	(*FileCopyStatusResponse)(nil),     // 42: netfs.transport.FileCopyStatusResponse
+	(*FileCopyCancelRequest)(nil),      // 43: netfs.transport.FileCopyCancelRequest
+	(*FileCopyCancelResponse)(nil),     // 44: netfs.transport.FileCopyCancelResponse
+	(*FileCopyPauseRequest)(nil),       // 45: netfs.transport.FileCopyPauseRequest
+	(*FileCopyPauseResponse)(nil),      // 46: netfs.transport.FileCopyPauseResponse
+	(*FileCopyResumeRequest)(nil),      // 47: netfs.transport.FileCopyResumeRequest
+	(*FileCopyResumeResponse)(nil),     // 48: netfs.transport.FileCopyResumeResponse
+	(*TaskHistoryRequest)(nil),         // 49: netfs.transport.TaskHistoryRequest
+	(*TaskHistoryResponse)(nil),        // 50: netfs.transport.TaskHistoryResponse
+	(*FileCopyLimitRequest)(nil),       // 51: netfs.transport.FileCopyLimitRequest
+	(*FileCopyLimitResponse)(nil),      // 52: netfs.transport.FileCopyLimitResponse
+	(*FileChildrenRequest)(nil),        // 53: netfs.transport.FileChildrenRequest
+	(*FileChildrenResponse)(nil),       // 54: netfs.transport.FileChildrenResponse
+	(*FileSearchRequest)(nil),          // 55: netfs.transport.FileSearchRequest
+	(*FileSearchResponse)(nil),         // 56: netfs.transport.FileSearchResponse
+	(*FileGrepRequest)(nil),            // 57: netfs.transport.FileGrepRequest
+	(*FileGrepResponse)(nil),           // 58: netfs.transport.FileGrepResponse
+	(*VolumeInfoRequest)(nil),          // 59: netfs.transport.VolumeInfoRequest
+	(*VolumeInfoResponse)(nil),         // 60: netfs.transport.VolumeInfoResponse
+	(*timestamppb.Timestamp)(nil),      // 61: google.protobuf.Timestamp
+	(*durationpb.Duration)(nil),        // 62: google.protobuf.Duration
+	(*descriptorpb.MethodOptions)(nil), // 63: google.protobuf.MethodOptions
+}
+var file_transport_proto_depIdxs = []int32{
+	0,  // 0: netfs.transport.FileInfo.type:type_name -> netfs.transport.FileType
+	61, // 1: netfs.transport.FileInfo.mod_time:type_name -> google.protobuf.Timestamp
+	1,  // 2: netfs.transport.HostCapabilities.protocols:type_name -> netfs.transport.TransportProtocol
+	4,  // 3: netfs.transport.HostCapabilities.roots:type_name -> netfs.transport.RootSpace
+	62, // 4: netfs.transport.HostCapabilities.uptime:type_name -> google.protobuf.Duration
+	5,  // 5: netfs.transport.RemoteHost.capabilities:type_name -> netfs.transport.HostCapabilities
+	3,  // 6: netfs.transport.RemoteFile.info:type_name -> netfs.transport.FileInfo
+	6,  // 7: netfs.transport.RemoteFile.host:type_name -> netfs.transport.RemoteHost
+	7,  // 8: netfs.transport.RemoteCopyTask.source:type_name -> netfs.transport.RemoteFile
+	7,  // 9: netfs.transport.RemoteCopyTask.target:type_name -> netfs.transport.RemoteFile
+	6,  // 10: netfs.transport.RemoteCopyTask.host:type_name -> netfs.transport.RemoteHost
+	8,  // 11: netfs.transport.RemoteCopyTask.error:type_name -> netfs.transport.RemoteError
+	2,  // 12: netfs.transport.RemoteCopyTask.status:type_name -> netfs.transport.TaskStatus
+	9,  // 13: netfs.transport.RemoteCopyTask.stats:type_name -> netfs.transport.TaskStats
+	62, // 14: netfs.transport.RemoteCopyTask.elapsed:type_name -> google.protobuf.Duration
+	11, // 15: netfs.transport.FileSignature.blocks:type_name -> netfs.transport.BlockSignature
+	3,  // 16: netfs.transport.GrepMatch.file:type_name -> netfs.transport.FileInfo
+	6,  // 17: netfs.transport.ServerHostResponse.body:type_name -> netfs.transport.RemoteHost
+	3,  // 18: netfs.transport.FileInfoResponse.body:type_name -> netfs.transport.FileInfo
+	3,  // 19: netfs.transport.FileCreateRequest.body:type_name -> netfs.transport.FileInfo
+	3,  // 20: netfs.transport.FileCreateResponse.body:type_name -> netfs.transport.FileInfo
+	12, // 21: netfs.transport.FileSignatureResponse.body:type_name -> netfs.transport.FileSignature
+	3,  // 22: netfs.transport.FileRenameResponse.body:type_name -> netfs.transport.FileInfo
+	10, // 23: netfs.transport.FileCopyResponse.body:type_name -> netfs.transport.RemoteCopyTask
+	10, // 24: netfs.transport.FileCopyStartRequest.body:type_name -> netfs.transport.RemoteCopyTask
+	10, // 25: netfs.transport.FileCopyStartResponse.body:type_name -> netfs.transport.RemoteCopyTask
+	10, // 26: netfs.transport.FileCopyStatusResponse.body:type_name -> netfs.transport.RemoteCopyTask
+	10, // 27: netfs.transport.TaskHistoryResponse.body:type_name -> netfs.transport.RemoteCopyTask
+	3,  // 28: netfs.transport.FileChildrenResponse.body:type_name -> netfs.transport.FileInfo
+	61, // 29: netfs.transport.FileSearchRequest.after:type_name -> google.protobuf.Timestamp
+	61, // 30: netfs.transport.FileSearchRequest.before:type_name -> google.protobuf.Timestamp
+	3,  // 31: netfs.transport.FileSearchResponse.body:type_name -> netfs.transport.FileInfo
+	14, // 32: netfs.transport.FileGrepResponse.body:type_name -> netfs.transport.GrepMatch
+	13, // 33: netfs.transport.VolumeInfoResponse.body:type_name -> netfs.transport.VolumeInfo
+	63, // 34: netfs.transport.endpoints:extendee -> google.protobuf.MethodOptions
+	63, // 35: netfs.transport.json_lines:extendee -> google.protobuf.MethodOptions
+	15, // 36: netfs.transport.Netfs.ServerHost:input_type -> netfs.transport.ServerHostRequest
+	17, // 37: netfs.transport.Netfs.ServerStop:input_type -> netfs.transport.ServerStopRequest
+	19, // 38: netfs.transport.Netfs.FileInfo:input_type -> netfs.transport.FileInfoRequest
+	21, // 39: netfs.transport.Netfs.FileCreate:input_type -> netfs.transport.FileCreateRequest
+	23, // 40: netfs.transport.Netfs.FileWrite:input_type -> netfs.transport.FileWriteRequest
+	25, // 41: netfs.transport.Netfs.FileRead:input_type -> netfs.transport.FileReadRequest
+	27, // 42: netfs.transport.Netfs.FileExtract:input_type -> netfs.transport.FileExtractRequest
+	29, // 43: netfs.transport.Netfs.FileSignature:input_type -> netfs.transport.FileSignatureRequest
+	31, // 44: netfs.transport.Netfs.FileDelta:input_type -> netfs.transport.FileDeltaRequest
+	33, // 45: netfs.transport.Netfs.FileRemove:input_type -> netfs.transport.FileRemoveRequest
+	35, // 46: netfs.transport.Netfs.FileRename:input_type -> netfs.transport.FileRenameRequest
+	37, // 47: netfs.transport.Netfs.FileCopy:input_type -> netfs.transport.FileCopyRequest
+	39, // 48: netfs.transport.Netfs.FileCopyStart:input_type -> netfs.transport.FileCopyStartRequest
+	41, // 49: netfs.transport.Netfs.FileCopyStatus:input_type -> netfs.transport.FileCopyStatusRequest
+	43, // 50: netfs.transport.Netfs.FileCopyCancel:input_type -> netfs.transport.FileCopyCancelRequest
+	45, // 51: netfs.transport.Netfs.FileCopyPause:input_type -> netfs.transport.FileCopyPauseRequest
+	47, // 52: netfs.transport.Netfs.FileCopyResume:input_type -> netfs.transport.FileCopyResumeRequest
+	49, // 53: netfs.transport.Netfs.TaskHistory:input_type -> netfs.transport.TaskHistoryRequest
+	51, // 54: netfs.transport.Netfs.FileCopyLimit:input_type -> netfs.transport.FileCopyLimitRequest
+	53, // 55: netfs.transport.Netfs.FileChildren:input_type -> netfs.transport.FileChildrenRequest
+	55, // 56: netfs.transport.Netfs.FileSearch:input_type -> netfs.transport.FileSearchRequest
+	57, // 57: netfs.transport.Netfs.FileGrep:input_type -> netfs.transport.FileGrepRequest
+	59, // 58: netfs.transport.Netfs.VolumeInfo:input_type -> netfs.transport.VolumeInfoRequest
+	16, // 59: netfs.transport.Netfs.ServerHost:output_type -> netfs.transport.ServerHostResponse
+	18, // 60: netfs.transport.Netfs.ServerStop:output_type -> netfs.transport.ServerStopResponse
+	20, // 61: netfs.transport.Netfs.FileInfo:output_type -> netfs.transport.FileInfoResponse
+	22, // 62: netfs.transport.Netfs.FileCreate:output_type -> netfs.transport.FileCreateResponse
+	24, // 63: netfs.transport.Netfs.FileWrite:output_type -> netfs.transport.FileWriteResponse
+	26, // 64: netfs.transport.Netfs.FileRead:output_type -> netfs.transport.FileReadResponse
+	28, // 65: netfs.transport.Netfs.FileExtract:output_type -> netfs.transport.FileExtractResponse
+	30, // 66: netfs.transport.Netfs.FileSignature:output_type -> netfs.transport.FileSignatureResponse
+	32, // 67: netfs.transport.Netfs.FileDelta:output_type -> netfs.transport.FileDeltaResponse
+	34, // 68: netfs.transport.Netfs.FileRemove:output_type -> netfs.transport.FileRemoveResponse
+	36, // 69: netfs.transport.Netfs.FileRename:output_type -> netfs.transport.FileRenameResponse
+	38, // 70: netfs.transport.Netfs.FileCopy:output_type -> netfs.transport.FileCopyResponse
+	40, // 71: netfs.transport.Netfs.FileCopyStart:output_type -> netfs.transport.FileCopyStartResponse
+	42, // 72: netfs.transport.Netfs.FileCopyStatus:output_type -> netfs.transport.FileCopyStatusResponse
+	44, // 73: netfs.transport.Netfs.FileCopyCancel:output_type -> netfs.transport.FileCopyCancelResponse
+	46, // 74: netfs.transport.Netfs.FileCopyPause:output_type -> netfs.transport.FileCopyPauseResponse
+	48, // 75: netfs.transport.Netfs.FileCopyResume:output_type -> netfs.transport.FileCopyResumeResponse
+	50, // 76: netfs.transport.Netfs.TaskHistory:output_type -> netfs.transport.TaskHistoryResponse
+	52, // 77: netfs.transport.Netfs.FileCopyLimit:output_type -> netfs.transport.FileCopyLimitResponse
+	54, // 78: netfs.transport.Netfs.FileChildren:output_type -> netfs.transport.FileChildrenResponse
+	56, // 79: netfs.transport.Netfs.FileSearch:output_type -> netfs.transport.FileSearchResponse
+	58, // 80: netfs.transport.Netfs.FileGrep:output_type -> netfs.transport.FileGrepResponse
+	60, // 81: netfs.transport.Netfs.VolumeInfo:output_type -> netfs.transport.VolumeInfoResponse
+	59, // [59:82] is the sub-list for method output_type
+	36, // [36:59] is the sub-list for method input_type
+	36, // [36:36] is the sub-list for extension type_name
+	34, // [34:36] is the sub-list for extension extendee
+	0,  // [0:34] is the sub-list for field type_name
+}
+
+func init() { file_transport_proto_init() }
+func file_transport_proto_init() {
+	if File_transport_proto != nil {
+		return
+	}
+	file_transport_proto_msgTypes[16].OneofWrappers = []any{}
+	file_transport_proto_msgTypes[18].OneofWrappers = []any{}
+	file_transport_proto_msgTypes[20].OneofWrappers = []any{}
+	file_transport_proto_msgTypes[22].OneofWrappers = []any{}
+	file_transport_proto_msgTypes[24].OneofWrappers = []any{}
+	file_transport_proto_msgTypes[26].OneofWrappers = []any{}
+	file_transport_proto_msgTypes[28].OneofWrappers = []any{}
+	file_transport_proto_msgTypes[30].OneofWrappers = []any{}
+	file_transport_proto_msgTypes[32].OneofWrappers = []any{}
+	file_transport_proto_msgTypes[38].OneofWrappers = []any{}
+	file_transport_proto_msgTypes[40].OneofWrappers = []any{}
+	file_transport_proto_msgTypes[42].OneofWrappers = []any{}
+	file_transport_proto_msgTypes[44].OneofWrappers = []any{}
+	file_transport_proto_msgTypes[48].OneofWrappers = []any{}
+	file_transport_proto_msgTypes[50].OneofWrappers = []any{}
+	file_transport_proto_msgTypes[52].OneofWrappers = []any{}
+	file_transport_proto_msgTypes[54].OneofWrappers = []any{}
+	file_transport_proto_msgTypes[56].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_transport_proto_rawDesc), len(file_transport_proto_rawDesc)),
+			NumEnums:      3,
+			NumMessages:   58,
+			NumExtensions: 2,
+			NumServices:   1,
+		},
+		GoTypes:           file_transport_proto_goTypes,
+		DependencyIndexes: file_transport_proto_depIdxs,
+		EnumInfos:         file_transport_proto_enumTypes,
+		MessageInfos:      file_transport_proto_msgTypes,
+		ExtensionInfos:    file_transport_proto_extTypes,
+	}.Build()
+	File_transport_proto = out.File
+	file_transport_proto_goTypes = nil
+	file_transport_proto_depIdxs = nil
+}
@@ -0,0 +1,1008 @@
+// The gRPC API of netfs, every method serves the endpoints of all API versions listed in its options.
+//
+// The Go code is generated by `go generate` in netfs/api/transport with the pinned tools:
+//   buf v1.50.0 (`buf alpha protoc`, compatible with protoc v5.29.3),
+//   protoc-gen-go v1.36.11,
+//   protoc-gen-go-grpc v1.5.1.
+//
+// The messages are converted to the JSON of the Go types of netfs/api:
+//   - the fields of the request, except body and data, are the parameters of the endpoint, json_name is the name of the parameter;
+//   - body is the JSON body of the request or the response, json_name of its fields is the name of the field of the Go type;
+//   - data is the raw body, the streams send it by chunks;
+//   - the streamed records are joined to JSON array, or to JSON lines if the method sets json_lines.
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v5.29.3
+// source: transport.proto
+
+package pb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	Netfs_ServerHost_FullMethodName     = "/netfs.transport.Netfs/ServerHost"
+	Netfs_ServerStop_FullMethodName     = "/netfs.transport.Netfs/ServerStop"
+	Netfs_FileInfo_FullMethodName       = "/netfs.transport.Netfs/FileInfo"
+	Netfs_FileCreate_FullMethodName     = "/netfs.transport.Netfs/FileCreate"
+	Netfs_FileWrite_FullMethodName      = "/netfs.transport.Netfs/FileWrite"
+	Netfs_FileRead_FullMethodName       = "/netfs.transport.Netfs/FileRead"
+	Netfs_FileExtract_FullMethodName    = "/netfs.transport.Netfs/FileExtract"
+	Netfs_FileSignature_FullMethodName  = "/netfs.transport.Netfs/FileSignature"
+	Netfs_FileDelta_FullMethodName      = "/netfs.transport.Netfs/FileDelta"
+	Netfs_FileRemove_FullMethodName     = "/netfs.transport.Netfs/FileRemove"
+	Netfs_FileRename_FullMethodName     = "/netfs.transport.Netfs/FileRename"
+	Netfs_FileCopy_FullMethodName       = "/netfs.transport.Netfs/FileCopy"
+	Netfs_FileCopyStart_FullMethodName  = "/netfs.transport.Netfs/FileCopyStart"
+	Netfs_FileCopyStatus_FullMethodName = "/netfs.transport.Netfs/FileCopyStatus"
+	Netfs_FileCopyCancel_FullMethodName = "/netfs.transport.Netfs/FileCopyCancel"
+	Netfs_FileCopyPause_FullMethodName  = "/netfs.transport.Netfs/FileCopyPause"
+	Netfs_FileCopyResume_FullMethodName = "/netfs.transport.Netfs/FileCopyResume"
+	Netfs_TaskHistory_FullMethodName    = "/netfs.transport.Netfs/TaskHistory"
+	Netfs_FileCopyLimit_FullMethodName  = "/netfs.transport.Netfs/FileCopyLimit"
+	Netfs_FileChildren_FullMethodName   = "/netfs.transport.Netfs/FileChildren"
+	Netfs_FileSearch_FullMethodName     = "/netfs.transport.Netfs/FileSearch"
+	Netfs_FileGrep_FullMethodName       = "/netfs.transport.Netfs/FileGrep"
+	Netfs_VolumeInfo_FullMethodName     = "/netfs.transport.Netfs/VolumeInfo"
+)
+
+// NetfsClient is the client API for Netfs service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type NetfsClient interface {
+	// Returns information about the host.
+	ServerHost(ctx context.Context, in *ServerHostRequest, opts ...grpc.CallOption) (*ServerHostResponse, error)
+	// Stops the server.
+	ServerStop(ctx context.Context, in *ServerStopRequest, opts ...grpc.CallOption) (*ServerStopResponse, error)
+	// Returns information about the file.
+	FileInfo(ctx context.Context, in *FileInfoRequest, opts ...grpc.CallOption) (*FileInfoResponse, error)
+	// Creates the file or directory.
+	FileCreate(ctx context.Context, in *FileCreateRequest, opts ...grpc.CallOption) (*FileCreateResponse, error)
+	// Writes the data sent by chunks to the file.
+	FileWrite(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[FileWriteRequest, FileWriteResponse], error)
+	// Reads the range of the file by chunks.
+	FileRead(ctx context.Context, in *FileReadRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[FileReadResponse], error)
+	// Extracts the tar archive sent by chunks into the directory.
+	FileExtract(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[FileExtractRequest, FileExtractResponse], error)
+	// Returns signatures of the blocks of the file.
+	FileSignature(ctx context.Context, in *FileSignatureRequest, opts ...grpc.CallOption) (*FileSignatureResponse, error)
+	// Updates the file by the delta sent by chunks.
+	FileDelta(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[FileDeltaRequest, FileDeltaResponse], error)
+	// Removes the file or directory.
+	FileRemove(ctx context.Context, in *FileRemoveRequest, opts ...grpc.CallOption) (*FileRemoveResponse, error)
+	// Renames the file in its directory.
+	FileRename(ctx context.Context, in *FileRenameRequest, opts ...grpc.CallOption) (*FileRenameResponse, error)
+	// Returns all running tasks.
+	FileCopy(ctx context.Context, in *FileCopyRequest, opts ...grpc.CallOption) (*FileCopyResponse, error)
+	// Starts the task which copies the file or directory.
+	FileCopyStart(ctx context.Context, in *FileCopyStartRequest, opts ...grpc.CallOption) (*FileCopyStartResponse, error)
+	// Returns status of the task.
+	FileCopyStatus(ctx context.Context, in *FileCopyStatusRequest, opts ...grpc.CallOption) (*FileCopyStatusResponse, error)
+	// Cancels the task.
+	FileCopyCancel(ctx context.Context, in *FileCopyCancelRequest, opts ...grpc.CallOption) (*FileCopyCancelResponse, error)
+	// Pauses the running task.
+	FileCopyPause(ctx context.Context, in *FileCopyPauseRequest, opts ...grpc.CallOption) (*FileCopyPauseResponse, error)
+	// Resumes the paused task.
+	FileCopyResume(ctx context.Context, in *FileCopyResumeRequest, opts ...grpc.CallOption) (*FileCopyResumeResponse, error)
+	// Returns all kept tasks, including the completed ones.
+	TaskHistory(ctx context.Context, in *TaskHistoryRequest, opts ...grpc.CallOption) (*TaskHistoryResponse, error)
+	// Changes the rate limit of the task.
+	FileCopyLimit(ctx context.Context, in *FileCopyLimitRequest, opts ...grpc.CallOption) (*FileCopyLimitResponse, error)
+	// Returns children of the directory by batches.
+	FileChildren(ctx context.Context, in *FileChildrenRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[FileChildrenResponse], error)
+	// Returns the found files as soon as they are found.
+	FileSearch(ctx context.Context, in *FileSearchRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[FileSearchResponse], error)
+	// Returns the found lines as soon as they are found.
+	FileGrep(ctx context.Context, in *FileGrepRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[FileGrepResponse], error)
+	// Returns information about the volumes.
+	VolumeInfo(ctx context.Context, in *VolumeInfoRequest, opts ...grpc.CallOption) (*VolumeInfoResponse, error)
+}
+
+type netfsClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewNetfsClient(cc grpc.ClientConnInterface) NetfsClient {
+	return &netfsClient{cc}
+}
+
+func (c *netfsClient) ServerHost(ctx context.Context, in *ServerHostRequest, opts ...grpc.CallOption) (*ServerHostResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ServerHostResponse)
+	err := c.cc.Invoke(ctx, Netfs_ServerHost_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *netfsClient) ServerStop(ctx context.Context, in *ServerStopRequest, opts ...grpc.CallOption) (*ServerStopResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ServerStopResponse)
+	err := c.cc.Invoke(ctx, Netfs_ServerStop_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *netfsClient) FileInfo(ctx context.Context, in *FileInfoRequest, opts ...grpc.CallOption) (*FileInfoResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FileInfoResponse)
+	err := c.cc.Invoke(ctx, Netfs_FileInfo_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *netfsClient) FileCreate(ctx context.Context, in *FileCreateRequest, opts ...grpc.CallOption) (*FileCreateResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FileCreateResponse)
+	err := c.cc.Invoke(ctx, Netfs_FileCreate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *netfsClient) FileWrite(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[FileWriteRequest, FileWriteResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Netfs_ServiceDesc.Streams[0], Netfs_FileWrite_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[FileWriteRequest, FileWriteResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Netfs_FileWriteClient = grpc.ClientStreamingClient[FileWriteRequest, FileWriteResponse]
+
+func (c *netfsClient) FileRead(ctx context.Context, in *FileReadRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[FileReadResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Netfs_ServiceDesc.Streams[1], Netfs_FileRead_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[FileReadRequest, FileReadResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Netfs_FileReadClient = grpc.ServerStreamingClient[FileReadResponse]
+
+func (c *netfsClient) FileExtract(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[FileExtractRequest, FileExtractResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Netfs_ServiceDesc.Streams[2], Netfs_FileExtract_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[FileExtractRequest, FileExtractResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Netfs_FileExtractClient = grpc.ClientStreamingClient[FileExtractRequest, FileExtractResponse]
+
+func (c *netfsClient) FileSignature(ctx context.Context, in *FileSignatureRequest, opts ...grpc.CallOption) (*FileSignatureResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FileSignatureResponse)
+	err := c.cc.Invoke(ctx, Netfs_FileSignature_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *netfsClient) FileDelta(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[FileDeltaRequest, FileDeltaResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Netfs_ServiceDesc.Streams[3], Netfs_FileDelta_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[FileDeltaRequest, FileDeltaResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Netfs_FileDeltaClient = grpc.ClientStreamingClient[FileDeltaRequest, FileDeltaResponse]
+
+func (c *netfsClient) FileRemove(ctx context.Context, in *FileRemoveRequest, opts ...grpc.CallOption) (*FileRemoveResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FileRemoveResponse)
+	err := c.cc.Invoke(ctx, Netfs_FileRemove_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *netfsClient) FileRename(ctx context.Context, in *FileRenameRequest, opts ...grpc.CallOption) (*FileRenameResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FileRenameResponse)
+	err := c.cc.Invoke(ctx, Netfs_FileRename_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *netfsClient) FileCopy(ctx context.Context, in *FileCopyRequest, opts ...grpc.CallOption) (*FileCopyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FileCopyResponse)
+	err := c.cc.Invoke(ctx, Netfs_FileCopy_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *netfsClient) FileCopyStart(ctx context.Context, in *FileCopyStartRequest, opts ...grpc.CallOption) (*FileCopyStartResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FileCopyStartResponse)
+	err := c.cc.Invoke(ctx, Netfs_FileCopyStart_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *netfsClient) FileCopyStatus(ctx context.Context, in *FileCopyStatusRequest, opts ...grpc.CallOption) (*FileCopyStatusResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FileCopyStatusResponse)
+	err := c.cc.Invoke(ctx, Netfs_FileCopyStatus_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *netfsClient) FileCopyCancel(ctx context.Context, in *FileCopyCancelRequest, opts ...grpc.CallOption) (*FileCopyCancelResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FileCopyCancelResponse)
+	err := c.cc.Invoke(ctx, Netfs_FileCopyCancel_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *netfsClient) FileCopyPause(ctx context.Context, in *FileCopyPauseRequest, opts ...grpc.CallOption) (*FileCopyPauseResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FileCopyPauseResponse)
+	err := c.cc.Invoke(ctx, Netfs_FileCopyPause_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *netfsClient) FileCopyResume(ctx context.Context, in *FileCopyResumeRequest, opts ...grpc.CallOption) (*FileCopyResumeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FileCopyResumeResponse)
+	err := c.cc.Invoke(ctx, Netfs_FileCopyResume_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *netfsClient) TaskHistory(ctx context.Context, in *TaskHistoryRequest, opts ...grpc.CallOption) (*TaskHistoryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TaskHistoryResponse)
+	err := c.cc.Invoke(ctx, Netfs_TaskHistory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *netfsClient) FileCopyLimit(ctx context.Context, in *FileCopyLimitRequest, opts ...grpc.CallOption) (*FileCopyLimitResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FileCopyLimitResponse)
+	err := c.cc.Invoke(ctx, Netfs_FileCopyLimit_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *netfsClient) FileChildren(ctx context.Context, in *FileChildrenRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[FileChildrenResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Netfs_ServiceDesc.Streams[4], Netfs_FileChildren_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[FileChildrenRequest, FileChildrenResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Netfs_FileChildrenClient = grpc.ServerStreamingClient[FileChildrenResponse]
+
+func (c *netfsClient) FileSearch(ctx context.Context, in *FileSearchRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[FileSearchResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Netfs_ServiceDesc.Streams[5], Netfs_FileSearch_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[FileSearchRequest, FileSearchResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Netfs_FileSearchClient = grpc.ServerStreamingClient[FileSearchResponse]
+
+func (c *netfsClient) FileGrep(ctx context.Context, in *FileGrepRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[FileGrepResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Netfs_ServiceDesc.Streams[6], Netfs_FileGrep_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[FileGrepRequest, FileGrepResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Netfs_FileGrepClient = grpc.ServerStreamingClient[FileGrepResponse]
+
+func (c *netfsClient) VolumeInfo(ctx context.Context, in *VolumeInfoRequest, opts ...grpc.CallOption) (*VolumeInfoResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(VolumeInfoResponse)
+	err := c.cc.Invoke(ctx, Netfs_VolumeInfo_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// NetfsServer is the server API for Netfs service.
+// All implementations must embed UnimplementedNetfsServer
+// for forward compatibility.
+type NetfsServer interface {
+	// Returns information about the host.
+	ServerHost(context.Context, *ServerHostRequest) (*ServerHostResponse, error)
+	// Stops the server.
+	ServerStop(context.Context, *ServerStopRequest) (*ServerStopResponse, error)
+	// Returns information about the file.
+	FileInfo(context.Context, *FileInfoRequest) (*FileInfoResponse, error)
+	// Creates the file or directory.
+	FileCreate(context.Context, *FileCreateRequest) (*FileCreateResponse, error)
+	// Writes the data sent by chunks to the file.
+	FileWrite(grpc.ClientStreamingServer[FileWriteRequest, FileWriteResponse]) error
+	// Reads the range of the file by chunks.
+	FileRead(*FileReadRequest, grpc.ServerStreamingServer[FileReadResponse]) error
+	// Extracts the tar archive sent by chunks into the directory.
+	FileExtract(grpc.ClientStreamingServer[FileExtractRequest, FileExtractResponse]) error
+	// Returns signatures of the blocks of the file.
+	FileSignature(context.Context, *FileSignatureRequest) (*FileSignatureResponse, error)
+	// Updates the file by the delta sent by chunks.
+	FileDelta(grpc.ClientStreamingServer[FileDeltaRequest, FileDeltaResponse]) error
+	// Removes the file or directory.
+	FileRemove(context.Context, *FileRemoveRequest) (*FileRemoveResponse, error)
+	// Renames the file in its directory.
+	FileRename(context.Context, *FileRenameRequest) (*FileRenameResponse, error)
+	// Returns all running tasks.
+	FileCopy(context.Context, *FileCopyRequest) (*FileCopyResponse, error)
+	// Starts the task which copies the file or directory.
+	FileCopyStart(context.Context, *FileCopyStartRequest) (*FileCopyStartResponse, error)
+	// Returns status of the task.
+	FileCopyStatus(context.Context, *FileCopyStatusRequest) (*FileCopyStatusResponse, error)
+	// Cancels the task.
+	FileCopyCancel(context.Context, *FileCopyCancelRequest) (*FileCopyCancelResponse, error)
+	// Pauses the running task.
+	FileCopyPause(context.Context, *FileCopyPauseRequest) (*FileCopyPauseResponse, error)
+	// Resumes the paused task.
+	FileCopyResume(context.Context, *FileCopyResumeRequest) (*FileCopyResumeResponse, error)
+	// Returns all kept tasks, including the completed ones.
+	TaskHistory(context.Context, *TaskHistoryRequest) (*TaskHistoryResponse, error)
+	// Changes the rate limit of the task.
+	FileCopyLimit(context.Context, *FileCopyLimitRequest) (*FileCopyLimitResponse, error)
+	// Returns children of the directory by batches.
+	FileChildren(*FileChildrenRequest, grpc.ServerStreamingServer[FileChildrenResponse]) error
+	// Returns the found files as soon as they are found.
+	FileSearch(*FileSearchRequest, grpc.ServerStreamingServer[FileSearchResponse]) error
+	// Returns the found lines as soon as they are found.
+	FileGrep(*FileGrepRequest, grpc.ServerStreamingServer[FileGrepResponse]) error
+	// Returns information about the volumes.
+	VolumeInfo(context.Context, *VolumeInfoRequest) (*VolumeInfoResponse, error)
+	mustEmbedUnimplementedNetfsServer()
+}
+
+// UnimplementedNetfsServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedNetfsServer struct{}
+
+func (UnimplementedNetfsServer) ServerHost(context.Context, *ServerHostRequest) (*ServerHostResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ServerHost not implemented")
+}
+func (UnimplementedNetfsServer) ServerStop(context.Context, *ServerStopRequest) (*ServerStopResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ServerStop not implemented")
+}
+func (UnimplementedNetfsServer) FileInfo(context.Context, *FileInfoRequest) (*FileInfoResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FileInfo not implemented")
+}
+func (UnimplementedNetfsServer) FileCreate(context.Context, *FileCreateRequest) (*FileCreateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FileCreate not implemented")
+}
+func (UnimplementedNetfsServer) FileWrite(grpc.ClientStreamingServer[FileWriteRequest, FileWriteResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method FileWrite not implemented")
+}
+func (UnimplementedNetfsServer) FileRead(*FileReadRequest, grpc.ServerStreamingServer[FileReadResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method FileRead not implemented")
+}
+func (UnimplementedNetfsServer) FileExtract(grpc.ClientStreamingServer[FileExtractRequest, FileExtractResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method FileExtract not implemented")
+}
+func (UnimplementedNetfsServer) FileSignature(context.Context, *FileSignatureRequest) (*FileSignatureResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FileSignature not implemented")
+}
+func (UnimplementedNetfsServer) FileDelta(grpc.ClientStreamingServer[FileDeltaRequest, FileDeltaResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method FileDelta not implemented")
+}
+func (UnimplementedNetfsServer) FileRemove(context.Context, *FileRemoveRequest) (*FileRemoveResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FileRemove not implemented")
+}
+func (UnimplementedNetfsServer) FileRename(context.Context, *FileRenameRequest) (*FileRenameResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FileRename not implemented")
+}
+func (UnimplementedNetfsServer) FileCopy(context.Context, *FileCopyRequest) (*FileCopyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FileCopy not implemented")
+}
+func (UnimplementedNetfsServer) FileCopyStart(context.Context, *FileCopyStartRequest) (*FileCopyStartResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FileCopyStart not implemented")
+}
+func (UnimplementedNetfsServer) FileCopyStatus(context.Context, *FileCopyStatusRequest) (*FileCopyStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FileCopyStatus not implemented")
+}
+func (UnimplementedNetfsServer) FileCopyCancel(context.Context, *FileCopyCancelRequest) (*FileCopyCancelResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FileCopyCancel not implemented")
+}
+func (UnimplementedNetfsServer) FileCopyPause(context.Context, *FileCopyPauseRequest) (*FileCopyPauseResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FileCopyPause not implemented")
+}
+func (UnimplementedNetfsServer) FileCopyResume(context.Context, *FileCopyResumeRequest) (*FileCopyResumeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FileCopyResume not implemented")
+}
+func (UnimplementedNetfsServer) TaskHistory(context.Context, *TaskHistoryRequest) (*TaskHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TaskHistory not implemented")
+}
+func (UnimplementedNetfsServer) FileCopyLimit(context.Context, *FileCopyLimitRequest) (*FileCopyLimitResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FileCopyLimit not implemented")
+}
+func (UnimplementedNetfsServer) FileChildren(*FileChildrenRequest, grpc.ServerStreamingServer[FileChildrenResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method FileChildren not implemented")
+}
+func (UnimplementedNetfsServer) FileSearch(*FileSearchRequest, grpc.ServerStreamingServer[FileSearchResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method FileSearch not implemented")
+}
+func (UnimplementedNetfsServer) FileGrep(*FileGrepRequest, grpc.ServerStreamingServer[FileGrepResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method FileGrep not implemented")
+}
+func (UnimplementedNetfsServer) VolumeInfo(context.Context, *VolumeInfoRequest) (*VolumeInfoResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method VolumeInfo not implemented")
+}
+func (UnimplementedNetfsServer) mustEmbedUnimplementedNetfsServer() {}
+func (UnimplementedNetfsServer) testEmbeddedByValue()               {}
+
+// UnsafeNetfsServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to NetfsServer will
+// result in compilation errors.
+type UnsafeNetfsServer interface {
+	mustEmbedUnimplementedNetfsServer()
+}
+
+func RegisterNetfsServer(s grpc.ServiceRegistrar, srv NetfsServer) {
+	// If the following call pancis, it indicates UnimplementedNetfsServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&Netfs_ServiceDesc, srv)
+}
+
+func _Netfs_ServerHost_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ServerHostRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetfsServer).ServerHost(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Netfs_ServerHost_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetfsServer).ServerHost(ctx, req.(*ServerHostRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Netfs_ServerStop_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ServerStopRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetfsServer).ServerStop(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Netfs_ServerStop_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetfsServer).ServerStop(ctx, req.(*ServerStopRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Netfs_FileInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FileInfoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetfsServer).FileInfo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Netfs_FileInfo_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetfsServer).FileInfo(ctx, req.(*FileInfoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Netfs_FileCreate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FileCreateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetfsServer).FileCreate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Netfs_FileCreate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetfsServer).FileCreate(ctx, req.(*FileCreateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Netfs_FileWrite_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(NetfsServer).FileWrite(&grpc.GenericServerStream[FileWriteRequest, FileWriteResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Netfs_FileWriteServer = grpc.ClientStreamingServer[FileWriteRequest, FileWriteResponse]
+
+func _Netfs_FileRead_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(FileReadRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(NetfsServer).FileRead(m, &grpc.GenericServerStream[FileReadRequest, FileReadResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Netfs_FileReadServer = grpc.ServerStreamingServer[FileReadResponse]
+
+func _Netfs_FileExtract_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(NetfsServer).FileExtract(&grpc.GenericServerStream[FileExtractRequest, FileExtractResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Netfs_FileExtractServer = grpc.ClientStreamingServer[FileExtractRequest, FileExtractResponse]
+
+func _Netfs_FileSignature_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FileSignatureRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetfsServer).FileSignature(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Netfs_FileSignature_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetfsServer).FileSignature(ctx, req.(*FileSignatureRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Netfs_FileDelta_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(NetfsServer).FileDelta(&grpc.GenericServerStream[FileDeltaRequest, FileDeltaResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Netfs_FileDeltaServer = grpc.ClientStreamingServer[FileDeltaRequest, FileDeltaResponse]
+
+func _Netfs_FileRemove_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FileRemoveRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetfsServer).FileRemove(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Netfs_FileRemove_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetfsServer).FileRemove(ctx, req.(*FileRemoveRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Netfs_FileRename_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FileRenameRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetfsServer).FileRename(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Netfs_FileRename_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetfsServer).FileRename(ctx, req.(*FileRenameRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Netfs_FileCopy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FileCopyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetfsServer).FileCopy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Netfs_FileCopy_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetfsServer).FileCopy(ctx, req.(*FileCopyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Netfs_FileCopyStart_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FileCopyStartRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetfsServer).FileCopyStart(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Netfs_FileCopyStart_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetfsServer).FileCopyStart(ctx, req.(*FileCopyStartRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Netfs_FileCopyStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FileCopyStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetfsServer).FileCopyStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Netfs_FileCopyStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetfsServer).FileCopyStatus(ctx, req.(*FileCopyStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Netfs_FileCopyCancel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FileCopyCancelRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetfsServer).FileCopyCancel(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Netfs_FileCopyCancel_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetfsServer).FileCopyCancel(ctx, req.(*FileCopyCancelRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Netfs_FileCopyPause_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FileCopyPauseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetfsServer).FileCopyPause(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Netfs_FileCopyPause_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetfsServer).FileCopyPause(ctx, req.(*FileCopyPauseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Netfs_FileCopyResume_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FileCopyResumeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetfsServer).FileCopyResume(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Netfs_FileCopyResume_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetfsServer).FileCopyResume(ctx, req.(*FileCopyResumeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Netfs_TaskHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TaskHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetfsServer).TaskHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Netfs_TaskHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetfsServer).TaskHistory(ctx, req.(*TaskHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Netfs_FileCopyLimit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FileCopyLimitRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetfsServer).FileCopyLimit(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Netfs_FileCopyLimit_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetfsServer).FileCopyLimit(ctx, req.(*FileCopyLimitRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Netfs_FileChildren_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(FileChildrenRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(NetfsServer).FileChildren(m, &grpc.GenericServerStream[FileChildrenRequest, FileChildrenResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Netfs_FileChildrenServer = grpc.ServerStreamingServer[FileChildrenResponse]
+
+func _Netfs_FileSearch_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(FileSearchRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(NetfsServer).FileSearch(m, &grpc.GenericServerStream[FileSearchRequest, FileSearchResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Netfs_FileSearchServer = grpc.ServerStreamingServer[FileSearchResponse]
+
+func _Netfs_FileGrep_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(FileGrepRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(NetfsServer).FileGrep(m, &grpc.GenericServerStream[FileGrepRequest, FileGrepResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Netfs_FileGrepServer = grpc.ServerStreamingServer[FileGrepResponse]
+
+func _Netfs_VolumeInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VolumeInfoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetfsServer).VolumeInfo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Netfs_VolumeInfo_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetfsServer).VolumeInfo(ctx, req.(*VolumeInfoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Netfs_ServiceDesc is the grpc.ServiceDesc for Netfs service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Netfs_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "netfs.transport.Netfs",
+	HandlerType: (*NetfsServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ServerHost",
+			Handler:    _Netfs_ServerHost_Handler,
+		},
+		{
+			MethodName: "ServerStop",
+			Handler:    _Netfs_ServerStop_Handler,
+		},
+		{
+			MethodName: "FileInfo",
+			Handler:    _Netfs_FileInfo_Handler,
+		},
+		{
+			MethodName: "FileCreate",
+			Handler:    _Netfs_FileCreate_Handler,
+		},
+		{
+			MethodName: "FileSignature",
+			Handler:    _Netfs_FileSignature_Handler,
+		},
+		{
+			MethodName: "FileRemove",
+			Handler:    _Netfs_FileRemove_Handler,
+		},
+		{
+			MethodName: "FileRename",
+			Handler:    _Netfs_FileRename_Handler,
+		},
+		{
+			MethodName: "FileCopy",
+			Handler:    _Netfs_FileCopy_Handler,
+		},
+		{
+			MethodName: "FileCopyStart",
+			Handler:    _Netfs_FileCopyStart_Handler,
+		},
+		{
+			MethodName: "FileCopyStatus",
+			Handler:    _Netfs_FileCopyStatus_Handler,
+		},
+		{
+			MethodName: "FileCopyCancel",
+			Handler:    _Netfs_FileCopyCancel_Handler,
+		},
+		{
+			MethodName: "FileCopyPause",
+			Handler:    _Netfs_FileCopyPause_Handler,
+		},
+		{
+			MethodName: "FileCopyResume",
+			Handler:    _Netfs_FileCopyResume_Handler,
+		},
+		{
+			MethodName: "TaskHistory",
+			Handler:    _Netfs_TaskHistory_Handler,
+		},
+		{
+			MethodName: "FileCopyLimit",
+			Handler:    _Netfs_FileCopyLimit_Handler,
+		},
+		{
+			MethodName: "VolumeInfo",
+			Handler:    _Netfs_VolumeInfo_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "FileWrite",
+			Handler:       _Netfs_FileWrite_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "FileRead",
+			Handler:       _Netfs_FileRead_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "FileExtract",
+			Handler:       _Netfs_FileExtract_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "FileDelta",
+			Handler:       _Netfs_FileDelta_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "FileChildren",
+			Handler:       _Netfs_FileChildren_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "FileSearch",
+			Handler:       _Netfs_FileSearch_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "FileGrep",
+			Handler:       _Netfs_FileGrep_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "transport.proto",
+}
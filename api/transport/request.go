@@ -0,0 +1,168 @@
+package transport
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+)
+
+const uint64BitSize = 64
+const decimalBase = 10
+
+type request struct {
+	ip       net.IP
+	endpoint string
+	params   []string
+	rawBody  []byte
+}
+
+func (req *request) IP() net.IP {
+	return req.ip
+}
+
+func (req *request) Endpoint() string {
+	return req.endpoint
+}
+
+func (req *request) Param(name string) string {
+	for index, param := range req.params {
+		if param == name && index < len(req.params)-1 {
+			return req.params[index+1]
+		}
+	}
+	return ""
+}
+
+func (req *request) ParamRequired(name string) (string, error) {
+	param := req.Param(name)
+	if param == "" {
+		return param, ErrRequiredParam
+	}
+	return param, nil
+}
+
+func (req *request) ParamInt(name string) (int, error) {
+	param := 0
+	value, err := req.ParamRequired(name)
+	if err == nil {
+		param, err = strconv.Atoi(value)
+		if err != nil {
+			err = errors.Join(fmt.Errorf("[%s] %w", name, ErrIncorrectParamValue), err)
+		}
+	}
+	return param, err
+}
+
+func (req *request) ParamUInt64(name string) (uint64, error) {
+	param := uint64(0)
+	value, err := req.ParamRequired(name)
+	if err == nil {
+		param, err = strconv.ParseUint(value, decimalBase, uint64BitSize)
+		if err != nil {
+			err = errors.Join(fmt.Errorf("[%s] %w", name, ErrIncorrectParamValue), err)
+		}
+	}
+	return param, err
+}
+
+func (req *request) Params() []string {
+	return req.params
+}
+
+func (req *request) RawBody() []byte {
+	return req.rawBody
+}
+
+func (req *request) Body(target any) (any, error) {
+	return target, json.Unmarshal(req.rawBody, target)
+}
+
+type response struct {
+	ip       net.IP
+	endpoint string
+	rawBody  []byte
+}
+
+func (res *response) IP() net.IP {
+	return res.ip
+}
+
+func (res *response) Endpoint() string {
+	return res.endpoint
+}
+
+func (res *response) RawBody() []byte {
+	return res.rawBody
+}
+
+func (res *response) Body(target any) (any, error) {
+	return target, json.Unmarshal(res.rawBody, target)
+}
+
+type streamRequest struct {
+	request
+	reader io.Reader
+	ctx    context.Context
+}
+
+func (req *streamRequest) Reader() io.Reader {
+	return req.reader
+}
+
+func (req *streamRequest) Context() context.Context {
+	if req.ctx == nil {
+		return context.Background()
+	}
+	return req.ctx
+}
+
+func (req *streamRequest) RawBody() []byte {
+	if req.rawBody == nil && req.reader != nil {
+		req.rawBody, _ = io.ReadAll(req.reader)
+		req.reader = bytes.NewReader(req.rawBody)
+	}
+	return req.rawBody
+}
+
+func (req *streamRequest) Body(target any) (any, error) {
+	return target, json.NewDecoder(req.reader).Decode(target)
+}
+
+type streamResponse struct {
+	ip       net.IP
+	endpoint string
+	body     io.ReadCloser
+	rawBody  []byte
+}
+
+func (res *streamResponse) IP() net.IP {
+	return res.ip
+}
+
+func (res *streamResponse) Endpoint() string {
+	return res.endpoint
+}
+
+func (res *streamResponse) Reader() io.Reader {
+	return res.body
+}
+
+func (res *streamResponse) RawBody() []byte {
+	if res.rawBody == nil {
+		res.rawBody, _ = io.ReadAll(res.body)
+	}
+	return res.rawBody
+}
+
+func (res *streamResponse) Body(target any) (any, error) {
+	return target, json.NewDecoder(res.body).Decode(target)
+}
+
+func (res *streamResponse) Close() error {
+	return res.body.Close()
+}
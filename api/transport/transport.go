@@ -1,7 +1,9 @@
 package transport
 
 import (
+	"context"
 	"errors"
+	"io"
 	"net"
 	"net/http"
 	"strconv"
@@ -22,8 +24,57 @@ var ErrRequiredParam = errors.New("is required")
 // Returns if param is incorrect.
 var ErrIncorrectParamValue = errors.New("has incorrect value")
 
+// Code of the error which has no own code.
+const CodeInternal = "INTERNAL"
+
+// Code of the error of the request parameters.
+const CodeBadRequest = "BAD_REQUEST"
+
 type TransportPoint []string
 
+// Kind of the error, each protocol maps the kind to its own status.
+type ErrorKind uint8
+
+const (
+	KindInternal ErrorKind = iota
+	KindBadRequest
+	KindNotFound
+	KindForbidden
+	KindConflict
+	KindInsufficientStorage
+	KindUnavailable
+)
+
+// The error with a machine-readable code which is transferred between hosts.
+type Error struct {
+	Kind    ErrorKind `json:"-"`
+	Code    string
+	Message string
+}
+
+// Returns the message of the error.
+func (err *Error) Error() string {
+	return err.Message
+}
+
+// Creates a new instance of Error.
+func NewError(kind ErrorKind, code string, message string) *Error {
+	return &Error{Kind: kind, Code: code, Message: message}
+}
+
+// Converts any error to the transferred error.
+// If the error chain contains Error, its kind and code are used, otherwise the error is internal.
+func ToError(err error) *Error {
+	var coded *Error
+	if errors.As(err, &coded) {
+		return &Error{Kind: coded.Kind, Code: coded.Code, Message: err.Error()}
+	}
+	if errors.Is(err, ErrRequiredParam) || errors.Is(err, ErrIncorrectParamValue) {
+		return &Error{Kind: KindBadRequest, Code: CodeBadRequest, Message: err.Error()}
+	}
+	return &Error{Kind: KindInternal, Code: CodeInternal, Message: err.Error()}
+}
+
 // Request data.
 type Request interface {
 	IP() net.IP
@@ -45,20 +96,52 @@ type Response interface {
 	Body(any) (any, error)
 }
 
+// Streaming request data, the body is read from the reader.
+type StreamRequest interface {
+	Request
+	Reader() io.Reader
+	// The context is cancelled if the sender closes the response before its end.
+	Context() context.Context
+}
+
+// Streaming response data, the body is read from the reader and must be closed.
+type StreamResponse interface {
+	Response
+	Reader() io.Reader
+	Close() error
+}
+
+// Connection-oriented data channel, the written data is sent to the receiver by one stream.
+type Channel interface {
+	// Sends data to the receiver.
+	Write([]byte) (int, error)
+	// Finishes the stream and returns the error of the receiver.
+	Close() error
+	// Breaks the stream, the receiver does not get the end of the data.
+	Abort(error)
+}
+
 // Available protocols.
 type TransportProtocol uint16
 
 const (
 	HTTP TransportProtocol = iota
 	CALL
+	GRPC
 )
 
 // Abstraction of the data sender.
 type TransportSender interface {
 	// Creates new request instance by parameters.
 	NewRequest(net.IP, string, []string, []byte, any) (Request, error)
+	// Creates new streaming request instance by parameters.
+	NewStreamRequest(net.IP, string, []string, io.Reader) (StreamRequest, error)
 	// Sends request.
 	Send(Request) (Response, error)
+	// Sends request and returns the response without reading its body.
+	SendStream(Request) (StreamResponse, error)
+	// Opens the data channel to the endpoint, the channels to the same host share one connection.
+	OpenChannel(net.IP, string, []string) (Channel, error)
 	// Returns protocol.
 	Protocol() TransportProtocol
 	// Returns port.
@@ -68,7 +151,27 @@ type TransportSender interface {
 // Creates new instance of TransportSender.
 func NewSender(protocol TransportProtocol, port uint16, timeout time.Duration) (TransportSender, error) {
 	if protocol == HTTP {
-		return &HttpTransportSender{client: &http.Client{Timeout: timeout}, port: port}, nil
+		// The own transport of the sender does not share idle connections with other senders.
+		client := &http.Client{Timeout: timeout, Transport: http.DefaultTransport.(*http.Transport).Clone()}
+
+		// The streaming body may be transferred longer than the timeout, so only waiting for the answer is limited.
+		streamTransport := http.DefaultTransport.(*http.Transport).Clone()
+		streamTransport.DialContext = (&net.Dialer{Timeout: timeout}).DialContext
+		streamTransport.ResponseHeaderTimeout = timeout
+		streamClient := &http.Client{Transport: streamTransport}
+
+		// The channels are HTTP/2 streams multiplexed over one connection, the broken connection is found by pings.
+		channelTransport := &http.Transport{
+			DialContext: (&net.Dialer{Timeout: timeout}).DialContext,
+			Protocols:   &http.Protocols{},
+			HTTP2:       &http.HTTP2Config{SendPingTimeout: timeout, PingTimeout: timeout},
+		}
+		channelTransport.Protocols.SetUnencryptedHTTP2(true)
+		channelClient := &http.Client{Transport: channelTransport}
+
+		return &HttpTransportSender{client: client, streamClient: streamClient, channelClient: channelClient, port: port}, nil
+	} else if protocol == GRPC {
+		return NewGrpcSender(port, timeout, nil), nil
 	}
 	return nil, ErrUnsupportedProtocol
 }
@@ -79,6 +182,8 @@ type TransportReceiver interface {
 	NewRequest(net.IP, string, []string, []byte, any) (Request, error)
 	// Receives request.
 	Receive(string, func(Request) ([]byte, any, error))
+	// Receives request and writes the response body to the writer.
+	ReceiveStream(string, func(StreamRequest, io.Writer) error)
 	// Starts receiver.
 	Start() error
 	// Stops receiver.
@@ -93,9 +198,21 @@ type TransportReceiver interface {
 func NewReceiver(protocol TransportProtocol, port uint16) (TransportReceiver, error) {
 	if protocol == HTTP {
 		mux := http.NewServeMux()
-		server := &http.Server{Addr: portSeparator + strconv.Itoa(int(port)), Handler: mux}
+		server := &http.Server{Addr: portSeparator + strconv.Itoa(int(port)), Handler: mux, Protocols: &http.Protocols{}}
+		server.Protocols.SetHTTP1(true)
+		server.Protocols.SetUnencryptedHTTP2(true)
 
 		return &HttpTransportReceiver{server: server, mux: mux, port: port}, nil
+	} else if protocol == GRPC {
+		return NewGrpcReceiver(port, nil), nil
 	}
 	return nil, ErrUnsupportedProtocol
 }
+
+// Sends the data written to the streaming response immediately.
+// If nothing is written yet, the response is started, so the sender doesn't wait for its first data.
+func Flush(writer io.Writer) {
+	if flusher, ok := writer.(interface{ Flush() }); ok {
+		flusher.Flush()
+	}
+}
@@ -0,0 +1,54 @@
+package api
+
+import (
+	"netfs/api/transport"
+)
+
+// Information about the volume.
+type VolumeInfo struct {
+	Path       string
+	Total      FileSize
+	Free       FileSize
+	Used       FileSize
+	Inodes     uint64
+	InodesFree uint64
+	InodesUsed uint64
+}
+
+// Returns information about volumes of all root directories.
+func (host RemoteHost) Volumes(client transport.TransportSender) ([]VolumeInfo, error) {
+	req, err := client.NewRequest(host.IP, host.Endpoints().VolumeInfo.Name, nil, nil, nil)
+	if err == nil {
+		var res transport.Response
+		if res, err = send(client, req); err == nil {
+			volumes := []VolumeInfo{}
+			if _, err = res.Body(&volumes); err == nil {
+				return volumes, nil
+			}
+		}
+	}
+	return nil, err
+}
+
+// Returns information about the volume which contains the file.
+// The file may not exist, in this case the volume of the nearest existing parent directory is returned.
+func (file *RemoteFile) Volume(client transport.TransportSender) (*VolumeInfo, error) {
+	endpoint := file.Host.Endpoints().VolumeInfo
+	params := []string{
+		endpoint.FileId, string(file.Info.Id),
+	}
+	req, err := client.NewRequest(file.Host.IP, endpoint.Name, params, nil, nil)
+	if err == nil {
+		var res transport.Response
+		if res, err = send(client, req); err == nil {
+			volumes := []VolumeInfo{}
+			if _, err = res.Body(&volumes); err == nil {
+				if len(volumes) == 0 {
+					return nil, transport.ErrUnexpectedAnswer
+				}
+				return &volumes[0], nil
+			}
+		}
+	}
+	return nil, err
+}
@@ -0,0 +1,339 @@
+package api
+
+import (
+	"net"
+	"sort"
+	"sync"
+	"time"
+)
+
+const defaultHeartbeat = 5 * time.Second
+const defaultDiscovery = 30 * time.Second
+const defaultExpiry = 15 * time.Second
+
+// Type of the host event.
+type HostEventType uint8
+
+const (
+	HostAdded HostEventType = iota
+	HostRemoved
+	HostChanged
+	// The network can't be scanned, the hosts table is not changed.
+	HostDiscoveryFailed
+)
+
+// Returns a string representation of the host event type.
+func (eventType HostEventType) String() string {
+	switch eventType {
+	case HostAdded:
+		return "added"
+	case HostRemoved:
+		return "removed"
+	case HostDiscoveryFailed:
+		return "failed"
+	default:
+		return "changed"
+	}
+}
+
+// The event sends after changing the host table.
+type HostEvent struct {
+	Type HostEventType
+	Host RemoteHost
+	// The error of the HostDiscoveryFailed event.
+	Error error
+}
+
+// Information about the host known to the watcher.
+type WatchedHost struct {
+	Host     RemoteHost
+	LastSeen time.Time
+}
+
+// Host watcher configuration.
+type HostWatcherConfig struct {
+	// Interval of checking the known hosts.
+	Heartbeat time.Duration
+	// Interval of scanning the whole network.
+	Discovery time.Duration
+	// The host is removed if it has not been seen for this time.
+	Expiry time.Duration
+}
+
+// The watcher maintains a live table of the hosts in the network.
+type HostWatcher struct {
+	network     *Network
+	config      HostWatcherConfig
+	lock        sync.Mutex
+	hosts       map[string]*WatchedHost
+	subscribers []*hostSubscriber
+	stop        chan struct{}
+	stopOnce    sync.Once
+}
+
+// Starts watching the network.
+func (watcher *HostWatcher) Start() {
+	go func() {
+		heartbeat := time.NewTicker(watcher.config.Heartbeat)
+		discovery := time.NewTicker(watcher.config.Discovery)
+		defer heartbeat.Stop()
+		defer discovery.Stop()
+
+		watcher.discover()
+		for {
+			select {
+			case <-watcher.stop:
+				return
+			case <-heartbeat.C:
+				watcher.heartbeat()
+			case <-discovery.C:
+				watcher.discover()
+			}
+		}
+	}()
+}
+
+// Stops watching the network and closes all subscriptions.
+func (watcher *HostWatcher) Stop() {
+	watcher.stopOnce.Do(func() {
+		close(watcher.stop)
+
+		watcher.lock.Lock()
+		defer watcher.lock.Unlock()
+		for _, subscriber := range watcher.subscribers {
+			close(subscriber.done)
+		}
+		watcher.subscribers = nil
+	})
+}
+
+// Returns a new channel of host events.
+// The channel receives the HostAdded event for every already known host, the channel is closed if the watcher is stopped.
+// The events are not lost if the subscriber is slow, the events of the host which are not received yet
+// are replaced by its latest event, so the subscriber always receives the latest state of every host.
+func (watcher *HostWatcher) Subscribe() <-chan HostEvent {
+	watcher.lock.Lock()
+	defer watcher.lock.Unlock()
+
+	subscriber := &hostSubscriber{events: make(chan HostEvent), wake: make(chan struct{}, 1), done: make(chan struct{})}
+	select {
+	case <-watcher.stop:
+		close(subscriber.events)
+		return subscriber.events
+	default:
+	}
+
+	for _, host := range watcher.sortedHosts() {
+		subscriber.push(HostEvent{Type: HostAdded, Host: host.Host})
+	}
+	watcher.subscribers = append(watcher.subscribers, subscriber)
+	go subscriber.run()
+	return subscriber.events
+}
+
+// Closes the channel of host events.
+func (watcher *HostWatcher) Unsubscribe(events <-chan HostEvent) {
+	watcher.lock.Lock()
+	defer watcher.lock.Unlock()
+
+	for index, subscriber := range watcher.subscribers {
+		if subscriber.events == events {
+			close(subscriber.done)
+			watcher.subscribers = append(watcher.subscribers[:index], watcher.subscribers[index+1:]...)
+			break
+		}
+	}
+}
+
+// Returns the known hosts sorted by name.
+func (watcher *HostWatcher) Hosts() []WatchedHost {
+	watcher.lock.Lock()
+	defer watcher.lock.Unlock()
+
+	return watcher.sortedHosts()
+}
+
+func (watcher *HostWatcher) sortedHosts() []WatchedHost {
+	hosts := make([]WatchedHost, 0, len(watcher.hosts))
+	for _, host := range watcher.hosts {
+		hosts = append(hosts, *host)
+	}
+	sort.Slice(hosts, func(i, j int) bool {
+		if hosts[i].Host.Name == hosts[j].Host.Name {
+			return hosts[i].Host.IP.String() < hosts[j].Host.IP.String()
+		}
+		return hosts[i].Host.Name < hosts[j].Host.Name
+	})
+	return hosts
+}
+
+// Scans the whole network.
+func (watcher *HostWatcher) discover() {
+	hosts, err := watcher.network.Hosts()
+	if err != nil {
+		watcher.lock.Lock()
+		watcher.publish(HostEvent{Type: HostDiscoveryFailed, Error: err})
+		watcher.lock.Unlock()
+	}
+	for _, host := range hosts {
+		watcher.seen(host)
+	}
+	watcher.expire()
+}
+
+// Checks the known hosts.
+func (watcher *HostWatcher) heartbeat() {
+	watcher.lock.Lock()
+	ips := make([]net.IP, 0, len(watcher.hosts))
+	for _, host := range watcher.hosts {
+		ips = append(ips, host.Host.IP)
+	}
+	watcher.lock.Unlock()
+
+	callback := make(chan *RemoteHost)
+	for _, ip := range ips {
+		go func(ip net.IP, callback chan *RemoteHost) {
+			host, _ := watcher.network.Host(ip)
+			callback <- host
+		}(ip, callback)
+	}
+
+	for range ips {
+		if host := <-callback; host != nil {
+			watcher.seen(*host)
+		}
+	}
+	watcher.expire()
+}
+
+// Updates the last seen time of the host.
+func (watcher *HostWatcher) seen(host RemoteHost) {
+	watcher.lock.Lock()
+	defer watcher.lock.Unlock()
+
+	key := host.IP.String()
+	if known, ok := watcher.hosts[key]; ok {
+		changed := known.Host.Name != host.Name || known.Host.Version() != host.Version()
+		known.Host = host
+		known.LastSeen = time.Now()
+		if changed {
+			watcher.publish(HostEvent{Type: HostChanged, Host: host})
+		}
+	} else {
+		watcher.hosts[key] = &WatchedHost{Host: host, LastSeen: time.Now()}
+		watcher.publish(HostEvent{Type: HostAdded, Host: host})
+	}
+}
+
+// Removes the hosts which have not been seen for a long time.
+func (watcher *HostWatcher) expire() {
+	watcher.lock.Lock()
+	defer watcher.lock.Unlock()
+
+	now := time.Now()
+	for key, host := range watcher.hosts {
+		if now.Sub(host.LastSeen) > watcher.config.Expiry {
+			delete(watcher.hosts, key)
+			watcher.publish(HostEvent{Type: HostRemoved, Host: host.Host})
+		}
+	}
+}
+
+// Sends the event to all subscribers.
+func (watcher *HostWatcher) publish(event HostEvent) {
+	for _, subscriber := range watcher.subscribers {
+		subscriber.push(event)
+	}
+}
+
+// The subscriber delivers the pending events to its channel one by one.
+type hostSubscriber struct {
+	events  chan HostEvent
+	wake    chan struct{}
+	done    chan struct{}
+	lock    sync.Mutex
+	pending []HostEvent
+}
+
+// Adds the event to the pending events, the pending event of the same host is replaced.
+// The host which is added and changed before receiving is still added for the subscriber.
+func (subscriber *hostSubscriber) push(event HostEvent) {
+	subscriber.lock.Lock()
+	defer subscriber.lock.Unlock()
+
+	replaced := false
+	for index, pending := range subscriber.pending {
+		if pending.Type == HostDiscoveryFailed && event.Type == HostDiscoveryFailed ||
+			pending.Type != HostDiscoveryFailed && event.Type != HostDiscoveryFailed && pending.Host.IP.Equal(event.Host.IP) {
+			if pending.Type == HostAdded && event.Type == HostChanged {
+				event.Type = HostAdded
+			}
+			subscriber.pending[index] = event
+			replaced = true
+			break
+		}
+	}
+	if !replaced {
+		subscriber.pending = append(subscriber.pending, event)
+	}
+
+	select {
+	case subscriber.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Returns the first pending event.
+func (subscriber *hostSubscriber) next() (HostEvent, bool) {
+	subscriber.lock.Lock()
+	defer subscriber.lock.Unlock()
+
+	if len(subscriber.pending) == 0 {
+		return HostEvent{}, false
+	}
+	event := subscriber.pending[0]
+	subscriber.pending = subscriber.pending[1:]
+	return event, true
+}
+
+// Sends the pending events until the subscription is closed.
+func (subscriber *hostSubscriber) run() {
+	defer close(subscriber.events)
+	for {
+		event, ok := subscriber.next()
+		if !ok {
+			select {
+			case <-subscriber.wake:
+				continue
+			case <-subscriber.done:
+				return
+			}
+		}
+
+		select {
+		case subscriber.events <- event:
+		case <-subscriber.done:
+			return
+		}
+	}
+}
+
+// Creates a new instance of HostWatcher, the empty fields of the configuration are replaced by default values.
+func NewHostWatcher(network *Network, config HostWatcherConfig) *HostWatcher {
+	if config.Heartbeat <= 0 {
+		config.Heartbeat = defaultHeartbeat
+	}
+	if config.Discovery <= 0 {
+		config.Discovery = defaultDiscovery
+	}
+	if config.Expiry <= 0 {
+		config.Expiry = defaultExpiry
+	}
+
+	return &HostWatcher{
+		network: network,
+		config:  config,
+		hosts:   map[string]*WatchedHost{},
+		stop:    make(chan struct{}),
+	}
+}
@@ -1,6 +1,17 @@
 module netfs/server
 
 require netfs/api v0.0.0-00010101000000-000000000000
+
+require (
+	github.com/klauspost/compress v1.17.11 // indirect
+	golang.org/x/net v0.48.0 // indirect
+	golang.org/x/sys v0.39.0 // indirect
+	golang.org/x/text v0.32.0 // indirect
+	google.golang.org/genproto/googleapis/rpc v0.0.0-20251202230838-ff82c1b0f217 // indirect
+	google.golang.org/grpc v1.79.3 // indirect
+	google.golang.org/protobuf v1.36.11 // indirect
+)
+
 replace netfs/api => ../api
 
 go 1.24.9
@@ -0,0 +1,219 @@
+package server
+
+import (
+	"archive/tar"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"netfs/api"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const extractPrefix = ".netfs-extract-"
+
+// The file which is sent by the archive.
+type archiveFile struct {
+	// Path of the source file.
+	path string
+	// Path of the file in the archive relative to the target directory.
+	name string
+}
+
+// The function writes the files to the tar archive.
+// The size of the file is read once, so the file changed during writing does not break the archive.
+func writeArchive(ctx context.Context, writer io.Writer, files []archiveFile) error {
+	archive := tar.NewWriter(writer)
+	for _, file := range files {
+		if err := context.Cause(ctx); err != nil {
+			return err
+		}
+		if err := writeArchiveFile(archive, file); err != nil {
+			return err
+		}
+	}
+	return archive.Close()
+}
+
+func writeArchiveFile(archive *tar.Writer, file archiveFile) error {
+	source, err := os.Open(file.path)
+	if err == nil {
+		var info os.FileInfo
+		if info, err = source.Stat(); err == nil {
+			var header *tar.Header
+			if header, err = tar.FileInfoHeader(info, ""); err == nil {
+				header.Name = filepath.ToSlash(file.name)
+				if err = archive.WriteHeader(header); err == nil {
+					_, err = io.CopyN(archive, source, header.Size)
+				}
+			}
+		}
+		err = errors.Join(err, source.Close())
+	}
+	return err
+}
+
+// The file moved from the temporary directory by the extraction.
+type extractedFile struct {
+	// Path of the file in the directory.
+	path string
+	// Path of the replaced file in the backup directory, empty if the file did not exist.
+	backup string
+	// Directories created for the file, the deepest directory is the first.
+	dirs []string
+	// True if the file is moved to the directory.
+	moved bool
+}
+
+// The function extracts the tar archive into the directory and returns the count of the extracted files.
+// The archive is extracted into the temporary directory first, so nothing is changed if the archive is broken.
+// Then the files are moved to the directory one by one, the replaced files are kept in the temporary directory.
+// If the file can't be moved, the moved files are removed and the replaced files are restored.
+// If the directory can't be restored, the error tells that the archive is partially extracted
+// and the temporary directory with the replaced files is kept.
+func extractArchive(reader io.Reader, dirPath string) (int, error) {
+	tempPath, err := os.MkdirTemp(dirPath, extractPrefix+"*")
+	if err != nil {
+		return 0, err
+	}
+
+	var names []string
+	if names, err = extractArchiveTemp(reader, tempPath); err == nil {
+		err = moveExtracted(tempPath, dirPath, names)
+	}
+	if !errors.Is(err, errArchivePartiallyExtracted) {
+		os.RemoveAll(tempPath)
+	}
+
+	if err != nil {
+		return 0, err
+	}
+	return len(names), nil
+}
+
+var errArchivePartiallyExtracted = errors.New("archive is partially extracted")
+
+// The function extracts the tar archive into the temporary directory and returns the names of the extracted files.
+func extractArchiveTemp(reader io.Reader, tempPath string) ([]string, error) {
+	var err error
+	names := []string{}
+	archive := tar.NewReader(reader)
+	for {
+		var header *tar.Header
+		if header, err = archive.Next(); errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+
+		name := filepath.FromSlash(header.Name)
+		if !filepath.IsLocal(name) || strings.HasPrefix(filepath.Base(name), extractPrefix) {
+			return nil, fmt.Errorf("%w: archive entry [%s] is outside of the directory", api.ErrInvalidArgument, header.Name)
+		}
+
+		path := filepath.Join(tempPath, name)
+		switch header.Typeflag {
+		case tar.TypeDir:
+			err = os.MkdirAll(path, 0777)
+		case tar.TypeReg:
+			if err = os.MkdirAll(filepath.Dir(path), 0777); err == nil {
+				err = extractArchiveFile(archive, path, header.FileInfo().Mode().Perm())
+				names = append(names, name)
+			}
+		default:
+			err = fmt.Errorf("%w: archive entry [%s] has unsupported type", api.ErrInvalidArgument, header.Name)
+		}
+
+		if err != nil {
+			return nil, err
+		}
+	}
+	return names, nil
+}
+
+// The function moves the extracted files from the temporary directory to the directory.
+// If the file can't be moved, the directory is restored.
+func moveExtracted(tempPath string, dirPath string, names []string) error {
+	backupPath := filepath.Join(tempPath, extractPrefix+"backup")
+	files := []extractedFile{}
+	for _, name := range names {
+		file, err := moveExtractedFile(filepath.Join(tempPath, name), filepath.Join(dirPath, name), filepath.Join(backupPath, name))
+		files = append(files, file)
+		if err == nil {
+			continue
+		}
+
+		var restoreErr error
+		for index := len(files) - 1; index >= 0; index-- {
+			restoreErr = errors.Join(restoreErr, files[index].restore())
+		}
+		if restoreErr != nil {
+			return fmt.Errorf("%w into [%s], the replaced files are kept in [%s]: %w",
+				errArchivePartiallyExtracted, dirPath, tempPath, errors.Join(err, restoreErr))
+		}
+		return err
+	}
+	return nil
+}
+
+// The function moves the extracted file to the path, the existing file is moved to the backup path.
+func moveExtractedFile(tempPath string, path string, backupPath string) (extractedFile, error) {
+	file := extractedFile{path: path}
+	for dir := filepath.Dir(path); ; dir = filepath.Dir(dir) {
+		if _, err := os.Lstat(dir); !errors.Is(err, fs.ErrNotExist) {
+			break
+		}
+		file.dirs = append(file.dirs, dir)
+	}
+
+	err := os.MkdirAll(filepath.Dir(path), 0777)
+	if err == nil {
+		var info os.FileInfo
+		if info, err = os.Lstat(path); err == nil {
+			if info.IsDir() {
+				return file, fmt.Errorf("%w: [%s] is a directory", api.ErrFileAlreadyExists, path)
+			}
+			if err = os.MkdirAll(filepath.Dir(backupPath), 0777); err == nil {
+				if err = os.Rename(path, backupPath); err == nil {
+					file.backup = backupPath
+				}
+			}
+		} else if errors.Is(err, fs.ErrNotExist) {
+			err = nil
+		}
+	}
+
+	if err == nil {
+		if err = os.Rename(tempPath, path); err == nil {
+			file.moved = true
+		}
+	}
+	return file, err
+}
+
+// The function removes the moved file and the created directories and restores the replaced file.
+func (file extractedFile) restore() error {
+	var err error
+	if file.moved {
+		err = os.Remove(file.path)
+	}
+	if file.backup != "" && err == nil {
+		err = os.Rename(file.backup, file.path)
+	}
+	for _, dir := range file.dirs {
+		err = errors.Join(err, os.Remove(dir))
+	}
+	return err
+}
+
+func extractArchiveFile(archive *tar.Reader, path string, mode os.FileMode) error {
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, mode|0200)
+	if err == nil {
+		_, err = io.Copy(file, archive)
+		err = errors.Join(err, file.Close())
+	}
+	return err
+}
@@ -0,0 +1,112 @@
+package server
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"netfs/api"
+	"netfs/api/transport"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+const sampleSize = 65536     // 64 KB
+const minCompressSize = 4096 // The smaller files are not compressed.
+const entropyThreshold = 7.5 // Bits per byte, the data with higher entropy is already compressed.
+
+// Extensions of the files which are compressed by their format.
+var compressedExtensions = []string{
+	".7z", ".br", ".bz2", ".gz", ".lz4", ".rar", ".tgz", ".xz", ".zip", ".zst",
+	".jar", ".apk", ".docx", ".xlsx", ".pptx", ".odt",
+	".jpg", ".jpeg", ".png", ".gif", ".webp", ".heic",
+	".mp3", ".aac", ".ogg", ".flac", ".mp4", ".mkv", ".avi", ".mov", ".webm",
+}
+
+// Returns the reader of the request data which is decompressed by the codec of the parameter.
+// The returned reader must be closed.
+func decompressedReader(req transport.StreamRequest, codecParam string) (io.ReadCloser, error) {
+	reader, err := transport.Decompress(req.Reader(), transport.Codec(req.Param(codecParam)))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", api.ErrInvalidArgument, err)
+	}
+	return reader, nil
+}
+
+// Returns the compression of the task, if the task does not set it, the default of the host is used.
+func (sch *CopyScheduler) compression(task *api.RemoteCopyTask) api.CompressionMode {
+	if task.Compression != api.CompressionDefault {
+		return task.Compression
+	}
+	return sch.config.Compression
+}
+
+// Returns the codec of the file sent to the target host.
+// The file is not compressed if the target host does not support codecs or the file looks already compressed.
+func (sch *CopyScheduler) codec(task *api.RemoteCopyTask, target *api.RemoteFile, file *os.File, size int64) transport.Codec {
+	codec := target.Host.Codec()
+	if codec == transport.CodecNone || !target.Host.Supports(api.FeatureChannel) {
+		return transport.CodecNone
+	}
+
+	switch sch.compression(task) {
+	case api.CompressionNone:
+		return transport.CodecNone
+	case api.CompressionAlways:
+		return codec
+	}
+
+	if size >= minCompressSize && compressibleType(file.Name()) && compressibleData(file) {
+		return codec
+	}
+	return transport.CodecNone
+}
+
+// Returns the codec of the archive of the small files.
+// The archive is compressed automatically if it contains at least one file of compressible type.
+func (sch *CopyScheduler) archiveCodec(task *api.RemoteCopyTask, files []archiveFile) transport.Codec {
+	codec := task.Target.Host.Codec()
+	switch sch.compression(task) {
+	case api.CompressionNone:
+		return transport.CodecNone
+	case api.CompressionAlways:
+		return codec
+	}
+
+	for _, file := range files {
+		if compressibleType(file.path) {
+			return codec
+		}
+	}
+	return transport.CodecNone
+}
+
+// Returns true if the file format is not compressed.
+func compressibleType(path string) bool {
+	return !slices.Contains(compressedExtensions, strings.ToLower(filepath.Ext(path)))
+}
+
+// Returns true if the sample of the file has low entropy, so the file is worth compressing.
+func compressibleData(file *os.File) bool {
+	sample := make([]byte, sampleSize)
+	read, _ := file.ReadAt(sample, 0)
+	return read > 0 && entropy(sample[:read]) < entropyThreshold
+}
+
+// Returns Shannon entropy of the data in bits per byte.
+func entropy(data []byte) float64 {
+	counts := [256]int{}
+	for _, value := range data {
+		counts[value]++
+	}
+
+	result := 0.0
+	for _, count := range counts {
+		if count > 0 {
+			probability := float64(count) / float64(len(data))
+			result -= probability * math.Log2(probability)
+		}
+	}
+	return result
+}
@@ -0,0 +1,116 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"io"
+	"netfs/api"
+	"netfs/api/transport"
+	"os"
+	"path/filepath"
+)
+
+const deltaPrefix = ".netfs-delta-"
+
+// The function builds the new file from the blocks of the file and the delta, then replaces the file by the new one.
+// The new file is written next to the file, so the file is not changed if the delta is broken.
+func applyDelta(reader io.Reader, path string, blockSize int) (int64, error) {
+	written := int64(0)
+
+	base, err := os.Open(path)
+	if err != nil {
+		return written, err
+	}
+	defer base.Close()
+
+	var info os.FileInfo
+	if info, err = base.Stat(); err == nil {
+		var temp *os.File
+		if temp, err = os.CreateTemp(filepath.Dir(path), deltaPrefix+"*"); err == nil {
+			written, err = api.ApplyDelta(base, blockSize, reader, temp)
+			err = errors.Join(err, temp.Close())
+			if err == nil {
+				if err = os.Chmod(temp.Name(), info.Mode().Perm()); err == nil {
+					err = os.Rename(temp.Name(), path)
+				}
+			}
+
+			if err != nil {
+				os.Remove(temp.Name())
+			}
+		}
+	}
+	return written, err
+}
+
+// Returns signatures of the existing target file if the task updates the file by the delta.
+// If the target file does not exist or the host can't return signatures, nil is returned and the file is copied fully.
+func (sch *CopyScheduler) signature(task *api.RemoteCopyTask, client transport.TransportSender, target *api.RemoteFile, size int64) *api.FileSignature {
+	if !task.Delta || !target.Host.Supports(api.FeatureDelta) {
+		return nil
+	}
+
+	signature, err := target.Signature(client, api.DeltaBlockSize(size))
+	if err != nil {
+		sch.log.Warn("CopyFile()", "taskId", task.Id, "delta", false, "error", err)
+		return nil
+	}
+	return signature
+}
+
+// Sends the delta of the file to the target file, only the blocks which the target file does not contain are sent.
+// The target file is replaced when the whole delta is applied, so the cancelled copying keeps the target file unchanged.
+func (sch *CopyScheduler) copyDelta(ctx context.Context, task *api.RemoteCopyTask, file *os.File, size int64, client transport.TransportSender, target *api.RemoteFile, codec transport.Codec, signature *api.FileSignature) error {
+	sch.log.Info("CopyFile()", "taskId", task.Id, "delta", true, "blocks", len(signature.Blocks), "blockSize", signature.BlockSize)
+
+	reader, writer := io.Pipe()
+	compressor, err := transport.NewCompressWriter(sch.limitWriter(ctx, task, writer, nil), codec)
+	if err != nil {
+		return err
+	}
+
+	go func() {
+		source := io.NewSectionReader(file, 0, size)
+		err := api.WriteDelta(&contextReader{ctx: ctx, reader: source}, signature, compressor, func(progress api.DeltaProgress) {
+			sch.update(task, func() {
+				task.Progress = int(min(float64(progress.Read)/float64(max(size, 1))*100.0, 100.0))
+				task.Stats.Saved = progress.Matched
+			})
+		})
+		writer.CloseWithError(errors.Join(err, compressor.Close()))
+	}()
+
+	err = target.WriteDelta(client, reader, signature.BlockSize, codec)
+	reader.Close()
+
+	if cause := context.Cause(ctx); cause != nil {
+		if !errors.Is(cause, errCopyCancelled) {
+			return cause
+		}
+		sch.update(task, func() { task.Status = api.Cancelled })
+		sch.log.Info("CopyFile()", "taskId", task.Id, "cancelled", true)
+		return nil
+	}
+
+	if err == nil {
+		sch.addStats(task, api.TaskStats{Codec: codec, Bytes: compressor.Bytes(), Sent: compressor.Sent()})
+		sch.update(task, func() {
+			task.Progress = 100.0
+			task.Status = api.Completed
+		})
+	}
+	return err
+}
+
+// The reader stops reading when the context is done.
+type contextReader struct {
+	ctx    context.Context
+	reader io.Reader
+}
+
+func (reader *contextReader) Read(data []byte) (int, error) {
+	if err := context.Cause(reader.ctx); err != nil {
+		return 0, err
+	}
+	return reader.reader.Read(data)
+}
@@ -0,0 +1,188 @@
+package server
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/fs"
+	"netfs/api"
+	"netfs/api/transport"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const defaultGrepMaxSize = api.FileSize(10 * 1024 * 1024)
+
+// The size of the beginning of the file which is checked for the binary data.
+const grepProbeSize = 8 * 1024
+
+// Maximum length of the line in the response, the longer lines are cut.
+const grepLineWidth = 1024
+
+// The conditions of the content search received by the request.
+type grepFilter struct {
+	query  api.GrepQuery
+	regexp *regexp.Regexp
+}
+
+// The function reads the conditions of the content search from the request,
+// the limits of the request can't exceed the limits of the server.
+func readGrepFilter(req transport.StreamRequest, config SearchConfig) (*grepFilter, error) {
+	endpoint := api.Endpoints.FileGrep
+	filter := &grepFilter{query: api.GrepQuery{Include: req.Param(endpoint.Include), MaxSize: config.MaxFileSize, Limit: config.Limit}}
+
+	pattern, err := req.ParamRequired(endpoint.Pattern)
+	filter.query.Pattern = pattern
+	for _, param := range []struct {
+		name   string
+		target *bool
+	}{{endpoint.Regexp, &filter.query.Regexp}, {endpoint.IgnoreCase, &filter.query.IgnoreCase}} {
+		if err == nil && req.Param(param.name) != "" {
+			if *param.target, err = strconv.ParseBool(req.Param(param.name)); err != nil {
+				err = fmt.Errorf("%w: [%s] is not boolean", api.ErrInvalidArgument, param.name)
+			}
+		}
+	}
+
+	if err == nil {
+		if !filter.query.Regexp {
+			pattern = regexp.QuoteMeta(pattern)
+		}
+		if filter.query.IgnoreCase {
+			pattern = "(?i)" + pattern
+		}
+		if filter.regexp, err = regexp.Compile(pattern); err != nil {
+			err = fmt.Errorf("%w: pattern [%s] is incorrect: %w", api.ErrInvalidArgument, filter.query.Pattern, err)
+		}
+	}
+	if err == nil {
+		if _, err = filepath.Match(filter.query.Include, ""); err != nil {
+			err = fmt.Errorf("%w: include [%s] is incorrect", api.ErrInvalidArgument, filter.query.Include)
+		}
+	}
+	if err == nil && req.Param(endpoint.MaxSize) != "" {
+		var size uint64
+		if size, err = req.ParamUInt64(endpoint.MaxSize); err == nil && size > 0 {
+			filter.query.MaxSize = min(api.FileSize(size), config.MaxFileSize)
+		}
+	}
+	if err == nil && req.Param(endpoint.Limit) != "" {
+		var requested int
+		if requested, err = req.ParamInt(endpoint.Limit); err == nil && requested > 0 {
+			filter.query.Limit = min(requested, config.Limit)
+		}
+	}
+
+	if err != nil {
+		return nil, err
+	}
+	return filter, nil
+}
+
+// The function walks the directories and calls found for each line which contains the searched text.
+// The binary, too large and unreadable files are skipped.
+// The walking stops after the limit of the filter is reached or the context is cancelled, the count of the found lines is returned.
+func grepFiles(ctx context.Context, roots []string, filter *grepFilter, found func(api.GrepMatch) error) (int, error) {
+	count := 0
+	for _, root := range roots {
+		err := filepath.WalkDir(root, func(path string, entry fs.DirEntry, err error) error {
+			if cause := context.Cause(ctx); cause != nil {
+				return cause
+			}
+
+			if err != nil {
+				// The root must exist, other files may be removed or closed for reading during walking.
+				if path == root {
+					return err
+				}
+				return nil
+			}
+
+			// The symbolic links and the special files are skipped too.
+			if !entry.Type().IsRegular() {
+				return nil
+			}
+			if filter.query.Include != "" {
+				if matched, _ := filepath.Match(filter.query.Include, entry.Name()); !matched {
+					return nil
+				}
+			}
+
+			var osInfo fs.FileInfo
+			if osInfo, err = entry.Info(); err != nil || api.FileSize(osInfo.Size()) > filter.query.MaxSize {
+				return nil
+			}
+
+			info := api.FileInfo{
+				Id:       api.FileId(path),
+				Name:     osInfo.Name(),
+				Path:     path,
+				Type:     api.FILE,
+				Size:     api.FileSize(osInfo.Size()),
+				ParentId: api.FileId(filepath.Dir(path)),
+				ModTime:  osInfo.ModTime(),
+			}
+			return grepFile(info, filter, func(match api.GrepMatch) error {
+				err := found(match)
+				if err == nil {
+					count++
+					if count >= filter.query.Limit {
+						return fs.SkipAll
+					}
+				}
+				return err
+			})
+		})
+
+		if err != nil || count >= filter.query.Limit {
+			return count, err
+		}
+	}
+	return count, nil
+}
+
+// The function reads the file by lines and calls found for each line which contains the searched text.
+// The file is skipped if its beginning contains the zero byte, like grep does.
+// The file which can't be read is skipped too, so only the errors of found are returned.
+func grepFile(info api.FileInfo, filter *grepFilter, found func(api.GrepMatch) error) error {
+	file, err := os.Open(info.Path)
+	if err != nil {
+		return nil
+	}
+	defer file.Close()
+
+	reader := bufio.NewReaderSize(file, grepProbeSize)
+	probe, err := reader.Peek(grepProbeSize)
+	if (err != nil && err != io.EOF) || bytes.IndexByte(probe, 0) >= 0 {
+		return nil
+	}
+
+	// The line can't be longer than the file.
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, 0, grepProbeSize), max(int(info.Size)+1, grepProbeSize))
+	line := 0
+	for scanner.Scan() {
+		line++
+		if text := scanner.Bytes(); filter.regexp.Match(text) {
+			if err = found(api.GrepMatch{File: info, Line: line, Text: grepLine(text)}); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// The function returns the line without the carriage return, the too long line is cut.
+func grepLine(text []byte) string {
+	text = bytes.TrimSuffix(text, []byte{'\r'})
+	if len(text) > grepLineWidth {
+		text = text[:grepLineWidth]
+	}
+	// The cut line may end with the part of the character.
+	return strings.ToValidUTF8(string(text), "")
+}
@@ -0,0 +1,302 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"netfs/api"
+	"sync"
+	"time"
+)
+
+const limitChunkSize = 262144 // 256 KB
+const minLimitChunkSize = 1024
+
+// The netfs bandwidth configuration, the rates are in bytes per second and zero means no limit.
+type LimitConfig struct {
+	// Maximum rate of the data sent by all tasks of the server.
+	Rate int64
+	// Maximum rate of the data sent to the host, the key is the IP or the name of the host.
+	Hosts map[string]int64
+}
+
+// The token bucket which limits the rate of the sent data.
+// The bucket may be shared by several writers, the writer which takes more tokens than available waits for them.
+type rateLimiter struct {
+	lock   sync.Mutex
+	rate   int64
+	tokens float64
+	last   time.Time
+	// The channel is closed when the paused writers may continue, nil if the bucket isn't paused.
+	resumed chan struct{}
+}
+
+func newRateLimiter(rate int64) *rateLimiter {
+	return &rateLimiter{rate: rate, tokens: float64(rate), last: time.Now()}
+}
+
+// Changes the rate of the bucket, zero means no limit.
+func (limiter *rateLimiter) setRate(rate int64) {
+	limiter.lock.Lock()
+	defer limiter.lock.Unlock()
+
+	limiter.rate = rate
+	limiter.tokens = min(limiter.tokens, float64(rate))
+}
+
+// Stops the writers of the bucket until the bucket is resumed.
+func (limiter *rateLimiter) pause() {
+	limiter.lock.Lock()
+	defer limiter.lock.Unlock()
+
+	if limiter.resumed == nil {
+		limiter.resumed = make(chan struct{})
+	}
+}
+
+// Continues the writers of the paused bucket.
+func (limiter *rateLimiter) resume() {
+	limiter.lock.Lock()
+	defer limiter.lock.Unlock()
+
+	if limiter.resumed != nil {
+		close(limiter.resumed)
+		limiter.resumed = nil
+	}
+}
+
+// Returns the channel which is closed after resuming of the bucket or nil if the bucket isn't paused.
+func (limiter *rateLimiter) paused() chan struct{} {
+	limiter.lock.Lock()
+	defer limiter.lock.Unlock()
+
+	return limiter.resumed
+}
+
+// Returns size of the data which is sent at once, so the writer waits at most a tenth of a second after each chunk.
+func (limiter *rateLimiter) chunkSize() int {
+	limiter.lock.Lock()
+	defer limiter.lock.Unlock()
+
+	if limiter.rate <= 0 {
+		return limitChunkSize
+	}
+	return int(min(max(limiter.rate/10, minLimitChunkSize), limitChunkSize))
+}
+
+// Takes the tokens and returns the time after which the tokens are available.
+// The bucket holds the tokens of one second, so the idle writer can't send a large burst.
+func (limiter *rateLimiter) reserve(count int64) time.Duration {
+	limiter.lock.Lock()
+	defer limiter.lock.Unlock()
+
+	now := time.Now()
+	elapsed := now.Sub(limiter.last).Seconds()
+	limiter.last = now
+	if limiter.rate <= 0 {
+		return 0
+	}
+
+	limiter.tokens = min(limiter.tokens+elapsed*float64(limiter.rate), float64(limiter.rate)) - float64(count)
+	if limiter.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-limiter.tokens / float64(limiter.rate) * float64(time.Second))
+}
+
+// Waits until all limiters allow sending of the data, the paused limiters block the sending until they are resumed.
+// The waiting is interrupted when the context is done, the caller checks the context itself.
+func waitLimits(ctx context.Context, limiters []*rateLimiter, count int64) {
+	waitResumed(ctx, limiters)
+	waitRate(ctx, limiters, count)
+}
+
+// Waits until all paused limiters are resumed or the context is done.
+func waitResumed(ctx context.Context, limiters []*rateLimiter) {
+	for _, limiter := range limiters {
+		if resumed := limiter.paused(); resumed != nil {
+			select {
+			case <-resumed:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}
+}
+
+// Waits until the rates of all limiters allow sending of the data or the context is done.
+func waitRate(ctx context.Context, limiters []*rateLimiter, count int64) {
+	delay := time.Duration(0)
+	for _, limiter := range limiters {
+		delay = max(delay, limiter.reserve(count))
+	}
+
+	if delay > 0 {
+		timer := time.NewTimer(delay)
+		defer timer.Stop()
+
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+		}
+	}
+}
+
+// Returns true if any limiter is paused.
+func isPaused(limiters []*rateLimiter) bool {
+	for _, limiter := range limiters {
+		if limiter.paused() != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// The error of the writer which stops writing of the data when the task is paused.
+var errTaskPaused = errors.New("task is paused")
+
+// The writer sends data by small chunks and waits for the limiters after each chunk.
+// If the sent function is set, it returns the size of the data actually sent, for example after compression.
+// If yield is set, the writer does not wait for the paused limiters, it returns errTaskPaused with the count of the written bytes,
+// so the caller returns its buffer to the pool and waits for resuming by waitResumed.
+type limitedWriter struct {
+	ctx      context.Context
+	writer   io.Writer
+	limiters []*rateLimiter
+	sent     func() int64
+	last     int64
+	yield    bool
+}
+
+func (writer *limitedWriter) Write(data []byte) (int, error) {
+	chunkSize := limitChunkSize
+	for _, limiter := range writer.limiters {
+		chunkSize = min(chunkSize, limiter.chunkSize())
+	}
+
+	written := 0
+	for written < len(data) {
+		count, err := writer.writer.Write(data[written:min(len(data), written+chunkSize)])
+		written += count
+		if err != nil {
+			return written, err
+		}
+
+		sent := int64(count)
+		if writer.sent != nil {
+			total := writer.sent()
+			sent = total - writer.last
+			writer.last = total
+		}
+
+		if !writer.yield {
+			waitLimits(writer.ctx, writer.limiters, sent)
+		} else if waitRate(writer.ctx, writer.limiters, sent); written < len(data) && isPaused(writer.limiters) {
+			return written, errTaskPaused
+		}
+	}
+	return written, nil
+}
+
+// Waits until the paused task of the writer is resumed or the context is done.
+func (writer *limitedWriter) waitResumed() {
+	waitResumed(writer.ctx, writer.limiters)
+}
+
+// Returns the writer which limits the rate of the data of the task by the server, host and task limits.
+func (sch *CopyScheduler) limitWriter(ctx context.Context, task *api.RemoteCopyTask, writer io.Writer, sent func() int64) io.Writer {
+	return &limitedWriter{ctx: ctx, writer: writer, limiters: sch.limiters(task), sent: sent}
+}
+
+// Returns the limited writer of the data from the pooled buffers, the writer stops writing when the task is paused,
+// so the paused task does not hold the buffers which are shared by all tasks.
+func (sch *CopyScheduler) limitBufferWriter(ctx context.Context, task *api.RemoteCopyTask, writer io.Writer, sent func() int64) *limitedWriter {
+	return &limitedWriter{ctx: ctx, writer: writer, limiters: sch.limiters(task), sent: sent, yield: true}
+}
+
+// Returns the limiters of the task, the host limiter is shared by all tasks which send data to the host.
+func (sch *CopyScheduler) limiters(task *api.RemoteCopyTask) []*rateLimiter {
+	sch.lock.Lock()
+	defer sch.lock.Unlock()
+
+	limiters := []*rateLimiter{sch.limiter}
+	host := task.Target.Host
+	for _, key := range []string{host.IP.String(), host.Name} {
+		if rate, ok := sch.limits.Hosts[key]; ok {
+			if _, exists := sch.hostLimiters[key]; !exists {
+				sch.hostLimiters[key] = newRateLimiter(rate)
+			}
+			limiters = append(limiters, sch.hostLimiters[key])
+			break
+		}
+	}
+
+	if limiter, ok := sch.taskLimiters[task.Id]; ok {
+		limiters = append(limiters, limiter)
+	}
+	return limiters
+}
+
+// Changes the rate limit of the running task, zero means no limit.
+func (sch *CopyScheduler) SetLimit(taskId api.TaskId, limit int64) error {
+	if limit < 0 {
+		return fmt.Errorf("%w: limit [%d] is negative", api.ErrInvalidArgument, limit)
+	}
+
+	sch.lock.Lock()
+	defer sch.lock.Unlock()
+
+	limiter, ok := sch.taskLimiters[taskId]
+	if !ok {
+		return fmt.Errorf("%w: %s", api.ErrTaskNotFound, taskId)
+	}
+
+	limiter.setRate(limit)
+	for _, task := range sch.tasks {
+		if task != nil && task.Id == taskId {
+			task.Limit = limit
+		}
+	}
+	return nil
+}
+
+// Pauses the running task, its writers wait before sending the next chunk of data.
+func (sch *CopyScheduler) PauseTask(taskId api.TaskId) error {
+	sch.lock.Lock()
+	defer sch.lock.Unlock()
+
+	limiter, ok := sch.taskLimiters[taskId]
+	if !ok {
+		return fmt.Errorf("%w: %s", api.ErrTaskNotFound, taskId)
+	}
+
+	limiter.pause()
+	for _, task := range sch.tasks {
+		if task != nil && task.Id == taskId && !task.Paused {
+			task.Paused = true
+			sch.clocks[taskId].pause()
+		}
+	}
+	return nil
+}
+
+// Resumes the paused task.
+func (sch *CopyScheduler) ResumeTask(taskId api.TaskId) error {
+	sch.lock.Lock()
+	defer sch.lock.Unlock()
+
+	limiter, ok := sch.taskLimiters[taskId]
+	if !ok {
+		return fmt.Errorf("%w: %s", api.ErrTaskNotFound, taskId)
+	}
+
+	limiter.resume()
+	for _, task := range sch.tasks {
+		if task != nil && task.Id == taskId && task.Paused {
+			task.Paused = false
+			sch.clocks[taskId].resume()
+		}
+	}
+	return nil
+}
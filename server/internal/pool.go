@@ -0,0 +1,45 @@
+package server
+
+import (
+	"context"
+)
+
+// The pool of the copy buffers shared by all tasks of the server.
+// The count of the buffers is limited by the memory limit, so the writer waits for a free buffer when the limit is reached.
+type bufferPool struct {
+	size  int
+	free  chan []byte
+	slots chan struct{}
+}
+
+// Creates new pool of the buffers of the size, the pool holds at least one buffer.
+func newBufferPool(size int, limit int64) *bufferPool {
+	count := max(int(limit/int64(size)), 1)
+	return &bufferPool{size: size, free: make(chan []byte, count), slots: make(chan struct{}, count)}
+}
+
+// Returns the free buffer, if all buffers are taken, the function waits until a buffer is returned or the context is done.
+// The buffer must be returned by put.
+func (pool *bufferPool) get(ctx context.Context) ([]byte, error) {
+	select {
+	case pool.slots <- struct{}{}:
+	case <-ctx.Done():
+		return nil, context.Cause(ctx)
+	}
+
+	select {
+	case buffer := <-pool.free:
+		return buffer, nil
+	default:
+		return make([]byte, pool.size), nil
+	}
+}
+
+// Returns the buffer to the pool.
+func (pool *bufferPool) put(buffer []byte) {
+	select {
+	case pool.free <- buffer[:pool.size]:
+	default:
+	}
+	<-pool.slots
+}
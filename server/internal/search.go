@@ -0,0 +1,175 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"netfs/api"
+	"netfs/api/transport"
+	"path/filepath"
+	"regexp"
+	"time"
+)
+
+const defaultSearchLimit = 1000
+
+// The netfs search configuration.
+type SearchConfig struct {
+	// Maximum count of the files or the lines found by one search.
+	Limit int
+	// The larger files are skipped by the content search.
+	MaxFileSize api.FileSize
+}
+
+// The conditions of the search received by the request.
+type searchFilter struct {
+	query  api.SearchQuery
+	regexp *regexp.Regexp
+}
+
+// The function reads the conditions of the search from the request, the limit of the request can't exceed the limit of the server.
+func readSearchFilter(req transport.StreamRequest, limit int) (*searchFilter, error) {
+	var err error
+	endpoint := api.Endpoints.FileSearch
+	filter := &searchFilter{query: api.SearchQuery{Pattern: req.Param(endpoint.Pattern), Regexp: req.Param(endpoint.Regexp), Limit: limit}}
+
+	if _, err = filepath.Match(filter.query.Pattern, ""); err != nil {
+		err = fmt.Errorf("%w: pattern [%s] is incorrect", api.ErrInvalidArgument, filter.query.Pattern)
+	}
+	if err == nil && filter.query.Regexp != "" {
+		if filter.regexp, err = regexp.Compile(filter.query.Regexp); err != nil {
+			err = fmt.Errorf("%w: regexp [%s] is incorrect: %w", api.ErrInvalidArgument, filter.query.Regexp, err)
+		}
+	}
+	if err == nil && req.Param(endpoint.Type) != "" {
+		switch req.Param(endpoint.Type) {
+		case api.FILE.String():
+			filter.query.Type = api.FILE
+		case api.DIRECTORY.String():
+			filter.query.Type = api.DIRECTORY
+		default:
+			err = fmt.Errorf("%w: type [%s] is incorrect", api.ErrInvalidArgument, req.Param(endpoint.Type))
+		}
+	}
+	for _, param := range []struct {
+		name   string
+		target *api.FileSize
+	}{{endpoint.MinSize, &filter.query.MinSize}, {endpoint.MaxSize, &filter.query.MaxSize}} {
+		if err == nil && req.Param(param.name) != "" {
+			var size uint64
+			size, err = req.ParamUInt64(param.name)
+			*param.target = api.FileSize(size)
+		}
+	}
+	for _, param := range []struct {
+		name   string
+		target *time.Time
+	}{{endpoint.After, &filter.query.After}, {endpoint.Before, &filter.query.Before}} {
+		if err == nil && req.Param(param.name) != "" {
+			if *param.target, err = time.Parse(time.RFC3339Nano, req.Param(param.name)); err != nil {
+				err = fmt.Errorf("%w: [%s] is not RFC 3339 time: %w", api.ErrInvalidArgument, param.name, err)
+			}
+		}
+	}
+	if err == nil && req.Param(endpoint.Limit) != "" {
+		var requested int
+		if requested, err = req.ParamInt(endpoint.Limit); err == nil && requested > 0 {
+			filter.query.Limit = min(requested, limit)
+		}
+	}
+
+	if err != nil {
+		return nil, err
+	}
+	return filter, nil
+}
+
+// The function returns true if the name of the file matches the conditions.
+// The name is checked before reading information about the file, so the most of the files are skipped without it.
+func (filter *searchFilter) matchName(name string) bool {
+	if filter.query.Pattern != "" {
+		if matched, _ := filepath.Match(filter.query.Pattern, name); !matched {
+			return false
+		}
+	}
+	return filter.regexp == nil || filter.regexp.MatchString(name)
+}
+
+// The function returns true if the file matches the conditions of its type, size and modification time.
+func (filter *searchFilter) matchInfo(info api.FileInfo) bool {
+	query := filter.query
+	switch {
+	case query.Type != 0 && query.Type != info.Type:
+		return false
+	case query.MinSize > 0 && info.Size < query.MinSize:
+		return false
+	case query.MaxSize > 0 && info.Size > query.MaxSize:
+		return false
+	case !query.After.IsZero() && info.ModTime.Before(query.After):
+		return false
+	case !query.Before.IsZero() && !info.ModTime.Before(query.Before):
+		return false
+	}
+	return true
+}
+
+// The function walks the directories and calls found for each file which matches the filter.
+// The roots are checked too if checkRoots is true, the unreadable directories are skipped.
+// The walking stops after the limit of the filter is reached or the context is cancelled, the count of the found files is returned.
+func searchFiles(ctx context.Context, roots []string, checkRoots bool, filter *searchFilter, found func(api.FileInfo) error) (int, error) {
+	count := 0
+	for _, root := range roots {
+		err := filepath.WalkDir(root, func(path string, entry fs.DirEntry, err error) error {
+			if cause := context.Cause(ctx); cause != nil {
+				return cause
+			}
+
+			if err != nil {
+				// The root must exist, other files may be removed or closed for reading during walking.
+				if path == root {
+					return err
+				}
+				return nil
+			}
+
+			if (path == root && !checkRoots) || !filter.matchName(entry.Name()) {
+				return nil
+			}
+
+			var osInfo fs.FileInfo
+			if osInfo, err = entry.Info(); err != nil {
+				return nil
+			}
+
+			fileType := api.FILE
+			if osInfo.IsDir() {
+				fileType = api.DIRECTORY
+			}
+			info := api.FileInfo{
+				Id:       api.FileId(path),
+				Name:     osInfo.Name(),
+				Path:     path,
+				Type:     fileType,
+				Size:     api.FileSize(osInfo.Size()),
+				ParentId: api.FileId(filepath.Dir(path)),
+				ModTime:  osInfo.ModTime(),
+			}
+			if !filter.matchInfo(info) {
+				return nil
+			}
+
+			if err = found(info); err == nil {
+				count++
+				if count >= filter.query.Limit {
+					return fs.SkipAll
+				}
+			}
+			return err
+		})
+
+		if err != nil || count >= filter.query.Limit {
+			return count, err
+		}
+	}
+	return count, nil
+}
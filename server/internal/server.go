@@ -1,19 +1,24 @@
 package server
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"io"
 	"io/fs"
 	"log/slog"
+	"math"
 	"netfs/api"
 	"netfs/api/transport"
 	"os"
 	"os/signal"
 	"path/filepath"
+	"runtime"
 	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"syscall"
 	"time"
 )
@@ -23,32 +28,84 @@ const defaultRoot = "./"
 const defaultPort = 8989
 const defaultTimeout = 2 * time.Second
 const defaultProtocol = transport.HTTP
+const defaultCopyStreams = 4
+const defaultCopyWorkers = 4
+const defaultRangeSize = 16777216     // 16 MB
+const defaultBatchThreshold = 1048576 // 1 MB
+const defaultBatchSize = 16777216     // 16 MB
+const batchMaxFiles = 1000
+const defaultChunkSize = 10485760    // 10 MB
+const defaultMemoryLimit = 268435456 // 256 MB
+const decimalBase = 10
+
+const childrenBatchSize = 256
+const arrayStart = "["
+const arraySeparator = ","
+const arrayEnd = "]"
 
 const DefaultConfigPath = "./netfs_config.json"
 
-var ErrFileAlreadyExists = errors.New("file already exists")
-var ErrTooManyActiveTasks = errors.New("too many active tasks")
+// The version of the netfs server.
+const Version = "0.1.0"
+
 var ErrConfigIsEmpty = errors.New("configuration file is empty")
 
+// The data channel of the cancelled copying is aborted by this error.
+var errCopyCancelled = errors.New("copying is cancelled")
+
 // The netfs logging configuration.
 type ServerLogConfig struct {
 	Level slog.Level
 }
 
+// The netfs copying configuration.
+type CopyConfig struct {
+	// Count of the concurrent streams of one file.
+	Streams int
+	// The file is split to the ranges which are not smaller than this size.
+	RangeSize api.FileSize
+	// Count of the files of the directory which are copied concurrently.
+	Workers int
+	// The files of the directory smaller than this size are sent by archives, a negative value disables archives.
+	BatchThreshold api.FileSize
+	// Maximum size of the files of one archive.
+	BatchSize api.FileSize
+	// Compression of the data of the tasks which do not set it.
+	Compression api.CompressionMode
+	// Size of the data which is read from the file and sent at once.
+	ChunkSize api.FileSize
+	// Maximum memory of the copy buffers of all tasks, the tasks wait for a free buffer when the limit is reached.
+	MemoryLimit api.FileSize
+}
+
 // The netfs server configuration.
 type ServerConfig struct {
 	Path     string `json:"-"`
 	Log      ServerLogConfig
 	Network  api.NetworkConfig
+	Copy     CopyConfig
+	Limit    LimitConfig
+	Search   SearchConfig
 	RootList []string
 }
 
 // The function creates the default configuration.
 func NewServerConfig() *ServerConfig {
 	return &ServerConfig{
-		Path:     DefaultConfigPath,
-		Log:      ServerLogConfig{Level: slog.LevelInfo},
-		Network:  api.NetworkConfig{Port: defaultPort, Protocol: defaultProtocol, Timeout: defaultTimeout},
+		Path:    DefaultConfigPath,
+		Log:     ServerLogConfig{Level: slog.LevelInfo},
+		Network: api.NetworkConfig{Port: defaultPort, Protocol: defaultProtocol, Timeout: defaultTimeout},
+		Copy: CopyConfig{
+			Streams:        defaultCopyStreams,
+			RangeSize:      defaultRangeSize,
+			Workers:        defaultCopyWorkers,
+			BatchThreshold: defaultBatchThreshold,
+			BatchSize:      defaultBatchSize,
+			Compression:    api.CompressionAuto,
+			ChunkSize:      defaultChunkSize,
+			MemoryLimit:    defaultMemoryLimit,
+		},
+		Search:   SearchConfig{Limit: defaultSearchLimit, MaxFileSize: defaultGrepMaxSize},
 		RootList: []string{defaultRoot},
 	}
 }
@@ -86,26 +143,43 @@ type Server struct {
 	log           *slog.Logger
 	network       *api.Network
 	receiver      transport.TransportReceiver
+	searchConfig  SearchConfig
 	stop          chan os.Signal
+	started       time.Time
 }
 
 // Starts the netfs server.
 func (srv *Server) Start() error {
-	srv.receiver.Receive(api.Endpoints.ServerStop, srv.StopServerHandle)
-	srv.receiver.Receive(api.Endpoints.ServerHost, srv.ServerHostHandle)
-	srv.receiver.Receive(api.Endpoints.FileInfo.Name, srv.FileInfoHandle)
-	srv.receiver.Receive(api.Endpoints.FileChildren.Name, srv.FileChildrenHandle)
-	srv.receiver.Receive(api.Endpoints.FileCreate.Name, srv.FileCreateHandle)
-	srv.receiver.Receive(api.Endpoints.FileWrite.Name, srv.FileWriteHandle)
-	srv.receiver.Receive(api.Endpoints.FileRemove.Name, srv.FileRemoveHandle)
-	srv.receiver.Receive(api.Endpoints.FileCopyStart, srv.FileCopyStartHandle)
-	srv.receiver.Receive(api.Endpoints.FileCopy, srv.FileCopyHandle)
-	// srv.receiver.Receive(api.Endpoints.FileCopyStop.Name, srv.FileCopyCancelHandle)
+	// The older clients use the first version of the API.
+	for _, endpoints := range []api.EndpointList{api.Endpoints, api.EndpointsV2} {
+		srv.receiver.Receive(endpoints.ServerStop, srv.StopServerHandle)
+		srv.receiver.Receive(endpoints.ServerHost, srv.ServerHostHandle)
+		srv.receiver.Receive(endpoints.FileInfo.Name, srv.FileInfoHandle)
+		srv.receiver.ReceiveStream(endpoints.FileChildren.Name, srv.FileChildrenHandle)
+		srv.receiver.ReceiveStream(endpoints.FileSearch.Name, srv.FileSearchHandle)
+		srv.receiver.ReceiveStream(endpoints.FileGrep.Name, srv.FileGrepHandle)
+		srv.receiver.Receive(endpoints.FileCreate.Name, srv.FileCreateHandle)
+		srv.receiver.ReceiveStream(endpoints.FileWrite.Name, srv.FileWriteHandle)
+		srv.receiver.ReceiveStream(endpoints.FileRead.Name, srv.FileReadHandle)
+		srv.receiver.ReceiveStream(endpoints.FileExtract.Name, srv.FileExtractHandle)
+		srv.receiver.Receive(endpoints.FileSignature.Name, srv.FileSignatureHandle)
+		srv.receiver.ReceiveStream(endpoints.FileDelta.Name, srv.FileDeltaHandle)
+		srv.receiver.Receive(endpoints.FileRemove.Name, srv.FileRemoveHandle)
+		srv.receiver.Receive(endpoints.FileRename.Name, srv.FileRenameHandle)
+		srv.receiver.Receive(endpoints.FileCopyStart, srv.FileCopyStartHandle)
+		srv.receiver.Receive(endpoints.FileCopy, srv.FileCopyHandle)
+		srv.receiver.Receive(endpoints.VolumeInfo.Name, srv.VolumeInfoHandle)
+		srv.receiver.Receive(endpoints.FileCopyStatus.Name, srv.FileCopyStatusHandle)
+		srv.receiver.Receive(endpoints.FileCopyCancel.Name, srv.FileCopyCancelHandle)
+		srv.receiver.Receive(endpoints.FileCopyPause.Name, srv.FileCopyPauseHandle)
+		srv.receiver.Receive(endpoints.FileCopyResume.Name, srv.FileCopyResumeHandle)
+		srv.receiver.Receive(endpoints.TaskHistory, srv.FileCopyHistoryHandle)
+		srv.receiver.Receive(endpoints.FileCopyLimit.Name, srv.FileCopyLimitHandle)
+	}
 
 	err := srv.receiver.Start()
 	if err == nil {
 		defer srv.receiver.Stop()
-
 		<-srv.stop // Stop signal waiting.
 	}
 	return err
@@ -115,7 +189,7 @@ func (srv *Server) Start() error {
 func (srv *Server) Stop() error {
 	srv.stop <- syscall.SIGINT
 	close(srv.stop)
-	close(srv.copyScheduler.cancel) // TODO. cancel all active tasks
+	srv.copyScheduler.CancelAll()
 	return nil
 }
 
@@ -145,26 +219,70 @@ func NewServer(config *ServerConfig) (*Server, error) {
 						Type:     fileType,
 						Size:     api.FileSize(osInfo.Size()),
 						ParentId: api.FileId(rootDirectory),
+						ModTime:  osInfo.ModTime(),
 					}
 				} else {
 					break
 				}
 			}
 
+			copyConfig := config.Copy
+			if copyConfig.Streams <= 0 {
+				copyConfig.Streams = defaultCopyStreams
+			}
+			if copyConfig.RangeSize <= 0 {
+				copyConfig.RangeSize = defaultRangeSize
+			}
+			if copyConfig.Workers <= 0 {
+				copyConfig.Workers = defaultCopyWorkers
+			}
+			if copyConfig.BatchThreshold == 0 {
+				copyConfig.BatchThreshold = defaultBatchThreshold
+			}
+			if copyConfig.BatchSize <= 0 {
+				copyConfig.BatchSize = defaultBatchSize
+			}
+			if copyConfig.Compression == api.CompressionDefault {
+				copyConfig.Compression = api.CompressionAuto
+			}
+			if copyConfig.ChunkSize <= 0 {
+				copyConfig.ChunkSize = defaultChunkSize
+			}
+			if copyConfig.MemoryLimit <= 0 {
+				copyConfig.MemoryLimit = defaultMemoryLimit
+			}
+
+			searchConfig := config.Search
+			if searchConfig.Limit <= 0 {
+				searchConfig.Limit = defaultSearchLimit
+			}
+			if searchConfig.MaxFileSize <= 0 {
+				searchConfig.MaxFileSize = defaultGrepMaxSize
+			}
+
 			if err == nil {
 				return &Server{
 					log: log,
 					copyScheduler: &CopyScheduler{
-						log:     log,
-						lock:    sync.Mutex{},
-						tasks:   make([]*api.RemoteCopyTask, 100),
-						network: network,
-						cancel:  make(chan api.TaskId),
+						log:          log,
+						lock:         sync.Mutex{},
+						tasks:        make([]*api.RemoteCopyTask, 100),
+						cancels:      map[api.TaskId]context.CancelCauseFunc{},
+						network:      network,
+						config:       copyConfig,
+						limits:       config.Limit,
+						limiter:      newRateLimiter(config.Limit.Rate),
+						hostLimiters: map[string]*rateLimiter{},
+						taskLimiters: map[api.TaskId]*rateLimiter{},
+						clocks:       map[api.TaskId]*taskClock{},
+						buffers:      newBufferPool(int(copyConfig.ChunkSize), int64(copyConfig.MemoryLimit)),
 					},
-					network:  network,
-					receiver: receiver,
-					rootList: rootList,
-					stop:     stop,
+					network:      network,
+					receiver:     receiver,
+					searchConfig: searchConfig,
+					rootList:     rootList,
+					stop:         stop,
+					started:      time.Now(),
 				}, nil
 			}
 		}
@@ -172,6 +290,19 @@ func NewServer(config *ServerConfig) (*Server, error) {
 	return nil, err
 }
 
+// The function converts the error of the file system to the typed netfs error.
+func fileError(err error) error {
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+		return fmt.Errorf("%w: %w", api.ErrFileNotFound, err)
+	case errors.Is(err, fs.ErrPermission):
+		return fmt.Errorf("%w: %w", api.ErrAccessDenied, err)
+	case errors.Is(err, fs.ErrExist):
+		return fmt.Errorf("%w: %w", api.ErrFileAlreadyExists, err)
+	}
+	return err
+}
+
 // Stops the server by request from current host.
 func (srv *Server) StopServerHandle(req transport.Request) ([]byte, any, error) { // TODO. Check current host.
 	return nil, nil, srv.Stop()
@@ -179,7 +310,34 @@ func (srv *Server) StopServerHandle(req transport.Request) ([]byte, any, error)
 
 // Returns information about the current host.
 func (srv *Server) ServerHostHandle(req transport.Request) ([]byte, any, error) {
-	return nil, srv.network.LocalHost(), nil
+	host := srv.network.LocalHost()
+	host.Capabilities = srv.capabilities()
+	return nil, host, nil
+}
+
+// The function returns capabilities of the current host.
+func (srv *Server) capabilities() *api.HostCapabilities {
+	roots := make([]api.RootSpace, 0, len(srv.rootList))
+	for _, root := range srv.rootList {
+		if stats, err := volumeStat(root.Path); err == nil {
+			roots = append(roots, api.RootSpace{Path: root.Path, Total: api.FileSize(stats.Total), Free: api.FileSize(stats.Free)})
+		} else {
+			srv.log.Error("capabilities()", "root", root.Path, "error", err)
+		}
+	}
+
+	return &api.HostCapabilities{
+		Version:       Version,
+		ApiVersion:    api.ApiVersion,
+		MinApiVersion: api.MinApiVersion,
+		OS:            runtime.GOOS,
+		Arch:          runtime.GOARCH,
+		Protocols:     []transport.TransportProtocol{srv.receiver.Protocol()},
+		Features:      []api.HostFeature{api.FeatureCopy, api.FeatureVolume, api.FeatureRead, api.FeatureChannel, api.FeatureRangeWrite, api.FeatureArchive, api.FeatureDelta, api.FeatureMove, api.FeatureSearch, api.FeatureGrep, api.FeatureRename, api.FeatureTaskControl},
+		Codecs:        transport.Codecs,
+		Roots:         roots,
+		Uptime:        time.Since(srv.started),
+	}
 }
 
 // The function handles request and returns information about the file.
@@ -204,69 +362,212 @@ func (srv *Server) FileInfoHandle(req transport.Request) ([]byte, any, error) {
 				Type:     fileType,
 				Size:     api.FileSize(osInfo.Size()),
 				ParentId: api.FileId(filepath.Dir(fileId)),
+				ModTime:  osInfo.ModTime(),
 			}
 		}
 	}
 
 	if err != nil {
 		srv.log.Error("FileInfoHandle()", "error", err)
-		return nil, nil, err
+		return nil, nil, fileError(err)
 	} else {
 		srv.log.Info("FileInfoHandle()", "info", *info)
 		return nil, info, nil
 	}
 }
 
-// The function handles request and returns children of the directory.
-func (srv *Server) FileChildrenHandle(req transport.Request) ([]byte, any, error) {
-	var children []api.FileInfo
+// The function handles request and writes children of the directory.
+// The entries are read and written in batches, so a large directory is not held in memory.
+func (srv *Server) FileChildrenHandle(req transport.StreamRequest, writer io.Writer) error {
+	count := 0
 
 	fileId, err := req.ParamRequired(api.Endpoints.FileChildren.FileId)
 	if err == nil {
 		srv.log.Info("FileChildrenHandle()", "fileId", fileId)
 
 		if fileId == rootDirectory {
-			children = srv.rootList
+			count = len(srv.rootList)
+			err = json.NewEncoder(writer).Encode(srv.rootList)
 		} else {
-			var entries []fs.DirEntry
-			if entries, err = os.ReadDir(fileId); err == nil {
-				children = make([]api.FileInfo, len(entries))
-
-				for index, entry := range entries {
-					var osInfo fs.FileInfo
-					if osInfo, err = entry.Info(); err == nil {
-						fileType := api.FILE
-						if osInfo.IsDir() {
-							fileType = api.DIRECTORY
-						}
-
-						name := osInfo.Name()
-						path := filepath.Join(fileId, name)
-						children[index] = api.FileInfo{
-							Id:       api.FileId(path),
-							Name:     name,
-							Path:     path,
-							Type:     fileType,
-							Size:     api.FileSize(osInfo.Size()),
-							ParentId: api.FileId(fileId),
-						}
-					} else {
-						break
-					}
-				}
+			var dir *os.File
+			if dir, err = os.Open(fileId); err == nil {
+				count, err = writeChildren(writer, dir, fileId)
+				err = errors.Join(err, dir.Close())
 			}
 		}
 	}
 
 	if err != nil {
 		srv.log.Error("FileChildrenHandle()", "error", err)
-		return nil, nil, err
+		return fileError(err)
+	} else {
+		srv.log.Info("FileChildrenHandle()", "fileId", fileId, "count", count)
+		return nil
+	}
+}
+
+// The function handles request and writes the files of the directory and its subdirectories which match the query.
+// Every found file is written as JSON object by its own line as soon as it is found.
+func (srv *Server) FileSearchHandle(req transport.StreamRequest, writer io.Writer) error {
+	var filter *searchFilter
+	count := 0
+
+	fileId, err := req.ParamRequired(api.Endpoints.FileSearch.FileId)
+	if err == nil {
+		filter, err = readSearchFilter(req, srv.searchConfig.Limit)
+	}
+
+	if err == nil {
+		srv.log.Info("FileSearchHandle()", "fileId", fileId, "query", filter.query)
+
+		// The search of the root directory walks all shared directories.
+		roots := []string{fileId}
+		if fileId == rootDirectory {
+			roots = make([]string, len(srv.rootList))
+			for index, root := range srv.rootList {
+				roots[index] = root.Path
+			}
+		} else {
+			_, err = os.Stat(fileId)
+		}
+
+		if err == nil {
+			// The response is started before walking, so the sender doesn't wait for the first file.
+			transport.Flush(writer)
+
+			encoder := json.NewEncoder(writer)
+			count, err = searchFiles(req.Context(), roots, fileId == rootDirectory, filter, func(info api.FileInfo) error {
+				err := encoder.Encode(info)
+				if err == nil {
+					transport.Flush(writer)
+				}
+				return err
+			})
+		}
+	}
+
+	if errors.Is(err, context.Canceled) {
+		srv.log.Info("FileSearchHandle()", "fileId", fileId, "count", count, "cancelled", true)
+		return err
+	} else if err != nil {
+		srv.log.Error("FileSearchHandle()", "error", err)
+		return fileError(err)
 	} else {
-		srv.log.Info("FileChildrenHandle()", "children", children)
-		return nil, children, nil
+		srv.log.Info("FileSearchHandle()", "fileId", fileId, "count", count)
+		return nil
 	}
 }
 
+// The function handles request and searches the text in the files of the directory and all its subdirectories.
+// The found lines are written as JSON objects separated by new lines while the files are read.
+func (srv *Server) FileGrepHandle(req transport.StreamRequest, writer io.Writer) error {
+	var filter *grepFilter
+	count := 0
+
+	fileId, err := req.ParamRequired(api.Endpoints.FileGrep.FileId)
+	if err == nil {
+		filter, err = readGrepFilter(req, srv.searchConfig)
+	}
+
+	if err == nil {
+		srv.log.Info("FileGrepHandle()", "fileId", fileId, "query", filter.query)
+
+		// The search of the root directory reads all shared directories.
+		roots := []string{fileId}
+		if fileId == rootDirectory {
+			roots = make([]string, len(srv.rootList))
+			for index, root := range srv.rootList {
+				roots[index] = root.Path
+			}
+		} else {
+			_, err = os.Stat(fileId)
+		}
+
+		if err == nil {
+			// The response is started before reading, so the sender doesn't wait for the first line.
+			transport.Flush(writer)
+
+			encoder := json.NewEncoder(writer)
+			count, err = grepFiles(req.Context(), roots, filter, func(match api.GrepMatch) error {
+				err := encoder.Encode(match)
+				if err == nil {
+					transport.Flush(writer)
+				}
+				return err
+			})
+		}
+	}
+
+	if errors.Is(err, context.Canceled) {
+		srv.log.Info("FileGrepHandle()", "fileId", fileId, "count", count, "cancelled", true)
+		return err
+	} else if err != nil {
+		srv.log.Error("FileGrepHandle()", "error", err)
+		return fileError(err)
+	} else {
+		srv.log.Info("FileGrepHandle()", "fileId", fileId, "count", count)
+		return nil
+	}
+}
+
+// The function writes entries of the directory as JSON array and returns the count of the entries.
+func writeChildren(writer io.Writer, dir *os.File, dirPath string) (int, error) {
+	count := 0
+	encoder := json.NewEncoder(writer)
+	for {
+		entries, err := dir.ReadDir(childrenBatchSize)
+		for _, entry := range entries {
+			var osInfo fs.FileInfo
+			if osInfo, err = entry.Info(); err == nil {
+				fileType := api.FILE
+				if osInfo.IsDir() {
+					fileType = api.DIRECTORY
+				}
+
+				separator := arraySeparator
+				if count == 0 {
+					separator = arrayStart
+				}
+
+				name := osInfo.Name()
+				path := filepath.Join(dirPath, name)
+				if _, err = io.WriteString(writer, separator); err == nil {
+					err = encoder.Encode(api.FileInfo{
+						Id:       api.FileId(path),
+						Name:     name,
+						Path:     path,
+						Type:     fileType,
+						Size:     api.FileSize(osInfo.Size()),
+						ParentId: api.FileId(dirPath),
+						ModTime:  osInfo.ModTime(),
+					})
+					count++
+				}
+			} else if errors.Is(err, fs.ErrNotExist) { // The entry has been removed after reading the directory.
+				err = nil
+			}
+
+			if err != nil {
+				return count, err
+			}
+		}
+
+		if errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return count, err
+		}
+	}
+
+	if count == 0 {
+		if _, err := io.WriteString(writer, arrayStart); err != nil {
+			return count, err
+		}
+	}
+	_, err := io.WriteString(writer, arrayEnd)
+	return count, err
+}
+
 // The function handles request and creates a new file or directory by api.FileInfo.
 func (srv *Server) FileCreateHandle(req transport.Request) ([]byte, any, error) {
 	var err error
@@ -283,10 +584,10 @@ func (srv *Server) FileCreateHandle(req transport.Request) ([]byte, any, error)
 			srv.log.Info("FileCreateHandle()", "file", *info)
 
 			if info.Path == "" || info.Type == 0 {
-				err = errors.New("path and type are required fields")
+				err = fmt.Errorf("%w: path and type are required fields", api.ErrInvalidArgument)
 			} else {
 				if _, exists := os.Stat(info.Path); !replace && !errors.Is(exists, os.ErrNotExist) {
-					err = ErrFileAlreadyExists
+					err = api.ErrFileAlreadyExists
 				} else {
 					if info.Type == api.DIRECTORY {
 						err = os.MkdirAll(info.Path, 0777)
@@ -311,7 +612,7 @@ func (srv *Server) FileCreateHandle(req transport.Request) ([]byte, any, error)
 
 	if err != nil {
 		srv.log.Error("FileCreateHandle()", "error", err)
-		return nil, nil, err
+		return nil, nil, fileError(err)
 	} else {
 		srv.log.Info("FileCreateHandle()", "file", *info)
 
@@ -323,24 +624,158 @@ func (srv *Server) FileCreateHandle(req transport.Request) ([]byte, any, error)
 }
 
 // The function handles request and writes data to a file.
-func (srv *Server) FileWriteHandle(req transport.Request) ([]byte, any, error) {
-	fileId, err := req.ParamRequired(api.Endpoints.FileInfo.FileId)
+// The data is copied from the request to the file without buffering.
+// If the offset is set, the data is written at the offset, otherwise the data is appended.
+func (srv *Server) FileWriteHandle(req transport.StreamRequest, writer io.Writer) error {
+	written := int64(0)
+	endpoint := api.Endpoints.FileWrite
+
+	fileId, err := req.ParamRequired(endpoint.FileId)
+	if err == nil {
+		srv.log.Info("FileWriteHandle()", "fileId", fileId, "offset", req.Param(endpoint.Offset), "codec", req.Param(endpoint.Codec))
+
+		var reader io.ReadCloser
+		if reader, err = decompressedReader(req, endpoint.Codec); err == nil {
+			var file *os.File
+			if req.Param(endpoint.Offset) == "" {
+				if file, err = os.OpenFile(fileId, os.O_APPEND|os.O_WRONLY|os.O_CREATE, 0777); err == nil {
+					written, err = io.Copy(file, reader)
+					err = errors.Join(err, file.Close())
+				}
+			} else {
+				var offset uint64
+				if offset, err = req.ParamUInt64(endpoint.Offset); err == nil {
+					if file, err = os.OpenFile(fileId, os.O_WRONLY|os.O_CREATE, 0777); err == nil {
+						written, err = io.Copy(io.NewOffsetWriter(file, int64(offset)), reader)
+						err = errors.Join(err, file.Close())
+					}
+				}
+			}
+			err = errors.Join(err, reader.Close())
+		}
+	}
+
+	if err != nil {
+		srv.log.Error("FileWriteHandle()", "error", err)
+	} else {
+		srv.log.Info("FileWriteHandle()", "fileId", fileId, "bytes", written)
+	}
+	return fileError(err)
+}
+
+// The function handles request and extracts the tar archive into the directory.
+func (srv *Server) FileExtractHandle(req transport.StreamRequest, writer io.Writer) error {
+	count := 0
+	endpoint := api.Endpoints.FileExtract
+
+	fileId, err := req.ParamRequired(endpoint.FileId)
 	if err == nil {
-		data := req.RawBody()
-		srv.log.Info("FileWriteHandle()", "fileId", "bytes", fileId, len(data))
+		srv.log.Info("FileExtractHandle()", "fileId", fileId, "codec", req.Param(endpoint.Codec))
+
+		var reader io.ReadCloser
+		if reader, err = decompressedReader(req, endpoint.Codec); err == nil {
+			count, err = extractArchive(reader, fileId)
+			err = errors.Join(err, reader.Close())
+		}
+	}
+
+	if err != nil {
+		srv.log.Error("FileExtractHandle()", "error", err)
+	} else {
+		srv.log.Info("FileExtractHandle()", "fileId", fileId, "count", count)
+	}
+	return fileError(err)
+}
+
+// The function handles request and returns signatures of the blocks of the file.
+func (srv *Server) FileSignatureHandle(req transport.Request) ([]byte, any, error) {
+	var signature *api.FileSignature
+	endpoint := api.Endpoints.FileSignature
+
+	fileId, err := req.ParamRequired(endpoint.FileId)
+	if err == nil {
+		var blockSize int
+		if blockSize, err = req.ParamInt(endpoint.BlockSize); err == nil {
+			srv.log.Info("FileSignatureHandle()", "fileId", fileId, "blockSize", blockSize)
+
+			var file *os.File
+			if file, err = os.Open(fileId); err == nil {
+				signature, err = api.NewSignature(file, blockSize)
+				err = errors.Join(err, file.Close())
+			}
+		}
+	}
+
+	if err != nil {
+		srv.log.Error("FileSignatureHandle()", "error", err)
+		return nil, nil, fileError(err)
+	}
+	srv.log.Info("FileSignatureHandle()", "fileId", fileId, "blocks", len(signature.Blocks))
+	return nil, signature, nil
+}
+
+// The function handles request and updates the file by the delta.
+func (srv *Server) FileDeltaHandle(req transport.StreamRequest, writer io.Writer) error {
+	written := int64(0)
+	endpoint := api.Endpoints.FileDelta
+
+	fileId, err := req.ParamRequired(endpoint.FileId)
+	if err == nil {
+		var blockSize int
+		if blockSize, err = req.ParamInt(endpoint.BlockSize); err == nil {
+			srv.log.Info("FileDeltaHandle()", "fileId", fileId, "blockSize", blockSize, "codec", req.Param(endpoint.Codec))
+
+			var reader io.ReadCloser
+			if reader, err = decompressedReader(req, endpoint.Codec); err == nil {
+				written, err = applyDelta(reader, fileId, blockSize)
+				err = errors.Join(err, reader.Close())
+			}
+		}
+	}
+
+	if err != nil {
+		srv.log.Error("FileDeltaHandle()", "error", err)
+	} else {
+		srv.log.Info("FileDeltaHandle()", "fileId", fileId, "bytes", written)
+	}
+	return fileError(err)
+}
+
+// The function handles request and writes the range of a file to the response.
+func (srv *Server) FileReadHandle(req transport.StreamRequest, writer io.Writer) error {
+	var offset, size uint64
+	endpoint := api.Endpoints.FileRead
+
+	fileId, err := req.ParamRequired(endpoint.FileId)
+	if err == nil && req.Param(endpoint.Offset) != "" {
+		offset, err = req.ParamUInt64(endpoint.Offset)
+	}
+	if err == nil && req.Param(endpoint.Size) != "" {
+		size, err = req.ParamUInt64(endpoint.Size)
+	}
+
+	read := int64(0)
+	if err == nil {
+		srv.log.Info("FileReadHandle()", "fileId", fileId, "offset", offset, "size", size)
 
 		var file *os.File
-		file, err = os.OpenFile(fileId, os.O_APPEND|os.O_WRONLY|os.O_CREATE, 0777)
-		if err == nil {
-			file.Write(req.RawBody())
-			err = file.Close()
+		if file, err = os.Open(fileId); err == nil {
+			limit := int64(size)
+			if size == 0 || size > math.MaxInt64-offset {
+				limit = math.MaxInt64 - int64(offset)
+			}
+
+			read, err = io.Copy(writer, io.NewSectionReader(file, int64(offset), limit))
+			err = errors.Join(err, file.Close())
 		}
 	}
 
 	if err != nil {
-		srv.log.Error("FileWriteHandle()", "error", err)
+		srv.log.Error("FileReadHandle()", "error", err)
+	} else {
+		srv.log.Info("FileReadHandle()", "fileId", fileId, "bytes", read)
 	}
-	return nil, nil, err
+	return fileError(err)
 }
 
 // The function handles request and removes the file.
@@ -354,7 +789,57 @@ func (srv *Server) FileRemoveHandle(req transport.Request) ([]byte, any, error)
 	if err != nil {
 		srv.log.Error("FileRemoveHandle()", "error", err)
 	}
-	return nil, nil, err
+	return nil, nil, fileError(err)
+}
+
+// The function handles request and renames the file or directory in its parent directory.
+func (srv *Server) FileRenameHandle(req transport.Request) ([]byte, any, error) {
+	var info *api.FileInfo
+	var name string
+
+	fileId, err := req.ParamRequired(api.Endpoints.FileRename.FileId)
+	if err == nil {
+		name, err = req.ParamRequired(api.Endpoints.FileRename.NewName)
+	}
+	if err == nil {
+		err = api.CheckFileName(name)
+	}
+
+	if err == nil {
+		srv.log.Info("FileRenameHandle()", "fileId", fileId, "name", name)
+
+		path := filepath.Join(filepath.Dir(fileId), name)
+		var source os.FileInfo
+		if source, err = os.Stat(fileId); err == nil {
+			// The name may differ only by case on the case-insensitive file system.
+			if target, exists := os.Stat(path); exists == nil && !os.SameFile(source, target) {
+				err = api.ErrFileAlreadyExists
+			} else if err = os.Rename(fileId, path); err == nil {
+				fileType := api.FILE
+				if source.IsDir() {
+					fileType = api.DIRECTORY
+				}
+
+				info = &api.FileInfo{
+					Id:       api.FileId(path),
+					Name:     name,
+					Path:     path,
+					Type:     fileType,
+					Size:     api.FileSize(source.Size()),
+					ParentId: api.FileId(filepath.Dir(path)),
+					ModTime:  source.ModTime(),
+				}
+			}
+		}
+	}
+
+	if err != nil {
+		srv.log.Error("FileRenameHandle()", "error", err)
+		return nil, nil, fileError(err)
+	} else {
+		srv.log.Info("FileRenameHandle()", "file", *info)
+		return nil, info, nil
+	}
 }
 
 // The function handles request and returns information about all tasks.
@@ -371,17 +856,64 @@ func (srv *Server) FileCopyStartHandle(req transport.Request) ([]byte, any, erro
 
 	_, err := req.Body(task)
 	if err == nil {
+		// The older clients do not send the flag, their copies always replace the target.
+		fields := map[string]json.RawMessage{}
+		if json.Unmarshal(req.RawBody(), &fields) == nil {
+			if _, ok := fields["Replace"]; !ok {
+				task.Replace = true
+			}
+		}
 		srv.log.Info("FileCopyStartHandle()", "task", task)
 
 		target := &task.Target
-		if target.Info.Type == api.FILE {
-			err = target.Remove(srv.network.Transport())
+		if target.Info.Id == "" {
+			target.Info.Id = api.FileId(target.Info.Path)
+		}
+
+		// The file can't replace itself, the replaced target is removed before copying.
+		if target.Host.IP.Equal(task.Source.Host.IP) && filepath.Clean(target.Info.Path) == filepath.Clean(task.Source.Info.Path) {
+			err = fmt.Errorf("%w: source and target are the same file [%s]", api.ErrInvalidArgument, target.Info.Path)
+		}
+
+		if err == nil && !task.Replace {
+			if _, exists := target.Host.File(srv.network.Transport(), target.Info.Id); exists == nil {
+				err = fmt.Errorf("%w: %s", api.ErrFileAlreadyExists, target.Info.Path)
+			}
+		}
+
+		// The capabilities of the target host define how the data is sent.
+		if err == nil && target.Host.Capabilities == nil {
+			if host, hostErr := srv.network.Host(target.Host.IP); hostErr == nil {
+				target.Host = *host
+			}
+		}
+
+		if err == nil {
+			err = srv.checkSpace(task)
+		}
+		// The existing target file is kept if the task updates it by the delta.
+		var existing *api.RemoteFile
+		if err == nil {
+			existing = srv.deltaTarget(task)
+		}
+
+		if existing != nil {
+			target = existing
+		} else {
+			if err == nil && target.Info.Type == api.FILE {
+				err = target.Remove(srv.network.Transport())
+			}
+			if err == nil {
+				target, err = target.Host.Create(srv.network.Transport(), target.Info, true)
+			}
 		}
 
 		if err == nil {
-			if target, err = target.Host.Create(srv.network.Transport(), target.Info, true); err == nil {
-				task.Target = *target
-				err = srv.copyScheduler.StartTask(task)
+			task.Target = *target
+
+			var started api.RemoteCopyTask
+			if started, err = srv.copyScheduler.StartTask(task); err == nil {
+				return nil, started, nil
 			}
 		}
 	}
@@ -392,16 +924,139 @@ func (srv *Server) FileCopyStartHandle(req transport.Request) ([]byte, any, erro
 	return nil, task, err
 }
 
+// The function returns the existing target file of the task which updates the file by the delta.
+func (srv *Server) deltaTarget(task *api.RemoteCopyTask) *api.RemoteFile {
+	target := &task.Target
+	if task.Delta && target.Info.Type == api.FILE && target.Host.Supports(api.FeatureDelta) {
+		if existing, err := target.Host.File(srv.network.Transport(), target.Info.Id); err == nil && existing.Info.Type == api.FILE {
+			return existing
+		}
+	}
+	return nil
+}
+
+// The function checks that the target volume can hold the source of the task, the size of the source is set to the task.
+// The space of the target file which is replaced by the task is counted as free.
+// The check is skipped if the target host does not report information about its volumes.
+func (srv *Server) checkSpace(task *api.RemoteCopyTask) error {
+	size, err := pathSize(task.Source.Info.Path)
+	if err == nil {
+		task.Size = size
+		required := size
+		if task.Replace {
+			if existing, existingErr := task.Target.Host.File(srv.network.Transport(), task.Target.Info.Id); existingErr == nil && existing.Info.Type == api.FILE {
+				required = max(size-int64(existing.Info.Size), 0)
+			}
+		}
+
+		parentId := api.FileId(filepath.Dir(task.Target.Info.Path))
+		parent := &api.RemoteFile{Host: task.Target.Host, Info: api.FileInfo{Id: parentId, Path: string(parentId)}}
+
+		volume, volumeErr := parent.Volume(srv.network.Transport())
+		if volumeErr != nil {
+			srv.log.Warn("checkSpace()", "taskId", task.Id, "skipped", true, "error", volumeErr)
+		} else {
+			srv.log.Info("checkSpace()", "taskId", task.Id, "required", required, "free", volume.Free)
+			if api.FileSize(required) > volume.Free {
+				err = fmt.Errorf("%w: required [%s], available [%s]", api.ErrNotEnoughSpace, api.FileSize(required), volume.Free)
+			}
+		}
+	}
+	return err
+}
+
+// The function handles request and returns information about volumes.
+// If the file is specified, only the volume which contains the file is returned.
+func (srv *Server) VolumeInfoHandle(req transport.Request) ([]byte, any, error) {
+	var err error
+	volumes := []api.VolumeInfo{}
+
+	fileId := req.Param(api.Endpoints.VolumeInfo.FileId)
+	srv.log.Info("VolumeInfoHandle()", "fileId", fileId)
+
+	if fileId != "" && fileId != rootDirectory {
+		var volume *api.VolumeInfo
+		if volume, err = volumeInfo(fileId); err == nil {
+			volumes = append(volumes, *volume)
+		}
+	} else {
+		for _, root := range srv.rootList {
+			var volume *api.VolumeInfo
+			if volume, err = volumeInfo(root.Path); err != nil {
+				break
+			}
+			volumes = append(volumes, *volume)
+		}
+	}
+
+	if err != nil {
+		srv.log.Error("VolumeInfoHandle()", "error", err)
+		return nil, nil, fileError(err)
+	} else {
+		srv.log.Info("VolumeInfoHandle()", "volumes", volumes)
+		return nil, volumes, nil
+	}
+}
+
 // The function handles request and returns status of the task.
-func (srv *Server) FileCopyStatusHandle(req transport.Request) ([]byte, any, error) { // TODO.
-	return nil, nil, nil
+func (srv *Server) FileCopyStatusHandle(req transport.Request) ([]byte, any, error) {
+	var task api.RemoteCopyTask
+
+	taskId, err := req.ParamRequired(api.Endpoints.FileCopyStatus.TaskId)
+	if err == nil {
+		srv.log.Info("FileCopyStatusHandle()", "taskId", taskId)
+		task, err = srv.copyScheduler.Task(api.TaskId(taskId))
+	}
+
+	if err != nil {
+		srv.log.Error("FileCopyStatusHandle()", "error", err)
+		return nil, nil, err
+	}
+	return nil, task, nil
+}
+
+// The function handles request and returns information about all kept tasks, including the completed ones.
+func (srv *Server) FileCopyHistoryHandle(req transport.Request) ([]byte, any, error) {
+	tasks := srv.copyScheduler.History()
+	srv.log.Info("FileCopyHistoryHandle()", "tasks", len(tasks))
+
+	return nil, tasks, nil
+}
+
+// The function handles request and pauses the running task.
+func (srv *Server) FileCopyPauseHandle(req transport.Request) ([]byte, any, error) {
+	taskId, err := req.ParamRequired(api.Endpoints.FileCopyPause.TaskId)
+	if err == nil {
+		srv.log.Info("FileCopyPauseHandle()", "taskId", taskId)
+		err = srv.copyScheduler.PauseTask(api.TaskId(taskId))
+	}
+
+	if err != nil {
+		srv.log.Error("FileCopyPauseHandle()", "error", err)
+	}
+	return nil, nil, err
+}
+
+// The function handles request and resumes the paused task.
+func (srv *Server) FileCopyResumeHandle(req transport.Request) ([]byte, any, error) {
+	taskId, err := req.ParamRequired(api.Endpoints.FileCopyResume.TaskId)
+	if err == nil {
+		srv.log.Info("FileCopyResumeHandle()", "taskId", taskId)
+		err = srv.copyScheduler.ResumeTask(api.TaskId(taskId))
+	}
+
+	if err != nil {
+		srv.log.Error("FileCopyResumeHandle()", "error", err)
+	}
+	return nil, nil, err
 }
 
 // The function handles request and stops the task.
 func (srv *Server) FileCopyCancelHandle(req transport.Request) ([]byte, any, error) {
 	taskId, err := req.ParamRequired(api.Endpoints.FileCopyCancel.TaskId)
 	if err == nil {
-		srv.copyScheduler.CancelTask(api.TaskId(taskId))
+		srv.log.Info("FileCopyCancelHandle()", "taskId", taskId)
+		err = srv.copyScheduler.CancelTask(api.TaskId(taskId))
 	}
 
 	if err != nil {
@@ -410,25 +1065,128 @@ func (srv *Server) FileCopyCancelHandle(req transport.Request) ([]byte, any, err
 	return nil, nil, err
 }
 
+// The function handles request and changes the rate limit of the running task.
+func (srv *Server) FileCopyLimitHandle(req transport.Request) ([]byte, any, error) {
+	endpoint := api.Endpoints.FileCopyLimit
+
+	taskId, err := req.ParamRequired(endpoint.TaskId)
+	if err == nil {
+		var limit uint64
+		if limit, err = req.ParamUInt64(endpoint.Limit); err == nil {
+			srv.log.Info("FileCopyLimitHandle()", "taskId", taskId, "limit", limit)
+			err = srv.copyScheduler.SetLimit(api.TaskId(taskId), int64(limit))
+		}
+	}
+
+	if err != nil {
+		srv.log.Error("FileCopyLimitHandle()", "error", err)
+	}
+	return nil, nil, err
+}
+
 type CopyScheduler struct {
-	log     *slog.Logger
-	lock    sync.Mutex
-	tasks   []*api.RemoteCopyTask
-	network *api.Network
-	cancel  chan api.TaskId
+	log          *slog.Logger
+	lock         sync.Mutex
+	tasks        []*api.RemoteCopyTask
+	cancels      map[api.TaskId]context.CancelCauseFunc
+	lastId       uint64
+	network      *api.Network
+	config       CopyConfig
+	limits       LimitConfig
+	limiter      *rateLimiter
+	hostLimiters map[string]*rateLimiter
+	taskLimiters map[api.TaskId]*rateLimiter
+	clocks       map[api.TaskId]*taskClock
+	buffers      *bufferPool
+}
+
+// The time of the task, the pauses are not counted.
+type taskClock struct {
+	started  time.Time
+	finished time.Time
+	paused   time.Time
+	idle     time.Duration
+}
+
+func (clock *taskClock) pause() {
+	clock.paused = time.Now()
+}
+
+func (clock *taskClock) resume() {
+	if !clock.paused.IsZero() {
+		clock.idle += time.Since(clock.paused)
+		clock.paused = time.Time{}
+	}
+}
+
+func (clock *taskClock) finish() {
+	clock.resume()
+	clock.finished = time.Now()
+}
+
+// Returns the time of the task without pauses.
+func (clock *taskClock) elapsed() time.Duration {
+	end := time.Now()
+	if !clock.finished.IsZero() {
+		end = clock.finished
+	} else if !clock.paused.IsZero() {
+		end = clock.paused
+	}
+	return end.Sub(clock.started) - clock.idle
 }
 
+// Returns the running and failed tasks.
 func (sch *CopyScheduler) Tasks() []api.RemoteCopyTask {
+	sch.lock.Lock()
+	defer sch.lock.Unlock()
+
 	tasks := []api.RemoteCopyTask{}
 	for _, task := range sch.tasks {
 		if task != nil && (task.Status == api.Running || task.Status == api.Failed) {
-			tasks = append(tasks, *task)
+			tasks = append(tasks, sch.snapshot(task))
 		}
 	}
 	return tasks
 }
 
-func (sch *CopyScheduler) StartTask(task *api.RemoteCopyTask) error {
+// Returns all kept tasks, the completed and cancelled tasks are kept until their positions are taken by new tasks.
+func (sch *CopyScheduler) History() []api.RemoteCopyTask {
+	sch.lock.Lock()
+	defer sch.lock.Unlock()
+
+	tasks := []api.RemoteCopyTask{}
+	for _, task := range sch.tasks {
+		if task != nil {
+			tasks = append(tasks, sch.snapshot(task))
+		}
+	}
+	return tasks
+}
+
+// Returns the kept task by its identifier.
+func (sch *CopyScheduler) Task(taskId api.TaskId) (api.RemoteCopyTask, error) {
+	sch.lock.Lock()
+	defer sch.lock.Unlock()
+
+	for _, task := range sch.tasks {
+		if task != nil && task.Id == taskId {
+			return sch.snapshot(task), nil
+		}
+	}
+	return api.RemoteCopyTask{}, fmt.Errorf("%w: %s", api.ErrTaskNotFound, taskId)
+}
+
+// Returns the copy of the task with its elapsed time, the lock must be held.
+func (sch *CopyScheduler) snapshot(task *api.RemoteCopyTask) api.RemoteCopyTask {
+	copied := *task
+	if clock, ok := sch.clocks[task.Id]; ok {
+		copied.Elapsed = clock.elapsed()
+	}
+	return copied
+}
+
+// Starts the task and returns its state at the start.
+func (sch *CopyScheduler) StartTask(task *api.RemoteCopyTask) (api.RemoteCopyTask, error) {
 	sch.lock.Lock()
 	defer sch.lock.Unlock()
 
@@ -447,114 +1205,369 @@ func (sch *CopyScheduler) StartTask(task *api.RemoteCopyTask) error {
 			status := sch.tasks[index].Status
 			if status != api.Running {
 				taskIndex = index
+				delete(sch.clocks, sch.tasks[index].Id)
 				break
 			}
 		}
 	}
 
 	if taskIndex != -1 {
+		sch.lastId++
+		task.Id = api.TaskId(strconv.FormatUint(sch.lastId, decimalBase))
 		sch.tasks[taskIndex] = task
 
+		ctx, cancel := context.WithCancelCause(context.Background())
+		sch.cancels[task.Id] = cancel
+		sch.taskLimiters[task.Id] = newRateLimiter(task.Limit)
+		sch.clocks[task.Id] = &taskClock{started: time.Now()}
+
 		if task.Source.Info.Type == api.FILE {
 			task.Count = 1
 			task.Current = 1
 
-			go sch.copyFile(task, sch.cancel)
+			go func() {
+				defer sch.finishTask(task.Id)
+				sch.copyFile(ctx, task)
+				sch.removeSource(task)
+			}()
 		} else {
-			go sch.copyDirectory(task, sch.cancel)
+			go func() {
+				defer sch.finishTask(task.Id)
+				sch.copyDirectory(ctx, task)
+				sch.removeSource(task)
+			}()
 		}
+		return *task, nil
+	}
+	return *task, api.ErrTooManyActiveTasks
+}
+
+// Removes the source file of the completed task which moves the file.
+func (sch *CopyScheduler) removeSource(task *api.RemoteCopyTask) {
+	completed := false
+	sch.update(task, func() { completed = task.Move && task.Status == api.Completed })
+	if !completed {
+		return
+	}
+
+	if err := os.RemoveAll(task.Source.Info.Path); err != nil {
+		sch.update(task, func() {
+			task.Error = api.ToRemoteError(fileError(err))
+			task.Status = api.Failed
+		})
+		sch.log.Error("RemoveSource()", "taskId", task.Id, "error", err)
+	} else {
+		sch.log.Info("RemoveSource()", "taskId", task.Id, "path", task.Source.Info.Path)
+	}
+}
+
+// Changes the task under the lock, so the running task is read consistently.
+func (sch *CopyScheduler) update(task *api.RemoteCopyTask, change func()) {
+	sch.lock.Lock()
+	defer sch.lock.Unlock()
+
+	change()
+}
+
+// Cancels the running task, all its workers are stopped.
+func (sch *CopyScheduler) CancelTask(taskId api.TaskId) error {
+	sch.lock.Lock()
+	defer sch.lock.Unlock()
+
+	if cancel, ok := sch.cancels[taskId]; ok {
+		cancel(errCopyCancelled)
 		return nil
 	}
-	return ErrTooManyActiveTasks
+	return fmt.Errorf("%w: %s", api.ErrTaskNotFound, taskId)
+}
+
+// Cancels all running tasks.
+func (sch *CopyScheduler) CancelAll() {
+	sch.lock.Lock()
+	defer sch.lock.Unlock()
+
+	for _, cancel := range sch.cancels {
+		cancel(errCopyCancelled)
+	}
+}
+
+// Releases the context of the finished task.
+func (sch *CopyScheduler) finishTask(taskId api.TaskId) {
+	sch.lock.Lock()
+	defer sch.lock.Unlock()
+
+	if cancel, ok := sch.cancels[taskId]; ok {
+		cancel(nil)
+		delete(sch.cancels, taskId)
+	}
+	delete(sch.taskLimiters, taskId)
+
+	if clock, ok := sch.clocks[taskId]; ok {
+		clock.finish()
+	}
+	for _, task := range sch.tasks {
+		if task != nil && task.Id == taskId {
+			task.Paused = false
+		}
+	}
 }
 
-func (sch *CopyScheduler) CancelTask(taskId api.TaskId) {
-	sch.cancel <- taskId
+// The file of the directory which is copied by the worker.
+type copyJob struct {
+	source api.RemoteFile
+	target api.RemoteFile
+	// The small files which are sent by one archive instead of the file.
+	files []archiveFile
 }
 
-func (sch *CopyScheduler) copyDirectory(task *api.RemoteCopyTask, cancel chan api.TaskId) {
+// Copies the directory, the files are copied concurrently by the workers.
+// The directories are created in the walking order, so the directory exists before its files are written.
+// If the target host supports archives, the small files are collected into batches and each batch is sent by one archive.
+func (sch *CopyScheduler) copyDirectory(ctx context.Context, task *api.RemoteCopyTask) {
 	sch.log.Info("CopyDirectory()", "taskId", task.Id, "started", true)
 
+	count := 0
 	source := &task.Source
 	err := filepath.WalkDir(source.Info.Path, func(path string, entry fs.DirEntry, err error) error {
 		if path != source.Info.Path {
-			task.Count++
+			count++
 		}
 		return err
 	})
 
-	sch.log.Info("CopyDirectory()", "taskId", task.Id, "count", task.Count)
-	if err == nil && task.Count > 0 {
-		task.Current = 1
-		task.Status = api.Running
+	sch.log.Info("CopyDirectory()", "taskId", task.Id, "count", count)
+	if err == nil && count > 0 {
+		sch.update(task, func() {
+			task.Count = count
+			task.Current = 0
+			task.Status = api.Running
+		})
 
-		target := &task.Target
-		err = filepath.WalkDir(source.Info.Path, func(path string, entry fs.DirEntry, err error) error {
-			if path != source.Info.Path {
-				sch.log.Info("CopyDirectory()", "taskId", task.Id, "path", path)
-
-				if err == nil && task.Status == api.Running {
-					select {
-					case taskId := <-cancel:
-						if taskId == task.Id {
-							task.Status = api.Cancelled
-							sch.log.Info("CopyDirectory()", "taskId", taskId, "cancelled", true)
-						}
-					default:
-						targetPath := strings.ReplaceAll(path, source.Info.Path, target.Info.Path)
-						sch.log.Info("CopyDirectory()", "taskId", task.Id, "source", path, "target", targetPath)
-
-						if entry.IsDir() {
-							_, err = target.Host.Create(
-								sch.network.Transport(),
-								api.FileInfo{Id: api.FileId(targetPath), Name: entry.Name(), Type: api.DIRECTORY, Path: targetPath, ParentId: api.FileId(filepath.Dir(targetPath))},
-								true,
-							)
+		ctx, stop := context.WithCancelCause(ctx)
+		defer stop(nil)
+
+		// The progress is counted by the completed files and directories.
+		complete := func() {
+			sch.update(task, func() {
+				task.Current++
+				task.Progress = int(float32(task.Current) / float32(task.Count) * 100.0)
+			})
+		}
+
+		jobs := make(chan copyJob)
+		workers := sync.WaitGroup{}
+		for range sch.workers(task) {
+			workers.Add(1)
+			go func() {
+				defer workers.Done()
+				for job := range jobs {
+					if ctx.Err() == nil && job.files != nil {
+						if err := sch.copyArchive(ctx, task, job.files); err != nil {
+							stop(err)
 						} else {
-							err = sch.copyFile(
-								&api.RemoteCopyTask{
-									Id:   task.Id,
-									Host: task.Host,
-									Source: api.RemoteFile{
-										Host: source.Host,
-										Info: api.FileInfo{Id: api.FileId(path), Name: entry.Name(), Type: api.FILE, Path: path, ParentId: api.FileId(filepath.Dir(path))},
-									},
-									Target: api.RemoteFile{
-										Host: target.Host,
-										Info: api.FileInfo{Id: api.FileId(targetPath), Name: entry.Name(), Type: api.FILE, Path: targetPath, ParentId: api.FileId(filepath.Dir(targetPath))},
-									},
-								},
-								cancel,
-							)
+							for range job.files {
+								complete()
+							}
+						}
+					} else if ctx.Err() == nil {
+						fileTask := &api.RemoteCopyTask{Id: task.Id, Host: task.Host, CopyOptions: api.CopyOptions{Streams: task.Streams, Compression: task.Compression, Delta: task.Delta}, Source: job.source, Target: job.target}
+						err := sch.copyFile(ctx, fileTask)
+						sch.addStats(task, fileTask.Stats)
+						if err != nil {
+							stop(err)
+						} else if fileTask.Status == api.Completed {
+							complete()
 						}
 					}
+				}
+			}()
+		}
 
+		target := &task.Target
+		archive := target.Host.Supports(api.FeatureArchive)
+		batch := []archiveFile{}
+		batchSize := api.FileSize(0)
+		send := func(job copyJob) error {
+			select {
+			case jobs <- job:
+				return nil
+			case <-ctx.Done():
+				return filepath.SkipAll
+			}
+		}
+		flush := func() error {
+			if len(batch) == 0 {
+				return nil
+			}
+			job := copyJob{files: batch}
+			batch = []archiveFile{}
+			batchSize = 0
+			return send(job)
+		}
+
+		err = filepath.WalkDir(source.Info.Path, func(path string, entry fs.DirEntry, err error) error {
+			if err == nil && path != source.Info.Path {
+				if ctx.Err() != nil {
+					return filepath.SkipAll
+				}
+
+				targetPath := strings.ReplaceAll(path, source.Info.Path, target.Info.Path)
+				sch.log.Info("CopyDirectory()", "taskId", task.Id, "source", path, "target", targetPath)
+
+				if entry.IsDir() {
+					_, err = target.Host.Create(
+						sch.network.Transport(),
+						api.FileInfo{Id: api.FileId(targetPath), Name: entry.Name(), Type: api.DIRECTORY, Path: targetPath, ParentId: api.FileId(filepath.Dir(targetPath))},
+						true,
+					)
 					if err == nil {
-						if task.Current < task.Count {
-							task.Progress = int(float32(task.Current) / float32(task.Count) * 100.0)
-							task.Current++
-							task.Status = api.Running
-						} else {
-							sch.log.Info("CopyDirectory()", "taskId", task.Id, "completed", true)
-							task.Progress = 100
-							task.Status = api.Completed
+						complete()
+					}
+				} else if size, ok := sch.batched(entry, archive); ok {
+					var name string
+					if name, err = filepath.Rel(target.Info.Path, targetPath); err == nil {
+						batch = append(batch, archiveFile{path: path, name: name})
+						batchSize += size
+						if batchSize >= sch.config.BatchSize || len(batch) >= batchMaxFiles {
+							err = flush()
 						}
 					}
+				} else {
+					job := copyJob{
+						source: api.RemoteFile{
+							Host: source.Host,
+							Info: api.FileInfo{Id: api.FileId(path), Name: entry.Name(), Type: api.FILE, Path: path, ParentId: api.FileId(filepath.Dir(path))},
+						},
+						target: api.RemoteFile{
+							Host: target.Host,
+							Info: api.FileInfo{Id: api.FileId(targetPath), Name: entry.Name(), Type: api.FILE, Path: targetPath, ParentId: api.FileId(filepath.Dir(targetPath))},
+						},
+					}
+
+					err = send(job)
 				}
 			}
 			return err
 		})
+		if err == nil {
+			err = flush()
+		}
+		close(jobs)
+		workers.Wait()
+
+		if err == nil {
+			err = context.Cause(ctx)
+		}
+		if errors.Is(err, errCopyCancelled) {
+			err = nil
+			sch.update(task, func() { task.Status = api.Cancelled })
+			sch.log.Info("CopyDirectory()", "taskId", task.Id, "cancelled", true)
+		} else if err == nil {
+			sch.update(task, func() {
+				task.Progress = 100
+				task.Status = api.Completed
+			})
+			sch.log.Info("CopyDirectory()", "taskId", task.Id, "completed", true)
+		}
 	}
 
 	if err != nil {
-		task.Error = err
-		task.Status = api.Failed
+		sch.update(task, func() {
+			task.Error = api.ToRemoteError(err)
+			task.Status = api.Failed
+		})
 
 		sch.log.Error("CopyDirectory()", "error", err)
 	}
 }
 
-func (sch *CopyScheduler) copyFile(task *api.RemoteCopyTask, cancel chan api.TaskId) error {
+// Returns size of the file if the file is small enough to be sent by the archive.
+func (sch *CopyScheduler) batched(entry fs.DirEntry, archive bool) (api.FileSize, bool) {
+	if archive && entry.Type().IsRegular() {
+		if info, err := entry.Info(); err == nil && api.FileSize(info.Size()) < sch.config.BatchThreshold {
+			return api.FileSize(info.Size()), true
+		}
+	}
+	return 0, false
+}
+
+// Sends the small files to the target directory of the task by one archive.
+// The archive is written to the request while it is sent, so the files are not buffered.
+func (sch *CopyScheduler) copyArchive(ctx context.Context, task *api.RemoteCopyTask, files []archiveFile) error {
+	codec := sch.archiveCodec(task, files)
+	sch.log.Info("CopyArchive()", "taskId", task.Id, "count", len(files), "codec", codec)
+
+	reader, writer := io.Pipe()
+	compressor, err := transport.NewCompressWriter(sch.limitWriter(ctx, task, writer, nil), codec)
+	if err != nil {
+		return err
+	}
+	go func() {
+		err := writeArchive(ctx, compressor, files)
+		writer.CloseWithError(errors.Join(err, compressor.Close()))
+	}()
+
+	err = task.Target.Extract(sch.network.Transport(), reader, codec)
+	reader.Close()
+
+	if err != nil {
+		sch.log.Error("CopyArchive()", "taskId", task.Id, "error", err)
+	} else {
+		sch.addStats(task, api.TaskStats{Codec: codec, Bytes: compressor.Bytes(), Sent: compressor.Sent()})
+	}
+	return err
+}
+
+// Adds the statistics of the sent data to the statistics of the task.
+func (sch *CopyScheduler) addStats(task *api.RemoteCopyTask, stats api.TaskStats) {
+	sch.update(task, func() {
+		task.Stats.Bytes += stats.Bytes
+		task.Stats.Sent += stats.Sent
+		task.Stats.Saved += stats.Saved
+		if stats.Codec != transport.CodecNone {
+			task.Stats.Codec = stats.Codec
+		}
+	})
+}
+
+// Returns count of the workers of the directory task.
+func (sch *CopyScheduler) workers(task *api.RemoteCopyTask) int {
+	if task.Workers > 0 {
+		return task.Workers
+	}
+	return sch.config.Workers
+}
+
+// Opens the writer of the target file.
+// If the host supports the data channel, the data is sent by one stream compressed by the codec,
+// otherwise each chunk is sent by own request without compression.
+func openWriter(client transport.TransportSender, target *api.RemoteFile, codec transport.Codec) (*transport.CompressedChannel, error) {
+	if target.Host.Supports(api.FeatureChannel) {
+		return target.CompressedWriter(client, -1, codec)
+	}
+	return transport.CompressChannel(&chunkWriter{client: client, target: target}, transport.CodecNone)
+}
+
+// The writer sends each chunk of data by own request.
+type chunkWriter struct {
+	client transport.TransportSender
+	target *api.RemoteFile
+}
+
+func (writer *chunkWriter) Write(data []byte) (int, error) {
+	if err := writer.target.Write(writer.client, data); err != nil {
+		return 0, err
+	}
+	return len(data), nil
+}
+
+func (writer *chunkWriter) Close() error {
+	return nil
+}
+
+func (writer *chunkWriter) Abort(error) {}
+
+func (sch *CopyScheduler) copyFile(ctx context.Context, task *api.RemoteCopyTask) error {
 	sch.log.Info("CopyFile()", "taskId", task.Id, "started", true)
 
 	source := &task.Source
@@ -562,64 +1575,208 @@ func (sch *CopyScheduler) copyFile(task *api.RemoteCopyTask, cancel chan api.Tas
 	if err == nil {
 		var info os.FileInfo
 		if info, err = file.Stat(); err == nil {
-			task.Progress = 0
-			task.Status = api.Running
+			sch.update(task, func() {
+				task.Progress = 0
+				task.Status = api.Running
+			})
 
-			read := 0
-			offset := int64(0)
 			size := info.Size()
-			buffer := make([]byte, min(size, 10485760)) // TODO. add pool
-
 			client := sch.network.Transport()
 			target := &task.Target
-			if target, err = target.Host.Create(client, target.Info, true); err == nil {
-				startTime := time.Now()
-				progressPercent := float64(size) / 100.0
-				for err == nil && task.Status == api.Running {
-					select {
-					case taskId := <-cancel:
-						if taskId == task.Id {
-							if err = target.Remove(client); err == nil {
-								task.Status = api.Cancelled
-								sch.log.Info("CopyFile()", "taskId", taskId, "cancelled", true)
-							} else {
-								sch.log.Info("CopyFile()", "taskId", taskId, "cancelled", false)
-							}
-						}
-					default:
-						if size > 0 {
-							if read, err = file.ReadAt(buffer, offset); err == nil {
-								if err = target.Write(client, buffer[:read]); err == nil {
-									offset += int64(read)
-									task.Progress = int(min((float64(offset) / progressPercent), 100.0))
-								}
-
-								sch.log.Info("CopyFile()", "taskId", task.Id, "offset", offset, "progress", task.Progress)
+			startTime := time.Now()
+			codec := sch.codec(task, target, file, size)
+			if signature := sch.signature(task, client, target, size); signature != nil {
+				err = sch.copyDelta(ctx, task, file, size, client, target, codec, signature)
+			} else if target, err = target.Host.Create(client, target.Info, true); err == nil {
+				if streams := sch.streams(task, target, size); streams > 1 {
+					err = sch.copyRanges(ctx, task, file, size, client, target, streams, codec)
+				} else {
+					err = sch.copyStream(ctx, task, file, size, client, target, codec)
+				}
+			}
+
+			if err == nil && task.Status == api.Completed {
+				sch.log.Info("CopyFile()", "taskId", task.Id, "progress", task.Progress, "duration", time.Since(startTime), "codec", codec, "ratio", task.Stats.Ratio(), "saved", task.Stats.Saved, "completed", true)
+			}
+		}
+	}
+
+	if file != nil {
+		err = errors.Join(err, file.Close())
+	}
+
+	if err != nil {
+		sch.update(task, func() {
+			task.Error = api.ToRemoteError(err)
+			task.Status = api.Failed
+		})
+
+		sch.log.Error("CopyFile()", "error", err)
+	}
+	return err
+}
+
+// Returns count of the concurrent streams of the file.
+// The file is sent by one stream if the target host can't write by offset or the file is smaller than two ranges.
+func (sch *CopyScheduler) streams(task *api.RemoteCopyTask, target *api.RemoteFile, size int64) int {
+	streams := task.Streams
+	if streams <= 0 {
+		streams = sch.config.Streams
+	}
+
+	if !target.Host.Supports(api.FeatureChannel) || !target.Host.Supports(api.FeatureRangeWrite) {
+		return 1
+	}
+	return int(min(int64(streams), size/int64(sch.config.RangeSize)))
+}
+
+// Copies the file sequentially by one stream.
+func (sch *CopyScheduler) copyStream(ctx context.Context, task *api.RemoteCopyTask, file *os.File, size int64, client transport.TransportSender, target *api.RemoteFile, codec transport.Codec) error {
+	read := 0
+	offset := int64(0)
+
+	writer, err := openWriter(client, target, codec)
+	if err == nil {
+		limited := sch.limitBufferWriter(ctx, task, writer, writer.Sent)
+		progressPercent := float64(size) / 100.0
+		for err == nil && task.Status == api.Running {
+			select {
+			case <-ctx.Done():
+				writer.Abort(context.Cause(ctx))
+				err = sch.cancelCopy(ctx, task, client, target)
+			default:
+				// The buffer is taken for one chunk, so the paused task waits for resuming without the buffer.
+				// If the buffer can't be taken, the context is done and the copying is cancelled by the next iteration.
+				if size > 0 {
+					limited.waitResumed()
+					if buffer, bufferErr := sch.buffers.get(ctx); bufferErr == nil {
+						if read, err = file.ReadAt(buffer, offset); read > 0 && (err == nil || errors.Is(err, io.EOF)) {
+							written, writeErr := limited.Write(buffer[:read])
+							offset += int64(written)
+							sch.update(task, func() { task.Progress = int(min((float64(offset) / progressPercent), 100.0)) })
+							if errors.Is(writeErr, errTaskPaused) {
+								err = nil // The rest of the chunk is read again after resuming.
+							} else if writeErr != nil {
+								err = writeErr
 							}
+
+							sch.log.Info("CopyFile()", "taskId", task.Id, "offset", offset, "progress", task.Progress)
 						}
+						sch.buffers.put(buffer)
+					}
+				}
 
-						if size == 0 || errors.Is(err, io.EOF) {
-							err = nil
-							endTime := time.Now()
+				if size == 0 || errors.Is(err, io.EOF) {
+					if err = writer.Close(); err == nil {
+						sch.addStats(task, api.TaskStats{Codec: codec, Bytes: writer.Bytes(), Sent: writer.Sent()})
+						sch.update(task, func() {
 							task.Progress = 100.0
 							task.Status = api.Completed
-							sch.log.Info("CopyFile()", "taskId", task.Id, "progress", task.Progress, "duration", endTime.Sub(startTime), "completed", true)
-						}
+						})
 					}
+				} else if err != nil {
+					writer.Abort(err)
 				}
 			}
 		}
 	}
+	return err
+}
 
-	if file != nil {
-		err = errors.Join(err, file.Close())
+// Copies the file by ranges which are sent concurrently, each range is written at its offset by own stream.
+func (sch *CopyScheduler) copyRanges(ctx context.Context, task *api.RemoteCopyTask, file *os.File, size int64, client transport.TransportSender, target *api.RemoteFile, streams int, codec transport.Codec) error {
+	rangeSize := (size + int64(streams) - 1) / int64(streams)
+	sch.log.Info("CopyFile()", "taskId", task.Id, "streams", streams, "rangeSize", rangeSize)
+
+	ctx, stop := context.WithCancelCause(ctx)
+	defer stop(nil)
+
+	copied := atomic.Int64{}
+	group := sync.WaitGroup{}
+	for offset := int64(0); offset < size; offset += rangeSize {
+		group.Add(1)
+		go func(offset int64, length int64) {
+			defer group.Done()
+			if err := sch.copyRange(ctx, task, file, size, offset, length, client, target, codec, &copied); err != nil {
+				stop(err)
+			}
+		}(offset, min(rangeSize, size-offset))
 	}
 
+	group.Wait()
+
+	if ctx.Err() != nil {
+		return sch.cancelCopy(ctx, task, client, target)
+	}
+	sch.update(task, func() {
+		task.Progress = 100.0
+		task.Status = api.Completed
+	})
+	return nil
+}
+
+// Copies the range of the file by one stream, the copying is stopped when the context is done.
+func (sch *CopyScheduler) copyRange(ctx context.Context, task *api.RemoteCopyTask, file *os.File, size int64, offset int64, length int64, client transport.TransportSender, target *api.RemoteFile, codec transport.Codec, copied *atomic.Int64) error {
+	writer, err := target.CompressedWriter(client, offset, codec)
 	if err != nil {
-		task.Error = err
-		task.Status = api.Failed
+		return err
+	}
+	limited := sch.limitBufferWriter(ctx, task, writer, writer.Sent)
+
+	written := int64(0)
+	for written < length {
+		select {
+		case <-ctx.Done():
+			writer.Abort(context.Cause(ctx))
+			return context.Cause(ctx)
+		default:
+			// The buffer is taken for one chunk, so the paused task waits for resuming without the buffer.
+			limited.waitResumed()
+			buffer, err := sch.buffers.get(ctx)
+			if err == nil {
+				var read int
+				read, err = file.ReadAt(buffer[:min(int64(len(buffer)), length-written)], offset+written)
+				if read > 0 && (err == nil || errors.Is(err, io.EOF)) {
+					var count int
+					count, err = limited.Write(buffer[:read])
+					written += int64(count)
+					progress := int(min(float64(copied.Add(int64(count)))/float64(size)*100.0, 100.0))
+					sch.update(task, func() { task.Progress = max(task.Progress, progress) })
+					sch.log.Info("CopyFile()", "taskId", task.Id, "offset", offset+written, "progress", progress)
+					if errors.Is(err, errTaskPaused) {
+						err = nil // The rest of the chunk is read again after resuming.
+					}
+				} else if err == nil || errors.Is(err, io.EOF) {
+					err = io.ErrUnexpectedEOF // The file has been truncated during copying.
+				}
+				sch.buffers.put(buffer)
+			}
 
-		sch.log.Error("CopyFile()", "error", err)
+			if err != nil {
+				writer.Abort(err)
+				return err
+			}
+		}
+	}
+
+	err = writer.Close()
+	if err == nil {
+		sch.addStats(task, api.TaskStats{Codec: codec, Bytes: writer.Bytes(), Sent: writer.Sent()})
+	}
+	return err
+}
+
+// Removes the target file of the stopped copying.
+// If the copying is cancelled, the task is marked as cancelled, otherwise the cause of the stopping is returned.
+func (sch *CopyScheduler) cancelCopy(ctx context.Context, task *api.RemoteCopyTask, client transport.TransportSender, target *api.RemoteFile) error {
+	err := target.Remove(client)
+	if cause := context.Cause(ctx); !errors.Is(cause, errCopyCancelled) {
+		return errors.Join(cause, err)
+	}
+
+	if err == nil {
+		sch.update(task, func() { task.Status = api.Cancelled })
 	}
+	sch.log.Info("CopyFile()", "taskId", task.Id, "cancelled", err == nil)
 	return err
 }
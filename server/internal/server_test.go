@@ -1,17 +1,27 @@
 package server_test
 
 import (
+	"archive/tar"
+	"bytes"
+	"errors"
 	"fmt"
+	"io"
+	"io/fs"
 	"netfs/api"
 	"netfs/api/transport"
 	server "netfs/server/internal"
+	"os"
 	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
 	"testing"
 	"time"
 )
 
 var config = server.ServerConfig{
 	Network: api.NetworkConfig{Port: 80, Protocol: transport.HTTP, Timeout: time.Second * 1},
+	Copy:    server.CopyConfig{Streams: 4, RangeSize: 1048576},
 }
 
 var srv *server.Server
@@ -25,10 +35,23 @@ func beforeEach() {
 	go func() {
 		srv.Start()
 	}()
+	waitServer(true)
 }
 
 func afterEach() {
 	srv.Stop()
+	waitServer(false)
+}
+
+// Waits until the server is started or stopped.
+func waitServer(started bool) {
+	network, _ := api.NewNetwork(config.Network)
+	for range 50 {
+		if _, err := network.Host(network.LocalIP()); (err == nil) == started {
+			return
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
 }
 
 func TestServerHostHandleSuccess(t *testing.T) {
@@ -46,6 +69,48 @@ func TestServerHostHandleSuccess(t *testing.T) {
 	}
 }
 
+func TestServerHostHandleCapabilities(t *testing.T) {
+	beforeEach()
+	defer afterEach()
+
+	network, _ := api.NewNetwork(config.Network)
+	host, err := network.Host(network.LocalIP())
+	if err != nil {
+		t.Fatalf("error should be nil, but err is [%s]", err)
+	}
+
+	capabilities := host.Capabilities
+	if capabilities == nil {
+		t.Fatal("capabilities should be not nil")
+	}
+	if capabilities.Version != server.Version {
+		t.Fatalf("version should be [%s], but version is [%s]", server.Version, capabilities.Version)
+	}
+	if capabilities.ApiVersion != api.ApiVersion {
+		t.Fatalf("API version should be [%d], but API version is [%d]", api.ApiVersion, capabilities.ApiVersion)
+	}
+	if capabilities.OS != runtime.GOOS || capabilities.Arch != runtime.GOARCH {
+		t.Fatalf("platform should be [%s/%s], but platform is [%s/%s]", runtime.GOOS, runtime.GOARCH, capabilities.OS, capabilities.Arch)
+	}
+	if !host.Supports(api.FeatureCopy) {
+		t.Fatalf("host should support [%s]", api.FeatureCopy)
+	}
+}
+
+func TestFileInfoHandleErrFileNotFound(t *testing.T) {
+	beforeEach()
+	defer afterEach()
+
+	network, _ := api.NewNetwork(config.Network)
+	host, _ := network.Host(network.LocalIP())
+
+	root, _ := filepath.Abs("./")
+	_, err := host.File(network.Transport(), api.FileId(filepath.Join(root, "not_exists.txt")))
+	if !errors.Is(err, api.ErrFileNotFound) {
+		t.Fatalf("error should be [api.ErrFileNotFound], but err is [%s]", err)
+	}
+}
+
 func TestFileChildrenHandleSuccess(t *testing.T) {
 	beforeEach()
 	defer afterEach()
@@ -79,6 +144,10 @@ func TestFileChildrenHandleSuccess(t *testing.T) {
 		t.Fatalf("file id should be [%v], but file id is [%v]", children[0].Info.Id, file.Info.Id)
 	}
 
+	if children[0].Info.ModTime.IsZero() {
+		t.Fatalf("modification time should be set, but it is zero")
+	}
+
 	dir.Remove(network.Transport())
 }
 
@@ -137,6 +206,96 @@ func TestFileCreateHandleErrFileAlreadyExists(t *testing.T) {
 	file.Remove(network.Transport())
 }
 
+func TestFileRenameHandleSuccess(t *testing.T) {
+	beforeEach()
+	defer afterEach()
+
+	network, _ := api.NewNetwork(config.Network)
+	host, _ := network.Host(network.LocalIP())
+
+	root, _ := filepath.Abs("./")
+	os.WriteFile(filepath.Join(root, "test_rename.txt"), generate(16), 0666)
+	defer os.Remove(filepath.Join(root, "test_rename.txt"))
+	defer os.Remove(filepath.Join(root, "test_renamed.txt"))
+
+	file, _ := host.File(network.Transport(), api.FileId(filepath.Join(root, "test_rename.txt")))
+	renamed, err := file.Rename(network.Transport(), "test_renamed.txt")
+	if err != nil {
+		t.Fatalf("error should be nil, but err is [%s]", err)
+	}
+	if renamed.Info.Path != filepath.Join(root, "test_renamed.txt") || renamed.Info.Size != 16 {
+		t.Fatalf("renamed file should be [%s], but file is [%v]", filepath.Join(root, "test_renamed.txt"), renamed.Info)
+	}
+
+	if _, err = os.Stat(filepath.Join(root, "test_rename.txt")); !errors.Is(err, fs.ErrNotExist) {
+		t.Fatalf("source file should not exist, but err is [%v]", err)
+	}
+	if _, err = os.Stat(filepath.Join(root, "test_renamed.txt")); err != nil {
+		t.Fatalf("renamed file should exist, but err is [%s]", err)
+	}
+}
+
+func TestFileRenameHandleErrFileAlreadyExists(t *testing.T) {
+	beforeEach()
+	defer afterEach()
+
+	network, _ := api.NewNetwork(config.Network)
+	host := network.LocalHost()
+
+	root, _ := filepath.Abs("./")
+	os.WriteFile(filepath.Join(root, "test_rename.txt"), generate(16), 0666)
+	os.WriteFile(filepath.Join(root, "test_renamed.txt"), generate(16), 0666)
+	defer os.Remove(filepath.Join(root, "test_rename.txt"))
+	defer os.Remove(filepath.Join(root, "test_renamed.txt"))
+
+	file, _ := host.File(network.Transport(), api.FileId(filepath.Join(root, "test_rename.txt")))
+	_, err := file.Rename(network.Transport(), "test_renamed.txt")
+	if !errors.Is(err, api.ErrFileAlreadyExists) {
+		t.Fatalf("error should be [api.ErrFileAlreadyExists], but err is [%v]", err)
+	}
+}
+
+func TestFileRenameHandleErrInvalidArgument(t *testing.T) {
+	beforeEach()
+	defer afterEach()
+
+	network, _ := api.NewNetwork(config.Network)
+	host := network.LocalHost()
+
+	root, _ := filepath.Abs("./")
+	os.WriteFile(filepath.Join(root, "test_rename.txt"), generate(16), 0666)
+	defer os.Remove(filepath.Join(root, "test_rename.txt"))
+
+	file, _ := host.File(network.Transport(), api.FileId(filepath.Join(root, "test_rename.txt")))
+	for _, name := range []string{"..", "sub/test.txt", " "} {
+		if _, err := file.Rename(network.Transport(), name); !errors.Is(err, api.ErrInvalidArgument) {
+			t.Fatalf("error of name [%s] should be [api.ErrInvalidArgument], but err is [%v]", name, err)
+		}
+	}
+}
+
+func TestVolumeInfoHandleSuccess(t *testing.T) {
+	beforeEach()
+	defer afterEach()
+
+	network, _ := api.NewNetwork(config.Network)
+	host := network.LocalHost()
+
+	root, _ := filepath.Abs("./")
+	file := api.RemoteFile{Host: host, Info: api.FileInfo{Id: api.FileId(filepath.Join(root, "not_exists", "test.txt"))}}
+	volume, err := file.Volume(network.Transport())
+	if err != nil {
+		t.Fatalf("error should be nil, but err is [%s]", err)
+	}
+
+	if volume.Total == 0 || volume.Free > volume.Total {
+		t.Fatalf("volume should have total space and free space less than total, but volume is [%v]", *volume)
+	}
+	if volume.Used != volume.Total-volume.Free {
+		t.Fatalf("used space should be [%d], but used space is [%d]", volume.Total-volume.Free, volume.Used)
+	}
+}
+
 func TestFileCopyStartHandleSuccess(t *testing.T) {
 	beforeEach()
 	defer afterEach()
@@ -170,10 +329,1139 @@ func TestFileCopyStartHandleSuccess(t *testing.T) {
 	time.Sleep(5 * time.Second)
 }
 
-func generate(size int) []byte {
-	result := make([]byte, size)
-	for i := range size {
-		result[i] = byte(1)
+func TestFileCopyStartHandleChannel(t *testing.T) {
+	beforeEach()
+	defer afterEach()
+
+	network, _ := api.NewNetwork(config.Network)
+	host, _ := network.Host(network.LocalIP())
+	if !host.Supports(api.FeatureChannel) {
+		t.Fatalf("host should support [%s]", api.FeatureChannel)
+	}
+
+	root, _ := filepath.Abs("./")
+	file, _ := host.Create(
+		network.Transport(),
+		api.FileInfo{Name: "test.txt", Path: filepath.Join(root, "test.txt"), Type: api.FILE},
+		true,
+	)
+	defer file.Remove(network.Transport())
+
+	data := generate(3 * 1048576)
+	file.WriteFrom(network.Transport(), bytes.NewReader(data))
+
+	target := api.RemoteFile{
+		Host: *host,
+		Info: api.FileInfo{Name: "test_channel.txt", Path: filepath.Join(root, "test_channel.txt"), Type: api.FILE},
+	}
+	if _, err := file.CopyWith(network.Transport(), target, api.CopyOptions{Replace: true, Streams: 1}); err != nil {
+		t.Fatalf("error should be nil, but err is [%s]", err)
+	}
+	defer os.Remove(target.Info.Path)
+
+	var copied *api.RemoteFile
+	for range 50 {
+		if copied, _ = host.File(network.Transport(), api.FileId(target.Info.Path)); copied != nil && int(copied.Info.Size) == len(data) {
+			break
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+	if copied == nil || int(copied.Info.Size) != len(data) {
+		t.Fatalf("copied file size should be [%d], but file is [%v]", len(data), copied)
+	}
+}
+
+func TestFileCopyStartHandleRanges(t *testing.T) {
+	beforeEach()
+	defer afterEach()
+
+	network, _ := api.NewNetwork(config.Network)
+	host, _ := network.Host(network.LocalIP())
+
+	root, _ := filepath.Abs("./")
+	file, _ := host.Create(
+		network.Transport(),
+		api.FileInfo{Name: "test.txt", Path: filepath.Join(root, "test.txt"), Type: api.FILE},
+		true,
+	)
+	defer file.Remove(network.Transport())
+
+	data := make([]byte, 5*1048576+123)
+	for i := range data {
+		data[i] = byte(i % 251)
+	}
+	file.WriteFrom(network.Transport(), bytes.NewReader(data))
+
+	target := api.RemoteFile{
+		Host: *host,
+		Info: api.FileInfo{Name: "test_ranges.txt", Path: filepath.Join(root, "test_ranges.txt"), Type: api.FILE},
+	}
+	if _, err := file.CopyWith(network.Transport(), target, api.CopyOptions{Replace: true, Streams: 3}); err != nil {
+		t.Fatalf("error should be nil, but err is [%s]", err)
+	}
+	target.Info.Id = api.FileId(target.Info.Path)
+	defer os.Remove(target.Info.Path)
+
+	if err := waitCopy(network, host); err != nil {
+		t.Fatalf("error should be nil, but err is [%s]", err)
+	}
+
+	reader, err := target.Read(network.Transport(), 0, 0)
+	if err != nil {
+		t.Fatalf("error should be nil, but err is [%s]", err)
+	}
+	defer reader.Close()
+
+	copied, _ := io.ReadAll(reader)
+	if !bytes.Equal(copied, data) {
+		t.Fatalf("copied data should be equal to the source data, copied size is [%d], source size is [%d]", len(copied), len(data))
+	}
+}
+
+func TestFileCopyStartHandleCompression(t *testing.T) {
+	beforeEach()
+	defer afterEach()
+
+	network, _ := api.NewNetwork(config.Network)
+	host, _ := network.Host(network.LocalIP())
+
+	root, _ := filepath.Abs("./")
+	file, _ := host.Create(
+		network.Transport(),
+		api.FileInfo{Name: "test.txt", Path: filepath.Join(root, "test.txt"), Type: api.FILE},
+		true,
+	)
+	defer file.Remove(network.Transport())
+
+	data := bytes.Repeat([]byte("TEST_DATA_"), 300000)
+	file.WriteFrom(network.Transport(), bytes.NewReader(data))
+
+	options := []api.CopyOptions{
+		{Replace: true, Streams: 1, Compression: api.CompressionAlways},
+		{Replace: true, Streams: 3, Compression: api.CompressionAlways},
+		{Replace: true, Compression: api.CompressionAuto},
+	}
+	for _, option := range options {
+		target := api.RemoteFile{
+			Host: *host,
+			Info: api.FileInfo{Name: "test_compressed.txt", Path: filepath.Join(root, "test_compressed.txt"), Type: api.FILE},
+		}
+		if _, err := file.CopyWith(network.Transport(), target, option); err != nil {
+			t.Fatalf("error should be nil, but err is [%s]", err)
+		}
+		target.Info.Id = api.FileId(target.Info.Path)
+
+		if err := waitCopy(network, host); err != nil {
+			t.Fatalf("error should be nil, but err is [%s]", err)
+		}
+
+		copied, _ := os.ReadFile(target.Info.Path)
+		target.Remove(network.Transport())
+		if !bytes.Equal(copied, data) {
+			t.Fatalf("copied data should be equal to the source data, copied size is [%d], source size is [%d]", len(copied), len(data))
+		}
+	}
+}
+
+func TestFileCopyStartHandleDelta(t *testing.T) {
+	beforeEach()
+	defer afterEach()
+
+	network, _ := api.NewNetwork(config.Network)
+	host, _ := network.Host(network.LocalIP())
+
+	root, _ := filepath.Abs("./")
+	sourcePath := filepath.Join(root, "test.txt")
+	targetPath := filepath.Join(root, "test_delta.txt")
+	defer os.Remove(sourcePath)
+	defer os.Remove(targetPath)
+
+	data := make([]byte, 3*1048576)
+	for i := range data {
+		data[i] = byte(i % 251)
+	}
+	os.WriteFile(targetPath, data, 0666)
+	copy(data[1048576:], "CHANGED")
+	os.WriteFile(sourcePath, append(data, "APPENDED"...), 0666)
+
+	source := api.RemoteFile{Host: *host, Info: api.FileInfo{Id: api.FileId(sourcePath), Name: "test.txt", Path: sourcePath, Type: api.FILE}}
+	target := api.RemoteFile{Host: *host, Info: api.FileInfo{Name: "test_delta.txt", Path: targetPath, Type: api.FILE}}
+	if _, err := source.CopyWith(network.Transport(), target, api.CopyOptions{Replace: true, Delta: true}); err != nil {
+		t.Fatalf("error should be nil, but err is [%s]", err)
+	}
+	if err := waitCopy(network, host); err != nil {
+		t.Fatalf("error should be nil, but err is [%s]", err)
+	}
+
+	copied, _ := os.ReadFile(targetPath)
+	if !bytes.Equal(copied, append(data, "APPENDED"...)) {
+		t.Fatalf("copied data should be equal to the source data, copied size is [%d], source size is [%d]", len(copied), len(data)+8)
+	}
+
+	entries, _ := os.ReadDir(root)
+	for _, entry := range entries {
+		if strings.HasPrefix(entry.Name(), ".netfs-delta-") {
+			t.Fatalf("temporary file [%s] should be removed", entry.Name())
+		}
+	}
+}
+
+func TestFileCopyStartHandleMove(t *testing.T) {
+	beforeEach()
+	defer afterEach()
+
+	network, _ := api.NewNetwork(config.Network)
+	host, _ := network.Host(network.LocalIP())
+
+	root, _ := filepath.Abs("./")
+	sourcePath := filepath.Join(root, "test.txt")
+	targetPath := filepath.Join(root, "test_move.txt")
+	data := generate(65536)
+	os.WriteFile(sourcePath, data, 0666)
+	defer os.Remove(sourcePath)
+	defer os.Remove(targetPath)
+
+	source := api.RemoteFile{Host: *host, Info: api.FileInfo{Id: api.FileId(sourcePath), Name: "test.txt", Path: sourcePath, Type: api.FILE}}
+	target := api.RemoteFile{Host: *host, Info: api.FileInfo{Name: "test_move.txt", Path: targetPath, Type: api.FILE}}
+	if _, err := source.MoveTo(network.Transport(), target, true); err != nil {
+		t.Fatalf("error should be nil, but err is [%s]", err)
+	}
+	if err := waitCopy(network, host); err != nil {
+		t.Fatalf("error should be nil, but err is [%s]", err)
+	}
+
+	// The source is removed after the task is completed.
+	for range 100 {
+		if _, err := os.Stat(sourcePath); errors.Is(err, os.ErrNotExist) {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if _, err := os.Stat(sourcePath); !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("source file should be removed, but err is [%v]", err)
+	}
+
+	moved, _ := os.ReadFile(targetPath)
+	if !bytes.Equal(moved, data) {
+		t.Fatalf("moved data should be equal to the source data, moved size is [%d], source size is [%d]", len(moved), len(data))
+	}
+}
+
+func TestFileCopyStartHandleMoveErrInvalidArgument(t *testing.T) {
+	beforeEach()
+	defer afterEach()
+
+	network, _ := api.NewNetwork(config.Network)
+	host, _ := network.Host(network.LocalIP())
+
+	root, _ := filepath.Abs("./")
+	sourcePath := filepath.Join(root, "test.txt")
+	os.WriteFile(sourcePath, generate(1024), 0666)
+	defer os.Remove(sourcePath)
+
+	source := api.RemoteFile{Host: *host, Info: api.FileInfo{Id: api.FileId(sourcePath), Name: "test.txt", Path: sourcePath, Type: api.FILE}}
+	_, err := source.MoveTo(network.Transport(), source, true)
+	if !errors.Is(err, api.ErrInvalidArgument) {
+		t.Fatalf("error should be [api.ErrInvalidArgument], but err is [%v]", err)
+	}
+	if _, err = os.Stat(sourcePath); err != nil {
+		t.Fatalf("source file should exist, but err is [%s]", err)
+	}
+}
+
+func TestFileCopyStartHandleSameFileErrInvalidArgument(t *testing.T) {
+	beforeEach()
+	defer afterEach()
+
+	network, _ := api.NewNetwork(config.Network)
+	host, _ := network.Host(network.LocalIP())
+
+	root, _ := filepath.Abs("./")
+	sourcePath := filepath.Join(root, "test.txt")
+	os.WriteFile(sourcePath, generate(1024), 0666)
+	defer os.Remove(sourcePath)
+
+	source := api.RemoteFile{Host: *host, Info: api.FileInfo{Id: api.FileId(sourcePath), Name: "test.txt", Path: sourcePath, Type: api.FILE}}
+	target := api.RemoteFile{Host: *host, Info: api.FileInfo{Name: "test.txt", Path: root + "/./test.txt", Type: api.FILE}}
+	_, err := source.CopyWith(network.Transport(), target, api.CopyOptions{Replace: true, Streams: 1})
+	if !errors.Is(err, api.ErrInvalidArgument) {
+		t.Fatalf("error should be [api.ErrInvalidArgument], but err is [%v]", err)
+	}
+	if info, err := os.Stat(sourcePath); err != nil || info.Size() != 1024 {
+		t.Fatalf("source file should be kept, but err is [%v]", err)
+	}
+}
+
+func TestFileCopyLimitHandleSuccess(t *testing.T) {
+	beforeEach()
+	defer afterEach()
+
+	network, _ := api.NewNetwork(config.Network)
+	host, _ := network.Host(network.LocalIP())
+
+	root, _ := filepath.Abs("./")
+	sourcePath := filepath.Join(root, "test.txt")
+	targetPath := filepath.Join(root, "test_limit.txt")
+	os.WriteFile(sourcePath, generate(4*1048576), 0666)
+	defer os.Remove(sourcePath)
+	defer os.Remove(targetPath)
+
+	source := api.RemoteFile{Host: *host, Info: api.FileInfo{Id: api.FileId(sourcePath), Name: "test.txt", Path: sourcePath, Type: api.FILE}}
+	target := api.RemoteFile{Host: *host, Info: api.FileInfo{Name: "test_limit.txt", Path: targetPath, Type: api.FILE}}
+	options := api.CopyOptions{Replace: true, Streams: 1, Compression: api.CompressionNone, Limit: 65536}
+	task, err := source.CopyWith(network.Transport(), target, options)
+	if err != nil {
+		t.Fatalf("error should be nil, but err is [%s]", err)
+	}
+
+	time.Sleep(300 * time.Millisecond)
+	tasks, _ := host.Tasks(network.Transport())
+	if len(tasks) != 1 || tasks[0].Progress >= 50 {
+		t.Fatalf("limited task should be running, but tasks are [%v]", tasks)
+	}
+
+	if err = task.SetLimit(network.Transport(), 0); err != nil {
+		t.Fatalf("error should be nil, but err is [%s]", err)
+	}
+	if err = waitCopy(network, host); err != nil {
+		t.Fatalf("error should be nil, but err is [%s]", err)
+	}
+
+	if info, _ := os.Stat(targetPath); info == nil || info.Size() != 4*1048576 {
+		t.Fatal("target file should be copied")
+	}
+}
+
+func TestFileCopyLimitHandleErrTaskNotFound(t *testing.T) {
+	beforeEach()
+	defer afterEach()
+
+	network, _ := api.NewNetwork(config.Network)
+	host, _ := network.Host(network.LocalIP())
+
+	task := api.RemoteCopyTask{Id: "unknown", Host: *host}
+	if err := task.SetLimit(network.Transport(), 1024); !errors.Is(err, api.ErrTaskNotFound) {
+		t.Fatalf("error should be [api.ErrTaskNotFound], but err is [%v]", err)
+	}
+}
+
+func TestFileCopyPauseHandleSuccess(t *testing.T) {
+	beforeEach()
+	defer afterEach()
+
+	network, _ := api.NewNetwork(config.Network)
+	host, _ := network.Host(network.LocalIP())
+
+	root, _ := filepath.Abs("./")
+	sourcePath := filepath.Join(root, "test.txt")
+	targetPath := filepath.Join(root, "test_pause.txt")
+	os.WriteFile(sourcePath, generate(4*1048576), 0666)
+	defer os.Remove(sourcePath)
+	defer os.Remove(targetPath)
+
+	source := api.RemoteFile{Host: *host, Info: api.FileInfo{Id: api.FileId(sourcePath), Name: "test.txt", Path: sourcePath, Type: api.FILE}}
+	target := api.RemoteFile{Host: *host, Info: api.FileInfo{Name: "test_pause.txt", Path: targetPath, Type: api.FILE}}
+	options := api.CopyOptions{Replace: true, Streams: 1, Compression: api.CompressionNone, Limit: 65536}
+	task, err := source.CopyWith(network.Transport(), target, options)
+	if err != nil {
+		t.Fatalf("error should be nil, but err is [%s]", err)
+	}
+
+	if err = task.Pause(network.Transport()); err != nil {
+		t.Fatalf("error should be nil, but err is [%s]", err)
+	}
+	time.Sleep(100 * time.Millisecond)
+	paused, _ := host.Task(network.Transport(), task.Id)
+	time.Sleep(300 * time.Millisecond)
+	current, _ := host.Task(network.Transport(), task.Id)
+	if !current.Paused || current.Status != api.Running || current.Progress != paused.Progress || current.Elapsed != paused.Elapsed {
+		t.Fatalf("paused task should not be changed, but task is [%v]", current)
+	}
+
+	if err = task.Resume(network.Transport()); err != nil {
+		t.Fatalf("error should be nil, but err is [%s]", err)
+	}
+	if err = task.SetLimit(network.Transport(), 0); err != nil {
+		t.Fatalf("error should be nil, but err is [%s]", err)
+	}
+	if err = waitCopy(network, host); err != nil {
+		t.Fatalf("error should be nil, but err is [%s]", err)
+	}
+
+	history, _ := host.TaskHistory(network.Transport())
+	if len(history) != 1 || history[0].Status != api.Completed || history[0].Paused || history[0].Size != 4*1048576 || history[0].Elapsed <= 0 {
+		t.Fatalf("completed task should be kept, but tasks are [%v]", history)
+	}
+	if info, _ := os.Stat(targetPath); info == nil || info.Size() != 4*1048576 {
+		t.Fatal("target file should be copied")
+	}
+}
+
+func TestFileCopyPauseHandleMemoryLimit(t *testing.T) {
+	copyConfig := config.Copy
+	config.Copy.ChunkSize = 65536
+	config.Copy.MemoryLimit = 65536
+	defer func() { config.Copy = copyConfig }()
+
+	beforeEach()
+	defer afterEach()
+
+	network, _ := api.NewNetwork(config.Network)
+	host, _ := network.Host(network.LocalIP())
+
+	root, _ := filepath.Abs("./")
+	sourcePath := filepath.Join(root, "test.txt")
+	pausedPath := filepath.Join(root, "test_pause.txt")
+	targetPath := filepath.Join(root, "test_pause_other.txt")
+	os.WriteFile(sourcePath, generate(1048576), 0666)
+	defer os.Remove(sourcePath)
+	defer os.Remove(pausedPath)
+	defer os.Remove(targetPath)
+
+	// The paused task returns the only buffer of the pool, so the other task is not blocked.
+	source := api.RemoteFile{Host: *host, Info: api.FileInfo{Id: api.FileId(sourcePath), Name: "test.txt", Path: sourcePath, Type: api.FILE}}
+	target := api.RemoteFile{Host: *host, Info: api.FileInfo{Name: "test_pause.txt", Path: pausedPath, Type: api.FILE}}
+	paused, err := source.CopyWith(network.Transport(), target, api.CopyOptions{Replace: true, Streams: 1, Compression: api.CompressionNone, Limit: 65536})
+	if err != nil {
+		t.Fatalf("error should be nil, but err is [%s]", err)
+	}
+	if err = paused.Pause(network.Transport()); err != nil {
+		t.Fatalf("error should be nil, but err is [%s]", err)
+	}
+	time.Sleep(200 * time.Millisecond)
+
+	target = api.RemoteFile{Host: *host, Info: api.FileInfo{Name: "test_pause_other.txt", Path: targetPath, Type: api.FILE}}
+	other, err := source.CopyWith(network.Transport(), target, api.CopyOptions{Replace: true, Streams: 1, Compression: api.CompressionNone})
+	if err != nil {
+		t.Fatalf("error should be nil, but err is [%s]", err)
+	}
+	completed := false
+	for range 100 {
+		if task, err := host.Task(network.Transport(), other.Id); err == nil && task.Status == api.Completed {
+			completed = true
+			break
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+	if !completed {
+		t.Fatal("task should be completed while the other task is paused")
+	}
+
+	if err = paused.Resume(network.Transport()); err != nil {
+		t.Fatalf("error should be nil, but err is [%s]", err)
+	}
+	if err = paused.SetLimit(network.Transport(), 0); err != nil {
+		t.Fatalf("error should be nil, but err is [%s]", err)
+	}
+	if err = waitCopy(network, host); err != nil {
+		t.Fatalf("error should be nil, but err is [%s]", err)
+	}
+	for _, path := range []string{pausedPath, targetPath} {
+		if info, _ := os.Stat(path); info == nil || info.Size() != 1048576 {
+			t.Fatalf("target file [%s] should be copied", path)
+		}
+	}
+}
+
+func TestFileCopyPauseHandleErrTaskNotFound(t *testing.T) {
+	beforeEach()
+	defer afterEach()
+
+	network, _ := api.NewNetwork(config.Network)
+	host, _ := network.Host(network.LocalIP())
+
+	task := api.RemoteCopyTask{Id: "unknown", Host: *host}
+	if err := task.Pause(network.Transport()); !errors.Is(err, api.ErrTaskNotFound) {
+		t.Fatalf("error should be [api.ErrTaskNotFound], but err is [%v]", err)
+	}
+	if err := task.Resume(network.Transport()); !errors.Is(err, api.ErrTaskNotFound) {
+		t.Fatalf("error should be [api.ErrTaskNotFound], but err is [%v]", err)
+	}
+	if _, err := host.Task(network.Transport(), task.Id); !errors.Is(err, api.ErrTaskNotFound) {
+		t.Fatalf("error should be [api.ErrTaskNotFound], but err is [%v]", err)
+	}
+}
+
+func TestFileCopyRetrySuccess(t *testing.T) {
+	beforeEach()
+	defer afterEach()
+
+	network, _ := api.NewNetwork(config.Network)
+	host, _ := network.Host(network.LocalIP())
+
+	root, _ := filepath.Abs("./")
+	sourcePath := filepath.Join(root, "test.txt")
+	targetPath := filepath.Join(root, "test_retry.txt")
+	os.WriteFile(sourcePath, generate(4*1048576), 0666)
+	defer os.Remove(sourcePath)
+	defer os.Remove(targetPath)
+
+	source := api.RemoteFile{Host: *host, Info: api.FileInfo{Id: api.FileId(sourcePath), Name: "test.txt", Path: sourcePath, Type: api.FILE}}
+	target := api.RemoteFile{Host: *host, Info: api.FileInfo{Name: "test_retry.txt", Path: targetPath, Type: api.FILE}}
+	task, err := source.CopyWith(network.Transport(), target, api.CopyOptions{Replace: true, Streams: 1, Limit: 65536})
+	if err != nil {
+		t.Fatalf("error should be nil, but err is [%s]", err)
+	}
+	task.Cancel(network.Transport())
+	waitCopy(network, host)
+	cancelled, _ := host.Task(network.Transport(), task.Id)
+	if cancelled.Status != api.Cancelled {
+		t.Fatalf("task should be cancelled, but task is [%v]", cancelled)
+	}
+
+	cancelled.Limit = 0
+	if _, err = cancelled.Retry(network.Transport()); err != nil {
+		t.Fatalf("error should be nil, but err is [%s]", err)
+	}
+	if err = waitCopy(network, host); err != nil {
+		t.Fatalf("error should be nil, but err is [%s]", err)
+	}
+	if info, _ := os.Stat(targetPath); info == nil || info.Size() != 4*1048576 {
+		t.Fatal("target file should be copied")
+	}
+}
+
+func TestFileCopyStartHandleDirectory(t *testing.T) {
+	beforeEach()
+	defer afterEach()
+
+	network, _ := api.NewNetwork(config.Network)
+	host, _ := network.Host(network.LocalIP())
+
+	root, _ := filepath.Abs("./")
+	sourcePath := filepath.Join(root, "test_dir")
+	targetPath := filepath.Join(root, "test_dir_copy")
+	createTree(sourcePath, 5, 20, 1024)
+	defer os.RemoveAll(sourcePath)
+	defer os.RemoveAll(targetPath)
+
+	source := api.RemoteFile{Host: *host, Info: api.FileInfo{Id: api.FileId(sourcePath), Name: "test_dir", Path: sourcePath, Type: api.DIRECTORY}}
+	target := api.RemoteFile{Host: *host, Info: api.FileInfo{Name: "test_dir_copy", Path: targetPath, Type: api.DIRECTORY}}
+	task, err := source.CopyWith(network.Transport(), target, api.CopyOptions{Replace: true, Workers: 4})
+	if err != nil {
+		t.Fatalf("error should be nil, but err is [%s]", err)
+	}
+	if task.Id == "" {
+		t.Fatal("task id should be not empty")
+	}
+
+	if err = waitCopy(network, host); err != nil {
+		t.Fatalf("error should be nil, but err is [%s]", err)
+	}
+
+	err = filepath.WalkDir(sourcePath, func(path string, entry fs.DirEntry, err error) error {
+		if err == nil && !entry.IsDir() {
+			var sourceData, targetData []byte
+			sourceData, _ = os.ReadFile(path)
+			if targetData, err = os.ReadFile(strings.Replace(path, sourcePath, targetPath, 1)); err == nil && !bytes.Equal(sourceData, targetData) {
+				err = fmt.Errorf("file [%s] is not equal to the source", path)
+			}
+		}
+		return err
+	})
+	if err != nil {
+		t.Fatalf("error should be nil, but err is [%s]", err)
+	}
+}
+
+func TestFileCopyCancelHandleSuccess(t *testing.T) {
+	beforeEach()
+	defer afterEach()
+
+	network, _ := api.NewNetwork(config.Network)
+	host, _ := network.Host(network.LocalIP())
+
+	root, _ := filepath.Abs("./")
+	sourcePath := filepath.Join(root, "test_dir")
+	targetPath := filepath.Join(root, "test_dir_copy")
+	createTree(sourcePath, 2, 100, 1048576)
+	defer os.RemoveAll(sourcePath)
+	defer os.RemoveAll(targetPath)
+
+	source := api.RemoteFile{Host: *host, Info: api.FileInfo{Id: api.FileId(sourcePath), Name: "test_dir", Path: sourcePath, Type: api.DIRECTORY}}
+	target := api.RemoteFile{Host: *host, Info: api.FileInfo{Name: "test_dir_copy", Path: targetPath, Type: api.DIRECTORY}}
+	task, _ := source.CopyWith(network.Transport(), target, api.CopyOptions{Replace: true, Workers: 2})
+	if err := task.Cancel(network.Transport()); err != nil {
+		t.Fatalf("error should be nil, but err is [%s]", err)
+	}
+
+	if err := waitCopy(network, host); err != nil {
+		t.Fatalf("error should be nil, but err is [%s]", err)
+	}
+
+	copied := 0
+	filepath.WalkDir(targetPath, func(path string, entry fs.DirEntry, err error) error {
+		if err == nil && !entry.IsDir() {
+			copied++
+		}
+		return nil
+	})
+	if copied >= 200 {
+		t.Fatalf("copied files count should be less than [200], but count is [%d]", copied)
+	}
+
+	if err := task.Cancel(network.Transport()); !errors.Is(err, api.ErrTaskNotFound) {
+		t.Fatalf("error should be [api.ErrTaskNotFound], but err is [%s]", err)
+	}
+}
+
+func TestFileCopyStartHandleErrFileAlreadyExists(t *testing.T) {
+	beforeEach()
+	defer afterEach()
+
+	network, _ := api.NewNetwork(config.Network)
+	host := network.LocalHost()
+
+	root, _ := filepath.Abs("./")
+	file, _ := host.Create(
+		network.Transport(),
+		api.FileInfo{Name: "test.txt", Path: filepath.Join(root, "test.txt"), Type: api.FILE},
+		true,
+	)
+	target, _ := host.Create(
+		network.Transport(),
+		api.FileInfo{Name: "test_exists.txt", Path: filepath.Join(root, "test_exists.txt"), Type: api.FILE},
+		true,
+	)
+	defer file.Remove(network.Transport())
+	defer target.Remove(network.Transport())
+
+	// The target is checked by the host which reports its capabilities.
+	capable, _ := network.Host(network.LocalIP())
+	file.Host = *capable
+	_, err := file.CopyWith(network.Transport(), *target, api.CopyOptions{})
+	if !errors.Is(err, api.ErrFileAlreadyExists) {
+		t.Fatalf("error should be [api.ErrFileAlreadyExists], but err is [%s]", err)
+	}
+}
+
+func TestFileCopyStartHandleLegacyReplace(t *testing.T) {
+	beforeEach()
+	defer afterEach()
+
+	network, _ := api.NewNetwork(config.Network)
+	host := network.LocalHost()
+
+	root, _ := filepath.Abs("./")
+	sourcePath := filepath.Join(root, "test.txt")
+	targetPath := filepath.Join(root, "test_legacy.txt")
+	os.WriteFile(sourcePath, generate(1024), 0666)
+	os.WriteFile(targetPath, generate(16), 0666)
+	defer os.Remove(sourcePath)
+	defer os.Remove(targetPath)
+
+	// The older clients send the task without the flag of replacing.
+	body := map[string]any{
+		"Source": api.RemoteFile{Host: host, Info: api.FileInfo{Id: api.FileId(sourcePath), Name: "test.txt", Path: sourcePath, Type: api.FILE}},
+		"Target": api.RemoteFile{Host: host, Info: api.FileInfo{Name: "test_legacy.txt", Path: targetPath, Type: api.FILE}},
+	}
+	req, _ := network.Transport().NewRequest(host.IP, api.Endpoints.FileCopyStart, nil, nil, body)
+	if _, err := network.Transport().Send(req); err != nil {
+		t.Fatalf("error should be nil, but err is [%s]", err)
+	}
+	if err := waitCopy(network, &host); err != nil {
+		t.Fatalf("error should be nil, but err is [%s]", err)
+	}
+	if info, _ := os.Stat(targetPath); info == nil || info.Size() != 1024 {
+		t.Fatal("target file should be replaced")
+	}
+}
+
+func TestFileReadHandleSuccess(t *testing.T) {
+	beforeEach()
+	defer afterEach()
+
+	network, _ := api.NewNetwork(config.Network)
+	host := network.LocalHost()
+
+	root, _ := filepath.Abs("./")
+	file, _ := host.Create(
+		network.Transport(),
+		api.FileInfo{Name: "test.txt", Path: filepath.Join(root, "test.txt"), Type: api.FILE},
+		true,
+	)
+	defer file.Remove(network.Transport())
+
+	err := file.WriteFrom(network.Transport(), bytes.NewReader([]byte("TEST_DATA")))
+	if err != nil {
+		t.Fatalf("error should be nil, but err is [%s]", err)
+	}
+
+	reader, err := file.Read(network.Transport(), 5, 4)
+	if err != nil {
+		t.Fatalf("error should be nil, but err is [%s]", err)
+	}
+	defer reader.Close()
+
+	data, _ := io.ReadAll(reader)
+	if string(data) != "DATA" {
+		t.Fatalf("data should be [DATA], but data is [%s]", data)
+	}
+}
+
+func TestFileCopyStartHandleMemoryLimit(t *testing.T) {
+	copyConfig := config.Copy
+	config.Copy.ChunkSize = 65536
+	config.Copy.MemoryLimit = 65536
+	defer func() { config.Copy = copyConfig }()
+
+	beforeEach()
+	defer afterEach()
+
+	network, _ := api.NewNetwork(config.Network)
+	host, _ := network.Host(network.LocalIP())
+
+	root, _ := filepath.Abs("./")
+	sourcePath := filepath.Join(root, "test.txt")
+	data := make([]byte, 3*1048576+123)
+	for i := range data {
+		data[i] = byte(i % 251)
+	}
+	os.WriteFile(sourcePath, data, 0666)
+	defer os.Remove(sourcePath)
+
+	// The tasks and their ranges share the only buffer of the pool.
+	source := api.RemoteFile{Host: *host, Info: api.FileInfo{Id: api.FileId(sourcePath), Name: "test.txt", Path: sourcePath, Type: api.FILE}}
+	for index := range 3 {
+		targetPath := filepath.Join(root, fmt.Sprintf("test_memory_%d.txt", index))
+		defer os.Remove(targetPath)
+
+		target := api.RemoteFile{Host: *host, Info: api.FileInfo{Name: filepath.Base(targetPath), Path: targetPath, Type: api.FILE}}
+		if _, err := source.CopyWith(network.Transport(), target, api.CopyOptions{Replace: true}); err != nil {
+			t.Fatalf("error should be nil, but err is [%s]", err)
+		}
+	}
+	if err := waitCopy(network, host); err != nil {
+		t.Fatalf("error should be nil, but err is [%s]", err)
+	}
+
+	for index := range 3 {
+		copied, _ := os.ReadFile(filepath.Join(root, fmt.Sprintf("test_memory_%d.txt", index)))
+		if !bytes.Equal(copied, data) {
+			t.Fatalf("copied data should be equal to the source data, copied size is [%d], source size is [%d]", len(copied), len(data))
+		}
+	}
+}
+
+func BenchmarkFileCopyOneStream(b *testing.B) {
+	benchmarkFileCopy(b, 1)
+}
+
+func BenchmarkFileCopyFourStreams(b *testing.B) {
+	benchmarkFileCopy(b, 4)
+}
+
+func benchmarkFileCopy(b *testing.B, streams int) {
+	beforeEach()
+	defer afterEach()
+
+	network, _ := api.NewNetwork(config.Network)
+	host, _ := network.Host(network.LocalIP())
+
+	root, _ := filepath.Abs("./")
+	file, _ := host.Create(
+		network.Transport(),
+		api.FileInfo{Name: "test.txt", Path: filepath.Join(root, "test.txt"), Type: api.FILE},
+		true,
+	)
+	defer file.Remove(network.Transport())
+
+	data := generate(64 * 1048576)
+	file.WriteFrom(network.Transport(), bytes.NewReader(data))
+
+	target := api.RemoteFile{
+		Host: *host,
+		Info: api.FileInfo{Id: api.FileId(filepath.Join(root, "test_bench.txt")), Name: "test_bench.txt", Path: filepath.Join(root, "test_bench.txt"), Type: api.FILE},
+	}
+	defer target.Remove(network.Transport())
+
+	b.SetBytes(int64(len(data)))
+	b.ReportAllocs()
+	b.ResetTimer()
+	for range b.N {
+		if _, err := file.CopyWith(network.Transport(), target, api.CopyOptions{Replace: true, Streams: streams}); err != nil {
+			b.Fatalf("error should be nil, but err is [%s]", err)
+		}
+		if err := waitCopy(network, host); err != nil {
+			b.Fatalf("error should be nil, but err is [%s]", err)
+		}
+	}
+}
+
+// The concurrent tasks share the buffers of the pool, so the allocations do not grow with the count of the tasks.
+func BenchmarkFileCopyConcurrentTasks(b *testing.B) {
+	beforeEach()
+	defer afterEach()
+
+	network, _ := api.NewNetwork(config.Network)
+	host, _ := network.Host(network.LocalIP())
+
+	root, _ := filepath.Abs("./")
+	sourcePath := filepath.Join(root, "test.txt")
+	data := generate(8 * 1048576)
+	os.WriteFile(sourcePath, data, 0666)
+	defer os.Remove(sourcePath)
+
+	tasks := 8
+	source := api.RemoteFile{Host: *host, Info: api.FileInfo{Id: api.FileId(sourcePath), Name: "test.txt", Path: sourcePath, Type: api.FILE}}
+	b.SetBytes(int64(len(data) * tasks))
+	b.ReportAllocs()
+	b.ResetTimer()
+	for range b.N {
+		for index := range tasks {
+			targetPath := filepath.Join(root, fmt.Sprintf("test_bench_%d.txt", index))
+			target := api.RemoteFile{Host: *host, Info: api.FileInfo{Name: filepath.Base(targetPath), Path: targetPath, Type: api.FILE}}
+			if _, err := source.CopyWith(network.Transport(), target, api.CopyOptions{Replace: true, Compression: api.CompressionNone}); err != nil {
+				b.Fatalf("error should be nil, but err is [%s]", err)
+			}
+		}
+		if err := waitCopy(network, host); err != nil {
+			b.Fatalf("error should be nil, but err is [%s]", err)
+		}
+	}
+
+	b.StopTimer()
+	for index := range tasks {
+		os.Remove(filepath.Join(root, fmt.Sprintf("test_bench_%d.txt", index)))
+	}
+}
+
+func TestFileExtractHandleSuccess(t *testing.T) {
+	beforeEach()
+	defer afterEach()
+
+	network, _ := api.NewNetwork(config.Network)
+	host := network.LocalHost()
+
+	root, _ := filepath.Abs("./")
+	dirPath := filepath.Join(root, "test_dir_extract")
+	os.MkdirAll(dirPath, 0777)
+	defer os.RemoveAll(dirPath)
+
+	compressed := &bytes.Buffer{}
+	compressor, _ := transport.NewCompressWriter(compressed, transport.CodecZstd)
+	io.Copy(compressor, createArchive(map[string]string{"test.txt": "TEST", "dir/test.txt": "DATA"}))
+	compressor.Close()
+
+	dir := api.RemoteFile{Host: host, Info: api.FileInfo{Id: api.FileId(dirPath), Path: dirPath, Type: api.DIRECTORY}}
+	if err := dir.Extract(network.Transport(), compressed, transport.CodecZstd); err != nil {
+		t.Fatalf("error should be nil, but err is [%s]", err)
+	}
+
+	for name, expected := range map[string]string{"test.txt": "TEST", "dir/test.txt": "DATA"} {
+		data, err := os.ReadFile(filepath.Join(dirPath, name))
+		if err != nil || string(data) != expected {
+			t.Fatalf("file [%s] should contain [%s], but data is [%s], err is [%v]", name, expected, data, err)
+		}
+	}
+
+	entries, _ := os.ReadDir(dirPath)
+	if len(entries) != 2 {
+		t.Fatalf("directory should contain [2] entries, but count is [%d]", len(entries))
+	}
+}
+
+func TestFileExtractHandleErrInvalidArgument(t *testing.T) {
+	beforeEach()
+	defer afterEach()
+
+	network, _ := api.NewNetwork(config.Network)
+	host := network.LocalHost()
+
+	root, _ := filepath.Abs("./")
+	dirPath := filepath.Join(root, "test_dir_extract")
+	os.MkdirAll(dirPath, 0777)
+	defer os.RemoveAll(dirPath)
+
+	archive := createArchive(map[string]string{"test.txt": "TEST", "../test_evil.txt": "EVIL"})
+	dir := api.RemoteFile{Host: host, Info: api.FileInfo{Id: api.FileId(dirPath), Path: dirPath, Type: api.DIRECTORY}}
+	if err := dir.Extract(network.Transport(), archive, transport.CodecNone); !errors.Is(err, api.ErrInvalidArgument) {
+		t.Fatalf("error should be [api.ErrInvalidArgument], but err is [%v]", err)
+	}
+
+	if _, err := os.Stat(filepath.Join(root, "test_evil.txt")); !errors.Is(err, fs.ErrNotExist) {
+		t.Fatal("file outside of the directory should not be created")
+	}
+	if entries, _ := os.ReadDir(dirPath); len(entries) != 0 {
+		t.Fatalf("directory should be empty, but count is [%d]", len(entries))
+	}
+}
+
+// Waits until all copy tasks of the host are finished.
+func waitCopy(network *api.Network, host *api.RemoteHost) error {
+	for range 1000 {
+		tasks, err := host.Tasks(network.Transport())
+		if err != nil {
+			return err
+		}
+		if len(tasks) == 0 {
+			return nil
+		}
+		for _, task := range tasks {
+			if task.Error != nil {
+				return task.Error
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	return errors.New("copying is not finished")
+}
+
+// Creates the directories with the files of the size.
+func createTree(path string, dirs int, files int, size int) {
+	data := generate(size)
+	for dir := range dirs {
+		dirPath := filepath.Join(path, fmt.Sprintf("dir_%d", dir))
+		os.MkdirAll(dirPath, 0777)
+		for file := range files {
+			os.WriteFile(filepath.Join(dirPath, fmt.Sprintf("file_%d.txt", file)), data, 0777)
+		}
+	}
+}
+
+func TestFileExtractHandleRollback(t *testing.T) {
+	beforeEach()
+	defer afterEach()
+
+	network, _ := api.NewNetwork(config.Network)
+	host := network.LocalHost()
+
+	root, _ := filepath.Abs("./")
+	dirPath := filepath.Join(root, "test_dir_extract")
+	os.MkdirAll(filepath.Join(dirPath, "busy", "test.txt"), 0777)
+	os.WriteFile(filepath.Join(dirPath, "test.txt"), []byte("OLD"), 0666)
+	defer os.RemoveAll(dirPath)
+
+	// The busy file is the last, so the moved files are restored.
+	archive := &bytes.Buffer{}
+	writer := tar.NewWriter(archive)
+	for _, name := range []string{"test.txt", "dir/test.txt", "busy/test.txt"} {
+		writer.WriteHeader(&tar.Header{Name: name, Mode: 0666, Size: 4, Typeflag: tar.TypeReg})
+		writer.Write([]byte("DATA"))
+	}
+	writer.Close()
+
+	dir := api.RemoteFile{Host: host, Info: api.FileInfo{Id: api.FileId(dirPath), Path: dirPath, Type: api.DIRECTORY}}
+	if err := dir.Extract(network.Transport(), archive, transport.CodecNone); !errors.Is(err, api.ErrFileAlreadyExists) {
+		t.Fatalf("error should be [api.ErrFileAlreadyExists], but err is [%v]", err)
+	}
+
+	if data, _ := os.ReadFile(filepath.Join(dirPath, "test.txt")); string(data) != "OLD" {
+		t.Fatalf("replaced file should be restored, but data is [%s]", data)
+	}
+	if _, err := os.Stat(filepath.Join(dirPath, "dir")); !errors.Is(err, fs.ErrNotExist) {
+		t.Fatal("created directory should be removed")
+	}
+	if entries, _ := os.ReadDir(dirPath); len(entries) != 2 {
+		t.Fatalf("directory should contain [2] entries, but count is [%d]", len(entries))
+	}
+}
+
+// Creates the tar archive with the files.
+func createArchive(files map[string]string) io.Reader {
+	buffer := &bytes.Buffer{}
+	archive := tar.NewWriter(buffer)
+	for name, data := range files {
+		archive.WriteHeader(&tar.Header{Name: name, Mode: 0666, Size: int64(len(data)), Typeflag: tar.TypeReg})
+		archive.Write([]byte(data))
+	}
+	archive.Close()
+	return buffer
+}
+
+func generate(size int) []byte {
+	result := make([]byte, size)
+	for i := range size {
+		result[i] = byte(1)
+	}
+	return result
+}
+
+func TestFileSearchHandleSuccess(t *testing.T) {
+	beforeEach()
+	defer afterEach()
+
+	network, _ := api.NewNetwork(config.Network)
+	host, _ := network.Host(network.LocalIP())
+
+	root, _ := filepath.Abs("./")
+	dirPath := filepath.Join(root, "test_search")
+	os.MkdirAll(filepath.Join(dirPath, "sub"), 0777)
+	defer os.RemoveAll(dirPath)
+	os.WriteFile(filepath.Join(dirPath, "small.txt"), generate(16), 0666)
+	os.WriteFile(filepath.Join(dirPath, "sub", "large.txt"), generate(4096), 0666)
+	os.WriteFile(filepath.Join(dirPath, "sub", "large.bin"), generate(4096), 0666)
+
+	dir := api.RemoteFile{Host: *host, Info: api.FileInfo{Id: api.FileId(dirPath), Path: dirPath, Type: api.DIRECTORY}}
+	search, err := dir.Search(network.Transport(), api.SearchQuery{Pattern: "*.txt", MinSize: 1024, After: time.Now().Add(-time.Hour)})
+	if err != nil {
+		t.Fatalf("error should be nil, but err is [%s]", err)
+	}
+
+	files, err := search.All()
+	if err != nil {
+		t.Fatalf("error should be nil, but err is [%s]", err)
+	}
+	if len(files) != 1 || files[0].Info.Name != "large.txt" || files[0].Info.Size != 4096 {
+		t.Fatalf("the only found file should be [large.txt], but files are [%v]", files)
+	}
+}
+
+func TestFileSearchHandleLimit(t *testing.T) {
+	beforeEach()
+	defer afterEach()
+
+	network, _ := api.NewNetwork(config.Network)
+	host, _ := network.Host(network.LocalIP())
+
+	root, _ := filepath.Abs("./")
+	dirPath := filepath.Join(root, "test_search")
+	os.MkdirAll(dirPath, 0777)
+	defer os.RemoveAll(dirPath)
+	for index := range 10 {
+		os.WriteFile(filepath.Join(dirPath, fmt.Sprintf("test_%d.txt", index)), generate(16), 0666)
+	}
+
+	dir := api.RemoteFile{Host: *host, Info: api.FileInfo{Id: api.FileId(dirPath), Path: dirPath, Type: api.DIRECTORY}}
+	search, err := dir.Search(network.Transport(), api.SearchQuery{Regexp: `^test_\d\.txt$`, Type: api.FILE, Limit: 3})
+	if err != nil {
+		t.Fatalf("error should be nil, but err is [%s]", err)
+	}
+
+	files, err := search.All()
+	if err != nil {
+		t.Fatalf("error should be nil, but err is [%s]", err)
+	}
+	if len(files) != 3 {
+		t.Fatalf("count of the found files should be [3], but count is [%d]", len(files))
+	}
+}
+
+func TestFileSearchHandleErrInvalidArgument(t *testing.T) {
+	beforeEach()
+	defer afterEach()
+
+	network, _ := api.NewNetwork(config.Network)
+	host, _ := network.Host(network.LocalIP())
+
+	root, _ := filepath.Abs("./")
+	dir := api.RemoteFile{Host: *host, Info: api.FileInfo{Id: api.FileId(root), Path: root, Type: api.DIRECTORY}}
+	_, err := dir.Search(network.Transport(), api.SearchQuery{Regexp: "(test"})
+	if !errors.Is(err, api.ErrInvalidArgument) {
+		t.Fatalf("error should be [api.ErrInvalidArgument], but err is [%v]", err)
+	}
+}
+
+func TestFileSearchHandleErrFileNotFound(t *testing.T) {
+	beforeEach()
+	defer afterEach()
+
+	network, _ := api.NewNetwork(config.Network)
+	host, _ := network.Host(network.LocalIP())
+
+	root, _ := filepath.Abs("./")
+	dirPath := filepath.Join(root, "test_search_not_found")
+	dir := api.RemoteFile{Host: *host, Info: api.FileInfo{Id: api.FileId(dirPath), Path: dirPath, Type: api.DIRECTORY}}
+	_, err := dir.Search(network.Transport(), api.SearchQuery{Pattern: "*"})
+	if !errors.Is(err, api.ErrFileNotFound) {
+		t.Fatalf("error should be [api.ErrFileNotFound], but err is [%v]", err)
+	}
+}
+
+func TestFileGrepHandleSuccess(t *testing.T) {
+	beforeEach()
+	defer afterEach()
+
+	network, _ := api.NewNetwork(config.Network)
+	host, _ := network.Host(network.LocalIP())
+
+	root, _ := filepath.Abs("./")
+	dirPath := filepath.Join(root, "test_grep")
+	os.MkdirAll(filepath.Join(dirPath, "sub"), 0777)
+	defer os.RemoveAll(dirPath)
+	os.WriteFile(filepath.Join(dirPath, "first.conf"), []byte("port=8080\nhost=localhost\n"), 0666)
+	os.WriteFile(filepath.Join(dirPath, "sub", "second.conf"), []byte("# comment\r\nHOST=remote\r\n"), 0666)
+	os.WriteFile(filepath.Join(dirPath, "sub", "binary.conf"), append([]byte("host=binary"), 0, 1, 2), 0666)
+	os.WriteFile(filepath.Join(dirPath, "sub", "large.conf"), append([]byte("host=large\n"), generate(4096)...), 0666)
+	os.WriteFile(filepath.Join(dirPath, "skipped.txt"), []byte("host=skipped\n"), 0666)
+
+	dir := api.RemoteFile{Host: *host, Info: api.FileInfo{Id: api.FileId(dirPath), Path: dirPath, Type: api.DIRECTORY}}
+	grep, err := dir.Grep(network.Transport(), api.GrepQuery{Pattern: "host=", IgnoreCase: true, Include: "*.conf", MaxSize: 1024})
+	if err != nil {
+		t.Fatalf("error should be nil, but err is [%s]", err)
+	}
+
+	matches, err := grep.All()
+	if err != nil {
+		t.Fatalf("error should be nil, but err is [%s]", err)
+	}
+	if len(matches) != 2 {
+		t.Fatalf("count of the found lines should be [2], but lines are [%v]", matches)
+	}
+	for _, expected := range []api.GrepMatch{{File: api.FileInfo{Name: "first.conf"}, Line: 2, Text: "host=localhost"}, {File: api.FileInfo{Name: "second.conf"}, Line: 2, Text: "HOST=remote"}} {
+		if !slices.ContainsFunc(matches, func(match api.GrepMatch) bool {
+			return match.File.Name == expected.File.Name && match.Line == expected.Line && match.Text == expected.Text && match.Host.IP.Equal(host.IP)
+		}) {
+			t.Fatalf("line [%d] of [%s] should be found, but lines are [%v]", expected.Line, expected.File.Name, matches)
+		}
+	}
+}
+
+func TestFileGrepHandleRegexpLimit(t *testing.T) {
+	beforeEach()
+	defer afterEach()
+
+	network, _ := api.NewNetwork(config.Network)
+	host, _ := network.Host(network.LocalIP())
+
+	root, _ := filepath.Abs("./")
+	dirPath := filepath.Join(root, "test_grep")
+	os.MkdirAll(dirPath, 0777)
+	defer os.RemoveAll(dirPath)
+	os.WriteFile(filepath.Join(dirPath, "test.log"), []byte("error 1\nwarning\nerror 2\nerror 3\n"), 0666)
+
+	dir := api.RemoteFile{Host: *host, Info: api.FileInfo{Id: api.FileId(dirPath), Path: dirPath, Type: api.DIRECTORY}}
+	grep, err := dir.Grep(network.Transport(), api.GrepQuery{Pattern: `^error \d$`, Regexp: true, Limit: 2})
+	if err != nil {
+		t.Fatalf("error should be nil, but err is [%s]", err)
+	}
+
+	matches, err := grep.All()
+	if err != nil {
+		t.Fatalf("error should be nil, but err is [%s]", err)
+	}
+	if len(matches) != 2 || matches[0].Line != 1 || matches[1].Line != 3 {
+		t.Fatalf("lines [1] and [3] should be found, but lines are [%v]", matches)
+	}
+}
+
+func TestFileGrepHandleErrInvalidArgument(t *testing.T) {
+	beforeEach()
+	defer afterEach()
+
+	network, _ := api.NewNetwork(config.Network)
+	host, _ := network.Host(network.LocalIP())
+
+	root, _ := filepath.Abs("./")
+	dir := api.RemoteFile{Host: *host, Info: api.FileInfo{Id: api.FileId(root), Path: root, Type: api.DIRECTORY}}
+	_, err := dir.Grep(network.Transport(), api.GrepQuery{Pattern: "(test", Regexp: true})
+	if !errors.Is(err, api.ErrInvalidArgument) {
+		t.Fatalf("error should be [api.ErrInvalidArgument], but err is [%v]", err)
+	}
+}
+
+func TestFileGrepHandleErrFileNotFound(t *testing.T) {
+	beforeEach()
+	defer afterEach()
+
+	network, _ := api.NewNetwork(config.Network)
+	host, _ := network.Host(network.LocalIP())
+
+	root, _ := filepath.Abs("./")
+	dirPath := filepath.Join(root, "test_grep_not_found")
+	dir := api.RemoteFile{Host: *host, Info: api.FileInfo{Id: api.FileId(dirPath), Path: dirPath, Type: api.DIRECTORY}}
+	_, err := dir.Grep(network.Transport(), api.GrepQuery{Pattern: "test"})
+	if !errors.Is(err, api.ErrFileNotFound) {
+		t.Fatalf("error should be [api.ErrFileNotFound], but err is [%v]", err)
 	}
-	return result
 }
@@ -0,0 +1,63 @@
+package server
+
+import (
+	"errors"
+	"io/fs"
+	"netfs/api"
+	"os"
+	"path/filepath"
+)
+
+// Statistics of the volume.
+type volumeStats struct {
+	Total      uint64
+	Free       uint64
+	Inodes     uint64
+	InodesFree uint64
+}
+
+// The function returns information about the volume which contains the path.
+// If the path does not exist, the nearest existing parent directory is used.
+func volumeInfo(path string) (*api.VolumeInfo, error) {
+	existing := path
+	for {
+		if _, err := os.Stat(existing); !errors.Is(err, os.ErrNotExist) {
+			break
+		}
+
+		parent := filepath.Dir(existing)
+		if parent == existing {
+			break
+		}
+		existing = parent
+	}
+
+	stats, err := volumeStat(existing)
+	if err == nil {
+		return &api.VolumeInfo{
+			Path:       path,
+			Total:      api.FileSize(stats.Total),
+			Free:       api.FileSize(stats.Free),
+			Used:       api.FileSize(stats.Total - min(stats.Free, stats.Total)),
+			Inodes:     stats.Inodes,
+			InodesFree: stats.InodesFree,
+			InodesUsed: stats.Inodes - min(stats.InodesFree, stats.Inodes),
+		}, nil
+	}
+	return nil, err
+}
+
+// The function returns the size of the file or the total size of the files in the directory.
+func pathSize(path string) (int64, error) {
+	size := int64(0)
+	err := filepath.WalkDir(path, func(path string, entry fs.DirEntry, err error) error {
+		if err == nil && entry.Type().IsRegular() {
+			var info fs.FileInfo
+			if info, err = entry.Info(); err == nil {
+				size += info.Size()
+			}
+		}
+		return err
+	})
+	return size, err
+}
@@ -0,0 +1,10 @@
+//go:build !linux && !darwin && !windows
+
+package server
+
+import "errors"
+
+// The function returns statistics of the volume which contains the path.
+func volumeStat(path string) (*volumeStats, error) {
+	return nil, errors.ErrUnsupported
+}
@@ -0,0 +1,21 @@
+//go:build linux || darwin
+
+package server
+
+import "syscall"
+
+// The function returns statistics of the volume which contains the path.
+func volumeStat(path string) (*volumeStats, error) {
+	stat := syscall.Statfs_t{}
+	err := syscall.Statfs(path, &stat)
+	if err == nil {
+		blockSize := uint64(stat.Bsize)
+		return &volumeStats{
+			Total:      uint64(stat.Blocks) * blockSize,
+			Free:       uint64(stat.Bavail) * blockSize,
+			Inodes:     uint64(stat.Files),
+			InodesFree: uint64(stat.Ffree),
+		}, nil
+	}
+	return nil, err
+}
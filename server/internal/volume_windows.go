@@ -0,0 +1,31 @@
+//go:build windows
+
+package server
+
+import (
+	"syscall"
+	"unsafe"
+)
+
+var getDiskFreeSpaceEx = syscall.NewLazyDLL("kernel32.dll").NewProc("GetDiskFreeSpaceExW")
+
+// The function returns statistics of the volume which contains the path.
+// NTFS has no fixed inode table, so the inode counts are always zero.
+func volumeStat(path string) (*volumeStats, error) {
+	var free, total, totalFree uint64
+
+	pathPtr, err := syscall.UTF16PtrFromString(path)
+	if err == nil {
+		result, _, callErr := getDiskFreeSpaceEx.Call(
+			uintptr(unsafe.Pointer(pathPtr)),
+			uintptr(unsafe.Pointer(&free)),
+			uintptr(unsafe.Pointer(&total)),
+			uintptr(unsafe.Pointer(&totalFree)),
+		)
+		if result == 0 {
+			return nil, callErr
+		}
+		return &volumeStats{Total: total, Free: free}, nil
+	}
+	return nil, err
+}
@@ -29,7 +29,11 @@ import (
 func main() {
 	network, err := api.NewNetwork(api.NetworkConfig{Port: 8989, Protocol: transport.HTTP, Timeout: time.Second * 1})
 	if err == nil {
-		program := tea.NewProgram(console.NewConsoleViewModel(network), tea.WithAltScreen())
+		watcher := api.NewHostWatcher(network, api.HostWatcherConfig{}) // TODO. from settings
+		watcher.Start()
+		defer watcher.Stop()
+
+		program := tea.NewProgram(console.NewConsoleViewModel(network, watcher), tea.WithAltScreen())
 
 		go func(program *tea.Program) {
 			time.Sleep(1 * time.Second) // TODO. from settings
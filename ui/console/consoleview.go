@@ -16,6 +16,11 @@ const QuitKeyMsg = "alt+q"
 const HostActiveKeyMsg = "alt+h"
 const FileActiveKeyMsg = "alt+f"
 const TaskActiveKeyMsg = "alt+t"
+const HistoryActiveKeyMsg = "alt+e"
+const DismissKeyMsg = "alt+d"
+const EscapeKeyMsg = "esc"
+const CommanderKeyMsg = "alt+m"
+const ViewerKeyMsg = "f3"
 
 type ConsoleActiveView uint8
 
@@ -23,6 +28,9 @@ const (
 	Host ConsoleActiveView = iota
 	File
 	Task
+	History
+	Viewer
+	Search
 )
 
 // The event sends after changing the terminal size.
@@ -37,21 +45,36 @@ type RefreshMsg struct{}
 // The event sends after switching to another view.
 type ChangeActiveViewMsg struct {
 	View ConsoleActiveView
+	// The previous view is activated after closing the history, the viewer or the search.
+	Back bool
 }
 
 // The main view of the UI.
 type ConsoleView struct {
-	hostsView  tea.Model
-	fileView   tea.Model
-	taskView   tea.Model
-	activeView ConsoleActiveView
-	style      lipgloss.Style
+	hostsView   tea.Model
+	fileViews   [2]tea.Model
+	taskView    tea.Model
+	historyView tea.Model
+	viewerView  tea.Model
+	searchView  tea.Model
+	statusBar   tea.Model
+	activeView  ConsoleActiveView
+	activePane  FilePane
+	// The views which are activated after closing the history, the viewer or the search,
+	// for example, the viewer opened from the search returns to the search.
+	prevViews []ConsoleActiveView
+	// Both panes of the file view are shown.
+	commander bool
+	width     int
+	height    int
+	style     lipgloss.Style
 }
 
 func (model ConsoleView) Init() tea.Cmd {
 	return tea.Sequence(
 		model.hostsView.Init(),
-		model.fileView.Init(),
+		model.fileViews[LeftPane].Init(),
+		model.fileViews[RightPane].Init(),
 		model.taskView.Init(),
 		func() tea.Msg { return ChangeActiveViewMsg{View: Host} },
 		tea.Every(3*time.Second, func(t time.Time) tea.Msg { return RefreshMsg{} }), // TODO. 3*time.Second - from settings
@@ -63,12 +86,16 @@ func (model ConsoleView) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var hostViewCmd tea.Cmd
 	var fileViewCmd tea.Cmd
 	var taskViewCmd tea.Cmd
+	var historyViewCmd tea.Cmd
+	var viewerViewCmd tea.Cmd
+	var searchViewCmd tea.Cmd
+	var statusBarCmd tea.Cmd
 
 	switch msg := msg.(type) {
 	case RefreshMsg:
 		cmd = tea.Every(3*time.Second, func(t time.Time) tea.Msg { return RefreshMsg{} }) // TODO. 3*time.Second - from settings
 		model.hostsView, hostViewCmd = model.hostsView.Update(msg)
-		model.fileView, fileViewCmd = model.fileView.Update(msg)
+		fileViewCmd = model.updateFileViews(msg)
 		model.taskView, taskViewCmd = model.taskView.Update(msg)
 
 	case tea.KeyMsg:
@@ -81,82 +108,243 @@ func (model ConsoleView) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return model, func() tea.Msg { return ChangeActiveViewMsg{View: File} }
 		case TaskActiveKeyMsg:
 			return model, func() tea.Msg { return ChangeActiveViewMsg{View: Task} }
+		case HistoryActiveKeyMsg:
+			if model.activeView == History {
+				return model, model.closeOverlay()
+			}
+			return model, func() tea.Msg { return ChangeActiveViewMsg{View: History} }
+		case DismissKeyMsg:
+			return model, func() tea.Msg { return DismissNotificationMsg{} }
+		case CommanderKeyMsg:
+			model.commander = !model.commander
+			if !model.commander && model.activePane != LeftPane {
+				cmd = func() tea.Msg { return ChangeActivePaneMsg{Pane: LeftPane} }
+			}
+			resizeCmd := model.resize()
+			return model, tea.Sequence(cmd, resizeCmd)
+		case EscapeKeyMsg:
+			if isOverlay(model.activeView) {
+				return model, model.closeOverlay()
+			}
+		// The search opens the viewer by the same key.
+		case ViewerKeyMsg:
+			if model.activeView == History || model.activeView == Viewer {
+				return model, model.closeOverlay()
+			}
 		}
 
 		switch model.activeView {
 		case Host:
 			model.hostsView, hostViewCmd = model.hostsView.Update(msg)
 		case File:
-			model.fileView, fileViewCmd = model.fileView.Update(msg)
+			model.fileViews[model.activePane], fileViewCmd = model.fileViews[model.activePane].Update(msg)
 		case Task:
 			model.taskView, taskViewCmd = model.taskView.Update(msg)
+		case History:
+			model.historyView, historyViewCmd = model.historyView.Update(msg)
+		case Viewer:
+			model.viewerView, viewerViewCmd = model.viewerView.Update(msg)
+		case Search:
+			model.searchView, searchViewCmd = model.searchView.Update(msg)
 		}
 
 	case ChangeActiveViewMsg:
-		switch msg.View {
-		case Host:
-			model.activeView = Host
-		case File:
-			model.activeView = File
-		case Task:
-			model.activeView = Task
+		switch {
+		case msg.Back && len(model.prevViews) > 0:
+			model.prevViews = model.prevViews[:len(model.prevViews)-1]
+		case isOverlay(msg.View):
+			if msg.View != model.activeView {
+				model.prevViews = append(model.prevViews, model.activeView)
+			}
+		default:
+			model.prevViews = nil
 		}
+		model.activeView = msg.View
 		model.hostsView, hostViewCmd = model.hostsView.Update(msg)
-		model.fileView, fileViewCmd = model.fileView.Update(msg)
+		fileViewCmd = model.updateFileViews(msg)
+		model.taskView, taskViewCmd = model.taskView.Update(msg)
+		model.searchView, searchViewCmd = model.searchView.Update(msg)
+
+	// The selected host is opened in the active pane.
+	case ChangeActiveHostMsg:
+		model.fileViews[model.activePane], fileViewCmd = model.fileViews[model.activePane].Update(msg)
 		model.taskView, taskViewCmd = model.taskView.Update(msg)
 
+	case ChangeActivePaneMsg:
+		if model.commander || msg.Pane == LeftPane {
+			model.activePane = msg.Pane
+			fileViewCmd = model.updateFileViews(msg)
+			// The tasks of the host of the active pane are shown.
+			if host := model.fileViews[msg.Pane].(FileView).Host(); host != nil {
+				model.taskView, taskViewCmd = model.taskView.Update(ChangeActiveHostMsg{Host: host})
+			}
+		}
+
+	case RequestTransferMsg:
+		cmd = model.transfer(msg)
+
+	case OpenViewerMsg:
+		cmd = func() tea.Msg { return ChangeActiveViewMsg{View: Viewer} }
+		model.viewerView, viewerViewCmd = model.viewerView.Update(msg)
+	case UpdateViewerMsg:
+		model.viewerView, viewerViewCmd = model.viewerView.Update(msg)
+
+	case OpenSearchMsg:
+		cmd = func() tea.Msg { return ChangeActiveViewMsg{View: Search} }
+		model.searchView, searchViewCmd = model.searchView.Update(msg)
+	case UpdateSearchMsg:
+		model.searchView, searchViewCmd = model.searchView.Update(msg)
+	// The directory of the found file is opened in the active pane.
+	case OpenDirMsg:
+		cmd = func() tea.Msg { return ChangeActiveViewMsg{View: File} }
+		model.fileViews[model.activePane], fileViewCmd = model.fileViews[model.activePane].Update(msg)
+
 	case tea.WindowSizeMsg:
 		frameX, frameY := model.style.GetFrameSize()
-		width := float32(msg.Width - frameX)
-		height := float32(msg.Height - frameY)
+		model.width = msg.Width - frameX
+		model.height = msg.Height - frameY
 		model.style = model.
 			style.
-			Width(int(width)).
-			Height(int(height))
+			Width(model.width).
+			Height(model.height)
 
-		// TODO. from settings?
-		hostViewWidth := (width / 100.0) * 30.0
-		fileViewWidth := int(width - hostViewWidth)
-		fileViewHeight := int((height / 100.0) * 70.0)
-
-		model.hostsView, hostViewCmd = model.hostsView.Update(ResizeMsg{Width: int(hostViewWidth), Height: int(height)})
-		model.fileView, fileViewCmd = model.fileView.Update(ResizeMsg{Width: fileViewWidth, Height: fileViewHeight})
-		model.taskView, taskViewCmd = model.taskView.Update(ResizeMsg{Width: fileViewWidth, Height: int(height) - fileViewHeight})
+		cmd = model.resize()
+	case NotificationMsg:
+		model.statusBar, statusBarCmd = model.statusBar.Update(msg)
+		model.historyView, historyViewCmd = model.historyView.Update(msg)
+	case ExpireNotificationMsg, DismissNotificationMsg:
+		model.statusBar, statusBarCmd = model.statusBar.Update(msg)
 	default:
 		model.hostsView, hostViewCmd = model.hostsView.Update(msg)
-		model.fileView, fileViewCmd = model.fileView.Update(msg)
+		fileViewCmd = model.updateFileViews(msg)
 		model.taskView, taskViewCmd = model.taskView.Update(msg)
-
-		return model, tea.Sequence(cmd, hostViewCmd, fileViewCmd, taskViewCmd)
 	}
 
-	return model, tea.Sequence(cmd, hostViewCmd, fileViewCmd, taskViewCmd)
+	return model, tea.Sequence(cmd, hostViewCmd, fileViewCmd, taskViewCmd, historyViewCmd, viewerViewCmd, searchViewCmd, statusBarCmd)
 }
 
 func (model ConsoleView) View() string {
+	files := model.fileViews[LeftPane].View()
+	if model.commander {
+		files = lipgloss.JoinHorizontal(lipgloss.Top, files, model.fileViews[RightPane].View())
+	}
+
+	content := lipgloss.JoinVertical(
+		lipgloss.Left,
+		files,
+		model.taskView.View(),
+	)
+	switch model.activeView {
+	case History:
+		content = model.historyView.View()
+	case Viewer:
+		content = model.viewerView.View()
+	case Search:
+		content = model.searchView.View()
+	}
+
 	return model.style.Render(
-		lipgloss.JoinHorizontal(
-			lipgloss.Top,
-			model.hostsView.View(),
-			lipgloss.JoinVertical(
-				lipgloss.Left,
-				model.fileView.View(),
-				model.taskView.View(),
+		lipgloss.JoinVertical(
+			lipgloss.Left,
+			lipgloss.JoinHorizontal(
+				lipgloss.Top,
+				model.hostsView.View(),
+				content,
 			),
+			model.statusBar.View(),
 		),
 	)
 }
 
+// The function sends the event to both panes of the file view.
+func (model *ConsoleView) updateFileViews(msg tea.Msg) tea.Cmd {
+	var leftCmd tea.Cmd
+	var rightCmd tea.Cmd
+	model.fileViews[LeftPane], leftCmd = model.fileViews[LeftPane].Update(msg)
+	model.fileViews[RightPane], rightCmd = model.fileViews[RightPane].Update(msg)
+	return tea.Sequence(leftCmd, rightCmd)
+}
+
+// The function changes sizes of all views by the size of the terminal.
+// In the commander mode the panes of the file view divide its width.
+func (model *ConsoleView) resize() tea.Cmd {
+	var hostViewCmd tea.Cmd
+	var fileViewCmd tea.Cmd
+	var taskViewCmd tea.Cmd
+	var historyViewCmd tea.Cmd
+	var viewerViewCmd tea.Cmd
+	var searchViewCmd tea.Cmd
+	var statusBarCmd tea.Cmd
+
+	width := float32(model.width)
+	height := float32(model.height - 1)
+	model.statusBar, statusBarCmd = model.statusBar.Update(ResizeMsg{Width: model.width, Height: 1})
+
+	// TODO. from settings?
+	hostViewWidth := (width / 100.0) * 30.0
+	fileViewWidth := int(width - hostViewWidth)
+	fileViewHeight := int((height / 100.0) * 70.0)
+
+	model.hostsView, hostViewCmd = model.hostsView.Update(ResizeMsg{Width: int(hostViewWidth), Height: int(height)})
+	if model.commander {
+		var rightCmd tea.Cmd
+		model.fileViews[LeftPane], fileViewCmd = model.fileViews[LeftPane].Update(ResizeMsg{Width: fileViewWidth / 2, Height: fileViewHeight})
+		model.fileViews[RightPane], rightCmd = model.fileViews[RightPane].Update(ResizeMsg{Width: fileViewWidth - fileViewWidth/2, Height: fileViewHeight})
+		fileViewCmd = tea.Sequence(fileViewCmd, rightCmd)
+	} else {
+		model.fileViews[LeftPane], fileViewCmd = model.fileViews[LeftPane].Update(ResizeMsg{Width: fileViewWidth, Height: fileViewHeight})
+	}
+	model.taskView, taskViewCmd = model.taskView.Update(ResizeMsg{Width: fileViewWidth, Height: int(height) - fileViewHeight})
+	model.historyView, historyViewCmd = model.historyView.Update(ResizeMsg{Width: fileViewWidth, Height: int(height)})
+	model.viewerView, viewerViewCmd = model.viewerView.Update(ResizeMsg{Width: fileViewWidth, Height: int(height)})
+	model.searchView, searchViewCmd = model.searchView.Update(ResizeMsg{Width: fileViewWidth, Height: int(height)})
+
+	return tea.Sequence(hostViewCmd, fileViewCmd, taskViewCmd, historyViewCmd, viewerViewCmd, searchViewCmd, statusBarCmd)
+}
+
+// The function returns true if the view is shown instead of the file and task views.
+func isOverlay(view ConsoleActiveView) bool {
+	return view == History || view == Viewer || view == Search
+}
+
+// The function returns the command which closes the history, the viewer or the search
+// and activates the view which was active before it.
+func (model ConsoleView) closeOverlay() tea.Cmd {
+	if len(model.prevViews) == 0 {
+		return func() tea.Msg { return ChangeActiveViewMsg{View: File} }
+	}
+	view := model.prevViews[len(model.prevViews)-1]
+	return func() tea.Msg { return ChangeActiveViewMsg{View: view, Back: true} }
+}
+
+// The function returns the command which copies or moves the selected file of the pane
+// to the current directory of the other pane.
+func (model ConsoleView) transfer(msg RequestTransferMsg) tea.Cmd {
+	if !model.commander {
+		return notify(NotificationWarning, "Copying to the other pane requires the commander mode ("+CommanderKeyMsg+")", nil, false)
+	}
+
+	dir := model.fileViews[msg.Pane.Other()].(FileView).CurrentDir()
+	if dir == nil {
+		return notify(NotificationWarning, "Open a directory in the other pane", nil, false)
+	}
+	return func() tea.Msg { return TransferFileMsg{Pane: msg.Pane, Dir: dir, Move: msg.Move} }
+}
+
 // The function returns new instance of ConsoleView.
-func NewConsoleViewModel(network *api.Network) tea.Model {
+func NewConsoleViewModel(network *api.Network, watcher *api.HostWatcher) tea.Model {
 	style := lipgloss.
 		NewStyle().
 		Align(lipgloss.Left, lipgloss.Left)
 
 	return ConsoleView{
-		hostsView: NewHostView(network),
-		fileView:  NewFileView(network),
-		taskView:  NewTaskView(network),
-		style:     style,
+		hostsView:   NewHostView(network, watcher),
+		fileViews:   [2]tea.Model{NewFileView(network, LeftPane), NewFileView(network, RightPane)},
+		taskView:    NewTaskView(network, watcher),
+		historyView: NewHistoryView(),
+		viewerView:  NewViewerView(network),
+		searchView:  NewSearchView(network),
+		statusBar:   NewStatusBar(),
+		style:       style,
 	}
 }
@@ -1,9 +1,16 @@
 package console
 
 import (
+	"cmp"
+	"errors"
+	"fmt"
 	"io"
 	"netfs/api"
 	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/charmbracelet/bubbles/list"
 	tea "github.com/charmbracelet/bubbletea"
@@ -13,28 +20,298 @@ import (
 const COUNT_MAX_LEN = 5
 const COLUMN_TYPE_WIDTH = 5
 const COLUMN_SIZE_WIDTH = 15
+const COLUMN_MODIFIED_WIDTH = 17
+
+// The modification time is shown only if the name column keeps this width.
+const COLUMN_NAME_MIN_WIDTH = 20
 const TOO_LONG_LINE_POSTFIX = "..."
 
 var TOO_LONG_LINE_POSTFIX_WIDTH = lipgloss.Width(TOO_LONG_LINE_POSTFIX)
 
+// The pane of the file view, the second pane is shown in the commander mode.
+type FilePane uint8
+
+const (
+	LeftPane FilePane = iota
+	RightPane
+)
+
+// Returns the opposite pane.
+func (pane FilePane) Other() FilePane {
+	if pane == LeftPane {
+		return RightPane
+	}
+	return LeftPane
+}
+
+// The field of the file by which the files are sorted.
+type FileSort uint8
+
+const (
+	SortByName FileSort = iota
+	SortBySize
+	SortByTime
+	SortByType
+)
+
+// Returns a string representation of the sort field.
+func (fileSort FileSort) String() string {
+	switch fileSort {
+	case SortBySize:
+		return "size"
+	case SortByTime:
+		return "time"
+	case SortByType:
+		return "type"
+	default:
+		return "name"
+	}
+}
+
+// The order of the files of the pane, the settings are kept while the console is running.
+type FileViewSettings struct {
+	Sort      FileSort
+	Reverse   bool
+	DirsFirst bool
+	// The files whose names start with a dot are shown.
+	Hidden bool
+}
+
+// Returns a string representation of the settings.
+func (settings FileViewSettings) String() string {
+	order := "↑"
+	if settings.Reverse {
+		order = "↓"
+	}
+
+	parts := []string{"by " + settings.Sort.String() + order}
+	if settings.DirsFirst {
+		parts = append(parts, "dirs first")
+	}
+	if !settings.Hidden {
+		parts = append(parts, "no hidden")
+	}
+	return strings.Join(parts, ", ")
+}
+
+// The function returns the visible files in the order of the settings.
+func (settings FileViewSettings) Arrange(items []list.Item) []list.Item {
+	result := make([]list.Item, 0, len(items))
+	for _, item := range items {
+		if settings.Hidden || !strings.HasPrefix(item.(*FileViewItem).File.Info.Name, ".") {
+			result = append(result, item)
+		}
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		left := result[i].(*FileViewItem).File.Info
+		right := result[j].(*FileViewItem).File.Info
+		if settings.DirsFirst && left.Type != right.Type {
+			return left.Type == api.DIRECTORY
+		}
+
+		compare := 0
+		switch settings.Sort {
+		case SortBySize:
+			compare = cmp.Compare(left.Size, right.Size)
+		case SortByTime:
+			compare = left.ModTime.Compare(right.ModTime)
+		case SortByType:
+			compare = cmp.Compare(strings.ToLower(filepath.Ext(left.Name)), strings.ToLower(filepath.Ext(right.Name)))
+		}
+		if compare == 0 {
+			compare = cmp.Compare(strings.ToLower(left.Name), strings.ToLower(right.Name))
+		}
+
+		if settings.Reverse {
+			return compare > 0
+		}
+		return compare < 0
+	})
+	return result
+}
+
+// The event sends after receiving the files of the directory.
 type UpdateFilesMsg struct {
+	Pane  FilePane
 	Items []list.Item
+	// The directory of the files and its node of the history.
+	Dir   *api.RemoteFile
+	Prev  *FileViewHistoryNode
+	Error error
+}
+
+// The event sends to receive the files of the current directory of all panes again.
+type RefreshFilesMsg struct{}
+
+type UpdateVolumeMsg struct {
+	Pane   FilePane
+	Volume *api.VolumeInfo
+	Error  error
+}
+
+// The event sends after switching to another pane.
+type ChangeActivePaneMsg struct {
+	Pane FilePane
+}
+
+// The event sends to copy or move the selected file of the pane to the current directory of the other pane.
+type RequestTransferMsg struct {
+	Pane FilePane
+	Move bool
+}
+
+// The event sends to copy or move the marked files or the selected file to the directory.
+type TransferFileMsg struct {
+	Pane FilePane
+	Dir  *api.RemoteFile
+	Move bool
+}
+
+// The event sends to confirm copying or moving of several files.
+type OpenTransferModalMsg struct {
+	Pane  FilePane
+	Files []*api.RemoteFile
+	Dir   *api.RemoteFile
+	Move  bool
+}
+
+type CloseTransferModalMsg struct {
+	Pane   FilePane
+	Action string
+	Files  []*api.RemoteFile
+	Dir    *api.RemoteFile
+	Move   bool
 }
 
 type OpenCopyFileModalMsg struct {
+	Pane FilePane
 	File *api.RemoteFile
+	// The copied file and the directory of the target file.
+	Source *api.RemoteFile
+	Dir    *api.RemoteFile
+	Move   bool
 }
 
 type CloseCopyFileModalMsg struct {
+	Pane   FilePane
 	Action string
+	Source *api.RemoteFile
+	Dir    *api.RemoteFile
+	Move   bool
 }
 
 type OpenDeleteFileModalMsg struct {
-	File *api.RemoteFile
+	Pane  FilePane
+	Files []*api.RemoteFile
 }
 
 type CloseDeleteFileModalMsg struct {
+	Pane   FilePane
+	Action string
+	Files  []*api.RemoteFile
+}
+
+// The event sends to mark or unmark the files which match the pattern.
+type OpenMarkModalMsg struct {
+	Pane FilePane
+	Mark bool
+}
+
+type CloseMarkModalMsg struct {
+	Pane   FilePane
+	Action string
+	Mark   bool
+}
+
+type OpenMakeDirModalMsg struct {
+	Pane FilePane
+	Dir  *api.RemoteFile
+}
+
+type CloseMakeDirModalMsg struct {
+	Pane   FilePane
+	Action string
+	Dir    *api.RemoteFile
+}
+
+type OpenMakeFileModalMsg struct {
+	Pane FilePane
+	Dir  *api.RemoteFile
+}
+
+type CloseMakeFileModalMsg struct {
+	Pane   FilePane
+	Action string
+	Dir    *api.RemoteFile
+}
+
+type OpenRenameModalMsg struct {
+	Pane FilePane
+	File *api.RemoteFile
+}
+
+type CloseRenameModalMsg struct {
+	Pane   FilePane
 	Action string
+	File   *api.RemoteFile
+}
+
+// The event sends to enter the query of the search in the directory.
+type OpenSearchModalMsg struct {
+	Pane FilePane
+	Dir  *api.RemoteFile
+}
+
+type CloseSearchModalMsg struct {
+	Pane   FilePane
+	Action string
+	Dir    *api.RemoteFile
+}
+
+// The function returns the pane of the event or false if the event is not sent to the certain pane.
+func filePane(msg tea.Msg) (FilePane, bool) {
+	switch msg := msg.(type) {
+	case UpdateFilesMsg:
+		return msg.Pane, true
+	case UpdateVolumeMsg:
+		return msg.Pane, true
+	case TransferFileMsg:
+		return msg.Pane, true
+	case OpenTransferModalMsg:
+		return msg.Pane, true
+	case CloseTransferModalMsg:
+		return msg.Pane, true
+	case OpenMarkModalMsg:
+		return msg.Pane, true
+	case CloseMarkModalMsg:
+		return msg.Pane, true
+	case OpenCopyFileModalMsg:
+		return msg.Pane, true
+	case CloseCopyFileModalMsg:
+		return msg.Pane, true
+	case OpenDeleteFileModalMsg:
+		return msg.Pane, true
+	case CloseDeleteFileModalMsg:
+		return msg.Pane, true
+	case OpenMakeDirModalMsg:
+		return msg.Pane, true
+	case CloseMakeDirModalMsg:
+		return msg.Pane, true
+	case OpenMakeFileModalMsg:
+		return msg.Pane, true
+	case CloseMakeFileModalMsg:
+		return msg.Pane, true
+	case OpenRenameModalMsg:
+		return msg.Pane, true
+	case CloseRenameModalMsg:
+		return msg.Pane, true
+	case OpenSearchModalMsg:
+		return msg.Pane, true
+	case CloseSearchModalMsg:
+		return msg.Pane, true
+	}
+	return 0, false
 }
 
 type FileViewHistoryNode struct {
@@ -44,6 +321,8 @@ type FileViewHistoryNode struct {
 
 type FileViewItem struct {
 	File *api.RemoteFile
+	// The file is marked for the bulk operation.
+	Marked bool
 }
 
 func (item FileViewItem) Title() string       { return item.File.Info.Name }
@@ -54,8 +333,10 @@ type FileViewItemDelegate struct {
 	columnTypeStyle   lipgloss.Style
 	columnNameStyle   lipgloss.Style
 	columnSizeStyle   lipgloss.Style
+	columnTimeStyle   lipgloss.Style
 	itemStyle         lipgloss.Style
 	itemSelectedStyle lipgloss.Style
+	itemMarkedStyle   lipgloss.Style
 	isActive          bool
 }
 
@@ -66,6 +347,10 @@ func (delegate FileViewItemDelegate) Render(writer io.Writer, model list.Model,
 	}
 
 	fileItem := item.(*FileViewItem)
+	if fileItem.Marked {
+		style = style.Inherit(delegate.itemMarkedStyle)
+	}
+
 	nameColumn := fileItem.File.Info.Name
 	nameWidth := delegate.columnNameStyle.GetWidth()
 	if lipgloss.Width(nameColumn) > nameWidth {
@@ -75,18 +360,20 @@ func (delegate FileViewItemDelegate) Render(writer io.Writer, model list.Model,
 			Render(nameColumn) + TOO_LONG_LINE_POSTFIX
 	}
 
-	writer.Write(
-		[]byte(
-			style.Render(
-				lipgloss.JoinHorizontal(
-					lipgloss.Left,
-					delegate.columnTypeStyle.Render(fileItem.File.Info.Type.String()),
-					delegate.columnNameStyle.Render(nameColumn),
-					delegate.columnSizeStyle.Render(fileItem.File.Info.Size.String()),
-				),
-			),
-		),
-	)
+	columns := []string{
+		delegate.columnTypeStyle.Render(fileItem.File.Info.Type.String()),
+		delegate.columnNameStyle.Render(nameColumn),
+		delegate.columnSizeStyle.Render(fileItem.File.Info.Size.String()),
+	}
+	if delegate.columnTimeStyle.GetWidth() > 0 {
+		modified := ""
+		if !fileItem.File.Info.ModTime.IsZero() {
+			modified = fileItem.File.Info.ModTime.Local().Format("2006-01-02 15:04")
+		}
+		columns = append(columns, delegate.columnTimeStyle.Render(modified))
+	}
+
+	writer.Write([]byte(style.Render(lipgloss.JoinHorizontal(lipgloss.Left, columns...))))
 }
 
 func (FileViewItemDelegate) Height() int { return 1 }
@@ -98,14 +385,26 @@ func (FileViewItemDelegate) Update(msg tea.Msg, m *list.Model) tea.Cmd {
 }
 
 type FileView struct {
-	modal    tea.Model
-	list     list.Model
-	delegate *FileViewItemDelegate
-	style    lipgloss.Style
-	prev     *FileViewHistoryNode
-	host     *api.RemoteHost
-	network  *api.Network
-	toCopy   *api.RemoteFile
+	modal       tea.Model
+	list        list.Model
+	delegate    *FileViewItemDelegate
+	style       lipgloss.Style
+	headerStyle lipgloss.Style
+	footerStyle lipgloss.Style
+	prev        *FileViewHistoryNode
+	host        *api.RemoteHost
+	network     *api.Network
+	toCopy      []*api.RemoteFile
+	volume      *api.VolumeInfo
+	// All files of the current directory, the list shows them by the settings.
+	items    []list.Item
+	settings FileViewSettings
+	// The last query of the search, it is offered in the next search.
+	search string
+	pane   FilePane
+	// The file view is active and its pane is active.
+	viewActive bool
+	paneActive bool
 }
 
 func (model FileView) Init() tea.Cmd {
@@ -119,92 +418,330 @@ func (model FileView) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var listCmd tea.Cmd
 	var modalCmd tea.Cmd
 
+	// The events of another pane are skipped.
+	if pane, ok := filePane(msg); ok && pane != model.pane {
+		return model, nil
+	}
+
 	modal := model.modal.(*Modal)
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
+		// The filter receives all keys while it is edited.
+		if !modal.GetVisibled() && model.list.SettingFilter() {
+			break
+		}
+
 		if !modal.GetVisibled() {
 			switch msg.Type {
 			// Enter to the selected directory.
 			case tea.KeyEnter:
-				item := model.list.SelectedItem()
-				file := item.(*FileViewItem).File
-				if file.Info.Type == api.DIRECTORY {
-					prev := FileViewHistoryNode{Item: item, Prev: model.prev}
-					model.prev = &prev
-					cmd = model.resolveFileChildren(file)
+				if item, ok := model.list.SelectedItem().(*FileViewItem); ok && item.File.Info.Type == api.DIRECTORY {
+					cmd = model.resolveFileChildren(item.File, &FileViewHistoryNode{Item: item, Prev: model.prev})
 				}
 			case tea.KeyBackspace:
 				// Exit to the root directory of the selected host.
 				if msg.Alt {
 					cmd = func() tea.Msg { return ChangeActiveHostMsg{Host: model.host} }
 					// Exit from the selected directory.
-				} else if model.prev.Prev != nil {
-					model.prev = model.prev.Prev
-					item := model.prev.Item
-					if item == nil {
+				} else if model.prev != nil && model.prev.Prev != nil {
+					prev := model.prev.Prev
+					if prev.Item == nil {
 						cmd = func() tea.Msg { return ChangeActiveHostMsg{Host: model.host} }
 					} else {
-						cmd = model.resolveFileChildren(item.(*FileViewItem).File)
+						cmd = model.resolveFileChildren(prev.Item.(*FileViewItem).File, prev)
 					}
 				}
-			// Marks the file for copying.
+			// Switches to the other pane.
+			case tea.KeyTab:
+				pane := model.pane.Other()
+				cmd = func() tea.Msg { return ChangeActivePaneMsg{Pane: pane} }
+			// Marks or unmarks the selected file and moves to the next one.
+			case tea.KeySpace, tea.KeyInsert:
+				if item, ok := model.list.SelectedItem().(*FileViewItem); ok {
+					item.Marked = !item.Marked
+					model.list.CursorDown()
+				}
+			// Remembers the files for copying.
 			case tea.KeyCtrlC:
-				item := model.list.SelectedItem()
-				if _, ok := item.(*FileViewItem); ok {
-					model.toCopy = item.(*FileViewItem).File
+				if files := model.selection(); len(files) > 0 {
+					model.toCopy = files
+					cmd = notify(NotificationSuccess, strconv.Itoa(len(files))+" file(s) to copy", nil, false)
 				}
 			// Starts the file copying.
 			case tea.KeyCtrlV:
-				if model.toCopy != nil && model.prev.Item != nil {
-					cmd = model.copyFile(false)
+				if dir := model.CurrentDir(); len(model.toCopy) == 1 && dir != nil {
+					cmd = tea.Sequence(model.copyFile(model.toCopy[0], dir, false, false), refreshFiles)
+				} else if len(model.toCopy) > 1 && dir != nil {
+					pane := model.pane
+					files := model.toCopy
+					cmd = func() tea.Msg { return OpenTransferModalMsg{Pane: pane, Files: files, Dir: dir} }
+				}
+			case tea.KeyRunes:
+				pane := model.pane
+				switch string(msg.Runes) {
+				// Marks the files by the pattern.
+				case "+":
+					cmd = func() tea.Msg { return OpenMarkModalMsg{Pane: pane, Mark: true} }
+				// Unmarks the files by the pattern.
+				case "-":
+					cmd = func() tea.Msg { return OpenMarkModalMsg{Pane: pane, Mark: false} }
+				// Inverts the marks.
+				case "*":
+					for _, item := range model.list.VisibleItems() {
+						item.(*FileViewItem).Marked = !item.(*FileViewItem).Marked
+					}
+				// Shows or hides the hidden files.
+				case ".":
+					model.settings.Hidden = !model.settings.Hidden
+					cmd = model.arrange()
+				}
+			// Sorts the files by the next field.
+			case tea.KeyCtrlS:
+				model.settings.Sort = (model.settings.Sort + 1) % (SortByType + 1)
+				cmd = model.arrange()
+			// Reverses the order of the files.
+			case tea.KeyCtrlR:
+				model.settings.Reverse = !model.settings.Reverse
+				cmd = model.arrange()
+			// Shows the directories before the files or mixes them.
+			case tea.KeyCtrlD:
+				model.settings.DirsFirst = !model.settings.DirsFirst
+				cmd = model.arrange()
+			// Searches the files in the current directory and its subdirectories.
+			case tea.KeyCtrlF:
+				if model.host == nil {
+					cmd = notify(NotificationWarning, "Open a host to search files", nil, false)
+				} else if !model.host.Supports(api.FeatureSearch) {
+					cmd = notify(NotificationWarning, "Host "+model.host.Name+" can't search files", nil, false)
+				} else {
+					pane := model.pane
+					dir := model.host.Root()
+					if current := model.CurrentDir(); current != nil {
+						dir = current
+					}
+					cmd = func() tea.Msg { return OpenSearchModalMsg{Pane: pane, Dir: dir} }
+				}
+			// Opens the selected file in the viewer.
+			case tea.KeyF3:
+				if item, ok := model.list.SelectedItem().(*FileViewItem); ok && item.File.Info.Type == api.FILE {
+					cmd = func() tea.Msg { return OpenViewerMsg{File: item.File} }
+				}
+			// Copies or moves the selected file to the other pane.
+			case tea.KeyF5, tea.KeyF6:
+				pane := model.pane
+				move := msg.Type == tea.KeyF6
+				cmd = func() tea.Msg { return RequestTransferMsg{Pane: pane, Move: move} }
+			case tea.KeyF7:
+				if dir := model.CurrentDir(); dir != nil {
+					pane := model.pane
+					cmd = func() tea.Msg { return OpenMakeDirModalMsg{Pane: pane, Dir: dir} }
+				} else {
+					cmd = notify(NotificationWarning, "Open a directory to create a new one", nil, false)
 				}
-			case tea.KeyDelete:
-				item := model.list.SelectedItem()
-				if _, ok := item.(*FileViewItem); ok {
+			// Creates a new empty file in the current directory.
+			case tea.KeyCtrlN:
+				if dir := model.CurrentDir(); dir != nil {
+					pane := model.pane
+					cmd = func() tea.Msg { return OpenMakeFileModalMsg{Pane: pane, Dir: dir} }
+				} else {
+					cmd = notify(NotificationWarning, "Open a directory to create a new file", nil, false)
+				}
+			// Renames the selected file, the shared directories can't be renamed.
+			case tea.KeyF2:
+				if item, ok := model.list.SelectedItem().(*FileViewItem); ok {
+					if model.CurrentDir() == nil {
+						cmd = notify(NotificationWarning, "Shared directories can't be renamed", nil, false)
+					} else if !item.File.Host.Supports(api.FeatureRename) {
+						cmd = notify(NotificationWarning, "Host "+item.File.Host.Name+" can't rename files", nil, false)
+					} else {
+						pane := model.pane
+						cmd = func() tea.Msg { return OpenRenameModalMsg{Pane: pane, File: item.File} }
+					}
+				}
+			case tea.KeyDelete, tea.KeyF8:
+				if files := model.selection(); len(files) > 0 {
+					pane := model.pane
 					cmd = func() tea.Msg {
-						return OpenDeleteFileModalMsg{File: item.(*FileViewItem).File}
+						return OpenDeleteFileModalMsg{Pane: pane, Files: files}
 					}
 				}
 			}
 		}
 	case ChangeActiveHostMsg:
-		model.prev = &FileViewHistoryNode{}
-		model.host = msg.Host
-		cmd = model.resolveFileChildren(msg.Host.Root())
+		cmd = model.resolveFileChildren(msg.Host.Root(), &FileViewHistoryNode{})
+	// The current directory is changed only if its files are received,
+	// so the failed directory is not shown as an empty one.
 	case UpdateFilesMsg:
-		cmd = model.list.SetItems(msg.Items)
+		if msg.Error != nil {
+			cmd = notify(NotificationError, "Failed to open "+msg.Dir.Host.Name+":"+msg.Dir.Info.Path, msg.Error, false)
+		} else {
+			// The marks and the filter are kept if the current directory is received again.
+			if dir := model.CurrentDir(); dir != nil && dir.Info.Id == msg.Dir.Info.Id && dir.Host.IP.Equal(msg.Dir.Host.IP) {
+				keepMarks(model.items, msg.Items)
+			} else {
+				model.list.ResetFilter()
+			}
+			model.prev = msg.Prev
+			model.host = &msg.Dir.Host
+			model.items = msg.Items
+			cmd = model.arrange()
+			footerCmd = model.resolveVolume(msg.Dir)
+		}
+	case RefreshFilesMsg:
+		cmd = model.refreshFiles()
+	case UpdateVolumeMsg:
+		model.volume = msg.Volume
+		if msg.Error != nil {
+			cmd = notify(NotificationWarning, "Failed to get the volume", msg.Error, false)
+		}
+	case TransferFileMsg:
+		if files := model.selection(); len(files) == 1 {
+			cmd = tea.Sequence(model.copyFile(files[0], msg.Dir, false, msg.Move), refreshFiles)
+		} else if len(files) > 1 {
+			cmd = func() tea.Msg {
+				return OpenTransferModalMsg{Pane: msg.Pane, Files: files, Dir: msg.Dir, Move: msg.Move}
+			}
+		}
+	case OpenTransferModalMsg:
+		action := "Copy "
+		if msg.Move {
+			action = "Move "
+		}
+		modal.SetVisibled(true)
+		modal.SetTitle(action + filesTitle(msg.Files) + " to " + lipgloss.NewStyle().Foreground(lipgloss.Color("#3b82f6")).Render(msg.Dir.Host.Name+":"+msg.Dir.Info.Path) + "?")
+		modal.SetButtons([]ModalButton{
+			{"Yes(Y)", "Y", func() tea.Msg {
+				return CloseTransferModalMsg{Pane: msg.Pane, Action: "Yes", Files: msg.Files, Dir: msg.Dir, Move: msg.Move}
+			}},
+			{"Replace(R)", "R", func() tea.Msg {
+				return CloseTransferModalMsg{Pane: msg.Pane, Action: "Replace", Files: msg.Files, Dir: msg.Dir, Move: msg.Move}
+			}},
+			{"Cancel(C)", "C", func() tea.Msg { return CloseTransferModalMsg{Pane: msg.Pane, Action: "Cancel"} }},
+		})
+	case CloseTransferModalMsg:
+		modal.SetVisibled(false)
+		if msg.Action != "Cancel" {
+			model.unmark()
+			cmd = tea.Sequence(model.copyFiles(msg.Files, msg.Dir, msg.Action == "Replace", msg.Move), refreshFiles)
+		}
+	case OpenMarkModalMsg:
+		title := "Mark files by pattern"
+		if !msg.Mark {
+			title = "Unmark files by pattern"
+		}
+		modal.SetVisibled(true)
+		modal.SetTitle(title)
+		modal.SetButtons([]ModalButton{
+			{"Ok", "", func() tea.Msg { return CloseMarkModalMsg{Pane: msg.Pane, Action: "Ok", Mark: msg.Mark} }},
+			{"Cancel", "", func() tea.Msg { return CloseMarkModalMsg{Pane: msg.Pane, Action: "Cancel"} }},
+		})
+		cmd = modal.SetInput("*")
+	case CloseMarkModalMsg:
+		pattern := modal.GetInput()
+		modal.SetVisibled(false)
+		if msg.Action == "Ok" {
+			cmd = model.markByPattern(pattern, msg.Mark)
+		}
 	case OpenCopyFileModalMsg:
 		modal.SetVisibled(true)
 		modal.SetTitle("File " + lipgloss.NewStyle().Foreground(lipgloss.Color("#3b82f6")).Render(msg.File.Info.Name) + " already exists! Replace?")
 		modal.SetButtons([]ModalButton{
-			{"Yes(Y)", "Y", func() tea.Msg { return CloseCopyFileModalMsg{Action: "Yes"} }},
-			{"Cancel(C)", "C", func() tea.Msg { return CloseCopyFileModalMsg{Action: "Cancel"} }},
+			{"Yes(Y)", "Y", func() tea.Msg {
+				return CloseCopyFileModalMsg{Pane: msg.Pane, Action: "Yes", Source: msg.Source, Dir: msg.Dir, Move: msg.Move}
+			}},
+			{"Cancel(C)", "C", func() tea.Msg { return CloseCopyFileModalMsg{Pane: msg.Pane, Action: "Cancel"} }},
 		})
 	case CloseCopyFileModalMsg:
 		modal.SetVisibled(false)
 		if msg.Action == "Yes" {
-			cmd = model.copyFile(true)
+			cmd = tea.Sequence(model.copyFile(msg.Source, msg.Dir, true, msg.Move), refreshFiles)
 		}
 	case OpenDeleteFileModalMsg:
 		modal.SetVisibled(true)
-		modal.SetTitle("Delete file " + lipgloss.NewStyle().Foreground(lipgloss.Color("#3b82f6")).Render(msg.File.Info.Name) + "?")
+		modal.SetTitle("Delete " + filesTitle(msg.Files) + "?")
 		modal.SetButtons([]ModalButton{
-			{"Yes(Y)", "Y", func() tea.Msg { return CloseDeleteFileModalMsg{Action: "Yes"} }},
-			{"Cancel(C)", "C", func() tea.Msg { return CloseDeleteFileModalMsg{Action: "Cancel"} }},
+			{"Yes(Y)", "Y", func() tea.Msg { return CloseDeleteFileModalMsg{Pane: msg.Pane, Action: "Yes", Files: msg.Files} }},
+			{"Cancel(C)", "C", func() tea.Msg { return CloseDeleteFileModalMsg{Pane: msg.Pane, Action: "Cancel"} }},
 		})
 	case CloseDeleteFileModalMsg:
 		modal.SetVisibled(false)
 		if msg.Action == "Yes" {
-			cmd = model.deleteFile()
+			model.unmark()
+			cmd = tea.Sequence(model.deleteFiles(msg.Files), refreshFiles)
 		}
-	case ChangeActiveViewMsg:
-		if msg.View == File {
-			model.delegate.isActive = true
-			model.style = model.style.BorderForeground(lipgloss.Color("#3b82f6"))
-		} else {
-			model.delegate.isActive = false
-			model.style = model.style.BorderForeground(lipgloss.Color("#ffffff"))
+	case OpenMakeDirModalMsg:
+		modal.SetVisibled(true)
+		modal.SetTitle("New directory in " + lipgloss.NewStyle().Foreground(lipgloss.Color("#3b82f6")).Render(msg.Dir.Info.Name))
+		modal.SetButtons([]ModalButton{
+			{"Create", "", func() tea.Msg { return CloseMakeDirModalMsg{Pane: msg.Pane, Action: "Create", Dir: msg.Dir} }},
+			{"Cancel", "", func() tea.Msg { return CloseMakeDirModalMsg{Pane: msg.Pane, Action: "Cancel"} }},
+		})
+		cmd = modal.SetInput("")
+		modal.SetValidator(model.nameValidator(""))
+	case CloseMakeDirModalMsg:
+		name := modal.GetInput()
+		modal.SetVisibled(false)
+		if msg.Action == "Create" {
+			cmd = tea.Sequence(model.createFile(msg.Dir, name, api.DIRECTORY), refreshFiles)
+		}
+	case OpenMakeFileModalMsg:
+		modal.SetVisibled(true)
+		modal.SetTitle("New file in " + lipgloss.NewStyle().Foreground(lipgloss.Color("#3b82f6")).Render(msg.Dir.Info.Name))
+		modal.SetButtons([]ModalButton{
+			{"Create", "", func() tea.Msg { return CloseMakeFileModalMsg{Pane: msg.Pane, Action: "Create", Dir: msg.Dir} }},
+			{"Cancel", "", func() tea.Msg { return CloseMakeFileModalMsg{Pane: msg.Pane, Action: "Cancel"} }},
+		})
+		cmd = modal.SetInput("")
+		modal.SetValidator(model.nameValidator(""))
+	case CloseMakeFileModalMsg:
+		name := modal.GetInput()
+		modal.SetVisibled(false)
+		if msg.Action == "Create" {
+			cmd = tea.Sequence(model.createFile(msg.Dir, name, api.FILE), refreshFiles)
 		}
+	case OpenRenameModalMsg:
+		modal.SetVisibled(true)
+		modal.SetTitle("Rename " + lipgloss.NewStyle().Foreground(lipgloss.Color("#3b82f6")).Render(msg.File.Info.Name))
+		modal.SetButtons([]ModalButton{
+			{"Rename", "", func() tea.Msg { return CloseRenameModalMsg{Pane: msg.Pane, Action: "Rename", File: msg.File} }},
+			{"Cancel", "", func() tea.Msg { return CloseRenameModalMsg{Pane: msg.Pane, Action: "Cancel"} }},
+		})
+		cmd = modal.SetInput(msg.File.Info.Name)
+		modal.SetValidator(model.nameValidator(msg.File.Info.Name))
+	case CloseRenameModalMsg:
+		name := modal.GetInput()
+		modal.SetVisibled(false)
+		if msg.Action == "Rename" {
+			cmd = tea.Sequence(model.renameFile(msg.File, name), refreshFiles)
+		}
+	case OpenSearchModalMsg:
+		modal.SetVisibled(true)
+		modal.SetTitle("Search in " + lipgloss.NewStyle().Foreground(lipgloss.Color("#3b82f6")).Render(msg.Dir.Host.Name+":"+msg.Dir.Info.Path) + "\n" + SEARCH_QUERY_HELP)
+		modal.SetButtons([]ModalButton{
+			{"Search", "", func() tea.Msg { return CloseSearchModalMsg{Pane: msg.Pane, Action: "Search", Dir: msg.Dir} }},
+			{"Cancel", "", func() tea.Msg { return CloseSearchModalMsg{Pane: msg.Pane, Action: "Cancel"} }},
+		})
+		cmd = modal.SetInput(model.search)
+	case CloseSearchModalMsg:
+		text := modal.GetInput()
+		modal.SetVisibled(false)
+		if msg.Action == "Search" {
+			model.search = text
+			if query, err := parseSearchQuery(text, time.Now()); err == nil {
+				cmd = func() tea.Msg { return OpenSearchMsg{Dir: msg.Dir, Query: query, Text: text} }
+			} else {
+				cmd = notify(NotificationWarning, "Incorrect search query", err, false)
+			}
+		}
+	// The directory of the found file is opened, the previous directory is the root of the host.
+	case OpenDirMsg:
+		cmd = model.resolveFileChildren(msg.Dir, &FileViewHistoryNode{Item: &FileViewItem{File: msg.Dir}, Prev: &FileViewHistoryNode{}})
+	case ChangeActiveViewMsg:
+		model.viewActive = msg.View == File
+		model = model.focus()
+	case ChangeActivePaneMsg:
+		model.paneActive = msg.Pane == model.pane
+		model = model.focus()
 	case ResizeMsg:
 		frameX, frameY := model.style.GetFrameSize()
 		width := msg.Width - frameX
@@ -216,16 +753,27 @@ func (model FileView) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 		delegate := model.delegate
 		delegate.columnTypeStyle = delegate.columnTypeStyle.Width(COLUMN_TYPE_WIDTH)
-		delegate.columnNameStyle = delegate.columnNameStyle.Width(width - (COLUMN_TYPE_WIDTH + COLUMN_SIZE_WIDTH))
+		nameWidth := width - (COLUMN_TYPE_WIDTH + COLUMN_SIZE_WIDTH)
+		delegate.columnTimeStyle = delegate.columnTimeStyle.Width(0)
+		if nameWidth-COLUMN_MODIFIED_WIDTH >= COLUMN_NAME_MIN_WIDTH {
+			nameWidth -= COLUMN_MODIFIED_WIDTH
+			delegate.columnTimeStyle = delegate.columnTimeStyle.Width(COLUMN_MODIFIED_WIDTH)
+		}
+		delegate.columnNameStyle = delegate.columnNameStyle.Width(nameWidth)
 		delegate.columnSizeStyle = delegate.columnSizeStyle.Width(COLUMN_SIZE_WIDTH)
 		delegate.itemStyle = delegate.itemStyle.Width(width)
 		delegate.itemSelectedStyle = delegate.itemSelectedStyle.Width(width)
 
-		model.list.SetSize(width, height)
+		modal.SetWidth(width)
+		model.headerStyle = model.headerStyle.Width(width).MaxWidth(width)
+		model.footerStyle = model.footerStyle.Width(width)
+		model.list.SetSize(width, height-model.headerStyle.GetHeight()-model.footerStyle.GetHeight())
 	}
 
 	if !modal.GetVisibled() {
 		model.list, listCmd = model.list.Update(msg)
+		// The filter line is shown only while the filter is edited, the applied filter is shown by the header.
+		model.list.SetShowFilter(model.list.SettingFilter())
 	} else {
 		model.modal, modalCmd = model.modal.Update(msg)
 	}
@@ -242,27 +790,182 @@ func (model FileView) View() string {
 			Render(model.modal.View())
 	}
 
-	return model.style.Render(model.list.View())
+	return model.style.Render(
+		lipgloss.JoinVertical(
+			lipgloss.Left,
+			model.headerStyle.Render(model.header()),
+			model.list.View(),
+			model.footerStyle.Render(model.footer()),
+		),
+	)
+}
+
+// The function returns the current directory or nil if the root directory of the host is shown.
+// The files can't be created in the root directory, it contains only the shared directories of the host.
+func (model FileView) CurrentDir() *api.RemoteFile {
+	if model.prev != nil {
+		if item, ok := model.prev.Item.(*FileViewItem); ok {
+			return item.File
+		}
+	}
+	return nil
+}
+
+// The function returns the host of the pane or nil if the host is not selected.
+func (model FileView) Host() *api.RemoteHost {
+	return model.host
+}
+
+// The function highlights the view if the view and its pane are active.
+func (model FileView) focus() FileView {
+	model.delegate.isActive = model.viewActive && model.paneActive
+	if model.delegate.isActive {
+		model.style = model.style.BorderForeground(lipgloss.Color("#3b82f6"))
+	} else {
+		model.style = model.style.BorderForeground(lipgloss.Color("#ffffff"))
+	}
+	return model
+}
+
+// The function returns the header line with the host, the path of the current directory and the order of the files.
+func (model FileView) header() string {
+	if model.host == nil {
+		return ""
+	}
+
+	path := "/"
+	if dir := model.CurrentDir(); dir != nil {
+		path = dir.Info.Path
+	}
+	header := model.host.Name + ":" + path + " (" + model.settings.String() + ")"
+	if model.list.FilterState() == list.FilterApplied {
+		header += " [" + model.list.FilterValue() + "]"
+	}
+	return header
+}
+
+// The function shows the files of the current directory by the settings.
+func (model *FileView) arrange() tea.Cmd {
+	return model.list.SetItems(model.settings.Arrange(model.items))
+}
+
+// The function returns the footer line with the summary of the marked files and information about the volume of the current directory.
+func (model FileView) footer() string {
+	parts := []string{}
+	if count, size := model.marked(); count > 0 {
+		parts = append(parts, "marked ", strconv.Itoa(count), " (", size.String(), ")")
+		if model.volume != nil {
+			parts = append(parts, ", ")
+		}
+	}
+
+	if volume := model.volume; volume != nil {
+		parts = append(parts, "free ", volume.Free.String(), " of ", volume.Total.String())
+		if volume.Inodes > 0 {
+			parts = append(parts, ", inodes free ", strconv.FormatUint(volume.InodesFree, 10), " of ", strconv.FormatUint(volume.Inodes, 10))
+		}
+	}
+	return strings.Join(parts, "")
+}
+
+// The function returns the marked files or the selected file if no file is marked.
+func (model FileView) selection() []*api.RemoteFile {
+	files := []*api.RemoteFile{}
+	for _, item := range model.list.Items() {
+		if item.(*FileViewItem).Marked {
+			files = append(files, item.(*FileViewItem).File)
+		}
+	}
+
+	if item, ok := model.list.SelectedItem().(*FileViewItem); ok && len(files) == 0 {
+		files = append(files, item.File)
+	}
+	return files
+}
+
+// The function returns count and size of the marked files, the size of a directory is its own size without the content.
+func (model FileView) marked() (int, api.FileSize) {
+	count := 0
+	size := api.FileSize(0)
+	for _, item := range model.list.Items() {
+		if fileItem := item.(*FileViewItem); fileItem.Marked {
+			count++
+			size += fileItem.File.Info.Size
+		}
+	}
+	return count, size
+}
+
+// The function removes all marks.
+func (model FileView) unmark() {
+	for _, item := range model.items {
+		item.(*FileViewItem).Marked = false
+	}
+}
+
+// The function marks or unmarks the shown files whose names match the pattern.
+func (model FileView) markByPattern(pattern string, mark bool) tea.Cmd {
+	if _, err := filepath.Match(pattern, ""); err != nil {
+		return notify(NotificationWarning, "Pattern ["+pattern+"] is incorrect", err, false)
+	}
+
+	for _, item := range model.list.VisibleItems() {
+		fileItem := item.(*FileViewItem)
+		if matched, _ := filepath.Match(pattern, fileItem.File.Info.Name); matched {
+			fileItem.Marked = mark
+		}
+	}
+	return nil
+}
+
+// The function copies marks of the items to the items of the same files.
+func keepMarks(items []list.Item, newItems []list.Item) {
+	marked := map[api.FileId]bool{}
+	for _, item := range items {
+		if fileItem := item.(*FileViewItem); fileItem.Marked {
+			marked[fileItem.File.Info.Id] = true
+		}
+	}
+
+	for _, item := range newItems {
+		fileItem := item.(*FileViewItem)
+		fileItem.Marked = marked[fileItem.File.Info.Id]
+	}
+}
+
+// The function returns the name of the file or count and size of the files for the title of the modal window.
+func filesTitle(files []*api.RemoteFile) string {
+	if len(files) == 1 {
+		return "file " + lipgloss.NewStyle().Foreground(lipgloss.Color("#3b82f6")).Render(files[0].Info.Name)
+	}
+
+	size := api.FileSize(0)
+	for _, file := range files {
+		size += file.Info.Size
+	}
+	return strconv.Itoa(len(files)) + " files (" + size.String() + ")"
 }
 
-func NewFileView(network *api.Network) tea.Model {
-	view := FileView{network: network}
+func NewFileView(network *api.Network, pane FilePane) tea.Model {
+	view := FileView{network: network, pane: pane, paneActive: pane == LeftPane}
 	view.delegate = &FileViewItemDelegate{
 		columnTypeStyle:   lipgloss.NewStyle().AlignHorizontal(lipgloss.Left),
 		columnNameStyle:   lipgloss.NewStyle().AlignHorizontal(lipgloss.Left),
 		columnSizeStyle:   lipgloss.NewStyle().AlignHorizontal(lipgloss.Right),
+		columnTimeStyle:   lipgloss.NewStyle().AlignHorizontal(lipgloss.Right),
 		itemStyle:         lipgloss.NewStyle(),
 		itemSelectedStyle: lipgloss.NewStyle().Background(lipgloss.Color("#3b82f6")),
+		itemMarkedStyle:   lipgloss.NewStyle().Foreground(lipgloss.Color("#facc15")).Bold(true),
 	}
 
 	lst := list.New([]list.Item{}, view.delegate, 0, 0)
 	lst.DisableQuitKeybindings()
-	lst.SetShowFilter(false)
 	lst.SetShowHelp(false)
 	lst.SetShowTitle(false)
 	lst.SetShowStatusBar(false)
 	lst.SetShowPagination(false)
 	view.list = lst
+	view.settings = FileViewSettings{Sort: SortByName, DirsFirst: true, Hidden: true}
 
 	view.style = lipgloss.
 		NewStyle().
@@ -270,80 +973,195 @@ func NewFileView(network *api.Network) tea.Model {
 		BorderForeground(lipgloss.Color("#ffffff")).
 		BorderStyle(lipgloss.NormalBorder())
 
+	view.headerStyle = lipgloss.
+		NewStyle().
+		Height(1).
+		Bold(true)
+
+	view.footerStyle = lipgloss.
+		NewStyle().
+		Height(1).
+		Foreground(lipgloss.Color("#9ca3af"))
+
 	view.modal = NewModal()
 
 	return view
 }
 
-func (model FileView) resolveFileChildren(file *api.RemoteFile) tea.Cmd {
+// The command receives the files of the current directory of all panes again.
+func refreshFiles() tea.Msg {
+	return RefreshFilesMsg{}
+}
+
+func (model FileView) resolveFileChildren(file *api.RemoteFile, prev *FileViewHistoryNode) tea.Cmd {
 	return func() tea.Msg {
-		// TODO. Show error.
-		children, _ := file.Children(model.network.Transport())
+		children, err := file.Children(model.network.Transport())
+		if err != nil {
+			return UpdateFilesMsg{Pane: model.pane, Dir: file, Prev: prev, Error: err}
+		}
+
 		items := make([]list.Item, len(children))
 		for index, file := range children {
 			items[index] = &FileViewItem{File: &file}
 		}
-		return UpdateFilesMsg{Items: items}
+		return UpdateFilesMsg{Pane: model.pane, Items: items, Dir: file, Prev: prev}
+	}
+}
+
+// The function receives the files of the current directory again.
+func (model FileView) refreshFiles() tea.Cmd {
+	if model.prev == nil || model.host == nil {
+		return nil
+	}
+
+	dir := model.host.Root()
+	if current := model.CurrentDir(); current != nil {
+		dir = current
+	}
+	return model.resolveFileChildren(dir, model.prev)
+}
+
+func (model FileView) resolveVolume(file *api.RemoteFile) tea.Cmd {
+	return func() tea.Msg {
+		if file.Info.Id == file.Host.Root().Info.Id || !file.Host.Supports(api.FeatureVolume) {
+			return UpdateVolumeMsg{Pane: model.pane}
+		}
+
+		volume, err := file.Volume(model.network.Transport())
+		return UpdateVolumeMsg{Pane: model.pane, Volume: volume, Error: err}
 	}
 }
 
-func (model FileView) copyFile(replace bool) tea.Cmd {
+// The function copies the file to the directory, the moved file is removed by its host after copying.
+func (model FileView) copyFile(file *api.RemoteFile, dir *api.RemoteFile, replace bool, move bool) tea.Cmd {
 	return func() tea.Msg {
-		client := model.network.Transport()
-		item := model.prev.Item.(*FileViewItem)
-		file := model.toCopy
-		path := filepath.Join(item.File.Info.Path, file.Info.Name)
-		target := api.RemoteFile{
-			Host: *model.host,
-			Info: api.FileInfo{
-				Id:   api.FileId(path),
-				Name: file.Info.Name,
-				Path: path,
-				Type: file.Info.Type,
-				Size: file.Info.Size,
-			},
-		}
-
-		var err error
-		if !replace {
-			_, err = model.host.File(client, api.FileId(target.Info.Path))
-			if err == nil { // File already exists.
-				return OpenCopyFileModalMsg{File: &target}
-			} else { // File not exists.
-				_, err = file.CopyTo(model.network.Transport(), target)
+		action := "copy"
+		if move {
+			action = "move"
+			if !file.Host.Supports(api.FeatureMove) {
+				return NotificationMsg{Level: NotificationError, Text: "Failed to move " + file.Info.Name, Error: errors.New("host " + file.Host.Name + " can't move files")}
 			}
-		} else {
-			_, err = file.CopyTo(model.network.Transport(), target)
 		}
 
-		// TODO. it does not working!
-		children, _ := item.File.Children(model.network.Transport())
-		items := make([]list.Item, len(children))
-		for index, file := range children {
-			items[index] = &FileViewItem{File: &file}
+		target := targetFile(file, dir)
+		_, err := file.CopyWith(model.network.Transport(), target, api.CopyOptions{Replace: replace, Move: move})
+		if errors.Is(err, api.ErrFileAlreadyExists) {
+			return OpenCopyFileModalMsg{Pane: model.pane, File: &target, Source: file, Dir: dir, Move: move}
+		} else if err != nil {
+			return NotificationMsg{Level: NotificationError, Text: "Failed to " + action + " " + file.Info.Name, Error: err}
 		}
-		return UpdateFilesMsg{Items: items}
+		return NotificationMsg{Level: NotificationSuccess, Text: "Started to " + action + " " + file.Info.Name + " to " + target.Host.Name + ":" + dir.Info.Path}
+	}
+}
+
+// The function returns the file with the same name in the directory.
+func targetFile(file *api.RemoteFile, dir *api.RemoteFile) api.RemoteFile {
+	path := filepath.Join(dir.Info.Path, file.Info.Name)
+	return api.RemoteFile{
+		Host: dir.Host,
+		Info: api.FileInfo{
+			Id:   api.FileId(path),
+			Name: file.Info.Name,
+			Path: path,
+			Type: file.Info.Type,
+			Size: file.Info.Size,
+		},
 	}
 }
 
-func (model FileView) deleteFile() tea.Cmd {
+// The function starts a task for each file, the existing files are replaced only if replace is true.
+func (model FileView) copyFiles(files []*api.RemoteFile, dir *api.RemoteFile, replace bool, move bool) tea.Cmd {
 	return func() tea.Msg {
-		item := model.list.SelectedItem()
-		if _, ok := item.(*FileViewItem); ok {
-			file := item.(*FileViewItem).File
-			file.Remove(model.network.Transport())
-
-			item = model.prev.Item
-			if _, ok := item.(*FileViewItem); ok {
-				file = item.(*FileViewItem).File
-				children, _ := file.Children(model.network.Transport())
-				items := make([]list.Item, len(children))
-				for index, file := range children {
-					items[index] = &FileViewItem{File: &file}
-				}
-				return UpdateFilesMsg{Items: items}
+		action := "copy"
+		if move {
+			action = "move"
+		}
+
+		errs := []error{}
+		for _, file := range files {
+			var err error
+			if move && !file.Host.Supports(api.FeatureMove) {
+				err = errors.New("host " + file.Host.Name + " can't move files")
+			} else {
+				_, err = file.CopyWith(model.network.Transport(), targetFile(file, dir), api.CopyOptions{Replace: replace, Move: move})
+			}
+
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", file.Info.Name, err))
 			}
 		}
+
+		if len(errs) > 0 {
+			text := "Failed to " + action + " " + strconv.Itoa(len(errs)) + " of " + strconv.Itoa(len(files)) + " files"
+			return NotificationMsg{Level: NotificationError, Text: text, Error: errors.Join(errs...)}
+		}
+		return NotificationMsg{Level: NotificationSuccess, Text: "Started to " + action + " " + strconv.Itoa(len(files)) + " files to " + dir.Host.Name + ":" + dir.Info.Path}
+	}
+}
+
+// The function removes the files, the removing continues after an error.
+func (model FileView) deleteFiles(files []*api.RemoteFile) tea.Cmd {
+	return func() tea.Msg {
+		errs := []error{}
+		for _, file := range files {
+			if err := file.Remove(model.network.Transport()); err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", file.Info.Name, err))
+			}
+		}
+
+		if len(errs) > 0 {
+			text := "Failed to delete " + strconv.Itoa(len(errs)) + " of " + strconv.Itoa(len(files)) + " files"
+			return NotificationMsg{Level: NotificationError, Text: text, Error: errors.Join(errs...)}
+		} else if len(files) == 1 {
+			return NotificationMsg{Level: NotificationSuccess, Text: files[0].Info.Name + " is deleted"}
+		}
+		return NotificationMsg{Level: NotificationSuccess, Text: strconv.Itoa(len(files)) + " files are deleted"}
+	}
+}
+
+// The function creates the directory with the name in the directory.
+func (model FileView) createFile(dir *api.RemoteFile, name string, fileType api.FileType) tea.Cmd {
+	return func() tea.Msg {
+		if err := api.CheckFileName(name); err != nil {
+			return NotificationMsg{Level: NotificationWarning, Text: "Name [" + name + "] is incorrect", Error: err}
+		}
+
+		info := api.FileInfo{Name: name, Path: filepath.Join(dir.Info.Path, name), Type: fileType}
+		if _, err := dir.Host.Create(model.network.Transport(), info, false); err != nil {
+			return NotificationMsg{Level: NotificationError, Text: "Failed to create " + name, Error: err}
+		}
+		return NotificationMsg{Level: NotificationSuccess, Text: name + " is created"}
+	}
+}
+
+// The function renames the file in its directory.
+func (model FileView) renameFile(file *api.RemoteFile, name string) tea.Cmd {
+	return func() tea.Msg {
+		if _, err := file.Rename(model.network.Transport(), name); err != nil {
+			return NotificationMsg{Level: NotificationError, Text: "Failed to rename " + file.Info.Name, Error: err}
+		}
+		return NotificationMsg{Level: NotificationSuccess, Text: file.Info.Name + " is renamed to " + name}
+	}
+}
+
+// The function returns the validation of the name of the new or renamed file.
+// The name must be correct and must not be used by other files of the current directory, including the hidden ones.
+func (model FileView) nameValidator(current string) func(string) error {
+	names := make(map[string]bool, len(model.items))
+	for _, item := range model.items {
+		names[item.(*FileViewItem).File.Info.Name] = true
+	}
+
+	return func(name string) error {
+		if err := api.CheckFileName(name); err != nil {
+			return err
+		}
+		if name == current {
+			return errors.New("name is not changed")
+		}
+		if names[name] {
+			return errors.New("file [" + name + "] already exists")
+		}
 		return nil
 	}
 }
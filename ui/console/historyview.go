@@ -0,0 +1,162 @@
+package console
+
+import (
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+const HISTORY_MAX_LEN = 100
+const COLUMN_TIME_WIDTH = 9
+const COLUMN_LEVEL_WIDTH = 8
+
+type HistoryViewItem struct {
+	Notification Notification
+}
+
+func (item HistoryViewItem) Title() string       { return item.Notification.Text }
+func (item HistoryViewItem) Description() string { return item.Notification.String() }
+func (item HistoryViewItem) FilterValue() string { return item.Notification.String() }
+
+type HistoryViewItemDelegate struct {
+	columnTimeStyle   lipgloss.Style
+	columnTextStyle   lipgloss.Style
+	levelStyle        map[NotificationLevel]lipgloss.Style
+	itemStyle         lipgloss.Style
+	itemSelectedStyle lipgloss.Style
+}
+
+func (delegate HistoryViewItemDelegate) Render(writer io.Writer, model list.Model, index int, item list.Item) {
+	style := delegate.itemStyle
+	if model.Index() == index {
+		style = delegate.itemSelectedStyle
+	}
+
+	notification := item.(*HistoryViewItem).Notification
+	text := strings.ReplaceAll(notification.String(), "\n", " ")
+	if notification.Count > 1 {
+		text += " (x" + strconv.Itoa(notification.Count) + ")"
+	}
+
+	textWidth := model.Width() - (COLUMN_TIME_WIDTH + COLUMN_LEVEL_WIDTH)
+	if lipgloss.Width(text) > textWidth && textWidth > TOO_LONG_LINE_POSTFIX_WIDTH {
+		text = lipgloss.
+			NewStyle().
+			MaxWidth(textWidth-TOO_LONG_LINE_POSTFIX_WIDTH).
+			Render(text) + TOO_LONG_LINE_POSTFIX
+	}
+
+	writer.Write(
+		[]byte(
+			style.Width(model.Width()).Render(
+				lipgloss.JoinHorizontal(
+					lipgloss.Left,
+					delegate.columnTimeStyle.Render(notification.Time.Format("15:04:05")),
+					delegate.levelStyle[notification.Level].Width(COLUMN_LEVEL_WIDTH).Render(notification.Level.String()),
+					delegate.columnTextStyle.Width(max(textWidth, 0)).Render(text),
+				),
+			),
+		),
+	)
+}
+
+func (HistoryViewItemDelegate) Height() int { return 1 }
+
+func (HistoryViewItemDelegate) Spacing() int { return 0 }
+
+func (HistoryViewItemDelegate) Update(msg tea.Msg, m *list.Model) tea.Cmd {
+	return nil
+}
+
+// The view for displaying the history of errors and warnings, the last notification is shown first.
+type HistoryView struct {
+	list  list.Model
+	style lipgloss.Style
+}
+
+func (model HistoryView) Init() tea.Cmd {
+	return nil
+}
+
+func (model HistoryView) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+	var listCmd tea.Cmd
+
+	switch msg := msg.(type) {
+	case NotificationMsg:
+		if msg.Level != NotificationSuccess {
+			cmd = model.list.SetItems(model.append(msg))
+		}
+	case ResizeMsg:
+		frameX, frameY := model.style.GetFrameSize()
+		width := msg.Width - frameX
+		height := msg.Height - frameY
+		model.style = model.
+			style.
+			Width(width).
+			Height(height)
+
+		model.list.SetSize(width, height)
+	case tea.KeyMsg:
+		model.list, listCmd = model.list.Update(msg)
+	}
+
+	return model, tea.Sequence(cmd, listCmd)
+}
+
+func (model HistoryView) View() string {
+	if len(model.list.Items()) == 0 {
+		return model.style.Render("No errors")
+	}
+	return model.style.Render(model.list.View())
+}
+
+// The function returns the items of the history with the notification.
+// The notification which repeats the last one increases its count, so the periodic errors do not flood the history.
+func (model HistoryView) append(msg NotificationMsg) []list.Item {
+	notification := Notification{Level: msg.Level, Text: msg.Text, Error: msg.Error, Time: time.Now(), Count: 1}
+
+	items := model.list.Items()
+	if len(items) > 0 {
+		if last := items[0].(*HistoryViewItem); last.Notification.Same(notification) {
+			notification.Count += last.Notification.Count
+			items = items[1:]
+		}
+	}
+
+	result := make([]list.Item, 0, min(len(items)+1, HISTORY_MAX_LEN))
+	result = append(result, &HistoryViewItem{Notification: notification})
+	return append(result, items[:min(len(items), HISTORY_MAX_LEN-1)]...)
+}
+
+func NewHistoryView() tea.Model {
+	delegate := HistoryViewItemDelegate{
+		columnTimeStyle:   lipgloss.NewStyle().Width(COLUMN_TIME_WIDTH),
+		columnTextStyle:   lipgloss.NewStyle().AlignHorizontal(lipgloss.Left),
+		levelStyle:        notificationLevelStyles(),
+		itemStyle:         lipgloss.NewStyle(),
+		itemSelectedStyle: lipgloss.NewStyle().Background(lipgloss.Color("#3b82f6")),
+	}
+
+	lst := list.New([]list.Item{}, delegate, 0, 0)
+	lst.DisableQuitKeybindings()
+	lst.SetShowFilter(false)
+	lst.SetShowHelp(false)
+	lst.SetShowTitle(false)
+	lst.SetShowStatusBar(false)
+	lst.SetShowPagination(false)
+
+	return HistoryView{
+		list: lst,
+		style: lipgloss.
+			NewStyle().
+			Align(lipgloss.Left, lipgloss.Left).
+			BorderForeground(lipgloss.Color("#3b82f6")).
+			BorderStyle(lipgloss.NormalBorder()),
+	}
+}
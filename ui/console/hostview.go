@@ -22,6 +22,11 @@ type ChangeHostsMsg struct {
 	Error error
 }
 
+// The event sends after changing the host table of the watcher.
+type HostEventMsg struct {
+	Event api.HostEvent
+}
+
 type HostViewItem struct {
 	Host *api.RemoteHost
 }
@@ -65,21 +70,13 @@ type HostView struct {
 	style       lipgloss.Style
 	activeStyle lipgloss.Style
 	network     *api.Network
+	watcher     *api.HostWatcher
+	events      <-chan api.HostEvent
 	active      bool
 }
 
 func (model HostView) Init() tea.Cmd {
-	return func() tea.Msg {
-		hosts, err := model.network.Hosts()
-		if err == nil && len(hosts) > 0 {
-			items := make([]list.Item, len(hosts))
-			for index, host := range hosts {
-				items[index] = &HostViewItem{Host: &host}
-			}
-			return ChangeHostsMsg{Items: items}
-		}
-		return ChangeHostsMsg{Error: err}
-	}
+	return model.waitHostEvent()
 }
 
 func (model HostView) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
@@ -99,6 +96,13 @@ func (model HostView) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		model.active = (msg.View == Host)
 	case ChangeHostsMsg:
 		cmd = model.list.SetItems(msg.Items)
+	case HostEventMsg:
+		hosts := model.watcher.Hosts()
+		items := make([]list.Item, len(hosts))
+		for index, host := range hosts {
+			items[index] = &HostViewItem{Host: &host.Host}
+		}
+		cmd = tea.Sequence(model.list.SetItems(items), model.notifyHostEvent(msg.Event), model.waitHostEvent())
 	case ResizeMsg:
 		frameX, frameY := model.style.GetFrameSize()
 		width := msg.Width - frameX
@@ -129,7 +133,28 @@ func (model HostView) View() string {
 	return model.style.Render(model.list.View())
 }
 
-func NewHostView(network *api.Network) tea.Model {
+// The function waits for the next event of the host watcher.
+func (model HostView) waitHostEvent() tea.Cmd {
+	return func() tea.Msg {
+		if event, ok := <-model.events; ok {
+			return HostEventMsg{Event: event}
+		}
+		return nil
+	}
+}
+
+// The function returns the command which shows the notification about the failed discovery or the lost host.
+func (model HostView) notifyHostEvent(event api.HostEvent) tea.Cmd {
+	switch event.Type {
+	case api.HostDiscoveryFailed:
+		return notify(NotificationError, "Failed to discover hosts", event.Error, true)
+	case api.HostRemoved:
+		return notify(NotificationWarning, "Host "+event.Host.Name+"("+event.Host.IP.String()+") is unavailable", nil, false)
+	}
+	return nil
+}
+
+func NewHostView(network *api.Network, watcher *api.HostWatcher) tea.Model {
 	delegate := HostViewItemDelegate{
 		itemStyle:         lipgloss.NewStyle(),
 		itemSelectedStyle: lipgloss.NewStyle().Background(lipgloss.Color("#3b82f6")),
@@ -155,5 +180,12 @@ func NewHostView(network *api.Network) tea.Model {
 		BorderForeground(lipgloss.Color("#3b82f6")).
 		BorderStyle(lipgloss.NormalBorder())
 
-	return &HostView{list: lst, network: network, style: style, activeStyle: activeStyle}
+	return &HostView{
+		list:        lst,
+		network:     network,
+		watcher:     watcher,
+		events:      watcher.Subscribe(),
+		style:       style,
+		activeStyle: activeStyle,
+	}
 }
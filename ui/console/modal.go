@@ -3,10 +3,13 @@ package console
 import (
 	"strings"
 
+	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 )
 
+const MODAL_INPUT_WIDTH = 40
+
 // A button of the modal window.
 type ModalButton struct {
 	title    string
@@ -19,16 +22,41 @@ type Modal struct {
 	titleStyle          lipgloss.Style
 	buttonStyle         lipgloss.Style
 	buttonSelectedStyle lipgloss.Style
+	inputStyle          lipgloss.Style
+	errorStyle          lipgloss.Style
 	windowStyle         lipgloss.Style
+	input               textinput.Model
 	buttons             []ModalButton
 	title               string
 	selected            int
+	width               int
 	visibled            bool
+	inputVisibled       bool
+	// The validation of the input field and its last error.
+	validate   func(string) error
+	inputError error
 }
 
 // The function sets the visibility flag for the modal window.
 func (model *Modal) SetVisibled(value bool) {
 	model.visibled = value
+	if !value {
+		model.inputVisibled = false
+		model.validate = nil
+		model.inputError = nil
+		model.input.Blur()
+	}
+}
+
+// The function sets the maximum width of the modal window, zero means the width is not limited.
+func (model *Modal) SetWidth(width int) {
+	model.width = width
+	model.inputStyle = model.inputStyle.Width(MODAL_INPUT_WIDTH)
+	if width > 0 {
+		innerWidth := width - model.windowStyle.GetHorizontalFrameSize() - model.inputStyle.GetHorizontalBorderSize()
+		model.inputStyle = model.inputStyle.Width(max(min(MODAL_INPUT_WIDTH, innerWidth), 1))
+	}
+	model.input.Width = model.inputStyle.GetWidth() - 1
 }
 
 // The function returns the visibility flag for the modal window.
@@ -44,6 +72,44 @@ func (model *Modal) SetTitle(title string) {
 // The function replaces the buttons of the modal window.
 func (model *Modal) SetButtons(buttons []ModalButton) {
 	model.buttons = buttons
+	model.selected = 0
+}
+
+// The function shows the input field with the value, the field is hidden by SetVisibled(false).
+func (model *Modal) SetInput(value string) tea.Cmd {
+	model.inputVisibled = true
+	model.input.SetValue(value)
+	model.input.CursorEnd()
+	model.check()
+	return model.input.Focus()
+}
+
+// The function sets the validation of the input field, the validation is removed by SetVisibled(false).
+// The buttons except the last one don't work while the value is incorrect, the error is shown under the field.
+func (model *Modal) SetValidator(validate func(string) error) {
+	model.validate = validate
+	model.check()
+}
+
+// The function validates the value of the input field.
+func (model *Modal) check() {
+	model.inputError = nil
+	if model.inputVisibled && model.validate != nil {
+		model.inputError = model.validate(model.input.Value())
+	}
+}
+
+// The function returns the command of the button or nil if the button doesn't work.
+func (model *Modal) press(index int) tea.Cmd {
+	if model.inputError != nil && index != len(model.buttons)-1 {
+		return nil
+	}
+	return model.buttons[index].cmd
+}
+
+// The function returns the value of the input field.
+func (model *Modal) GetInput() string {
+	return model.input.Value()
 }
 
 func (model *Modal) Init() tea.Cmd {
@@ -56,6 +122,13 @@ func (model *Modal) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
 		if model.visibled {
+			// The input field receives all keys except the keys of the buttons.
+			if model.inputVisibled && msg.Type != tea.KeyEnter && msg.Type != tea.KeyEsc && msg.Type != tea.KeyTab {
+				model.input, cmd = model.input.Update(msg)
+				model.check()
+				return model, cmd
+			}
+
 			switch msg.Type {
 			case tea.KeyLeft:
 				if model.selected > 0 {
@@ -65,17 +138,31 @@ func (model *Modal) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				if model.selected < len(model.buttons)-1 {
 					model.selected += 1
 				}
+			case tea.KeyTab:
+				model.selected = (model.selected + 1) % max(len(model.buttons), 1)
 			case tea.KeyEnter:
-				cmd = model.buttons[model.selected].cmd
+				if len(model.buttons) > 0 {
+					cmd = model.press(model.selected)
+				}
+			// The last button cancels the modal window.
+			case tea.KeyEsc:
+				if len(model.buttons) > 0 {
+					cmd = model.buttons[len(model.buttons)-1].cmd
+				}
 			default:
-				for _, button := range model.buttons {
+				for index, button := range model.buttons {
 					if strings.EqualFold(button.shortcut, string(msg.Runes)) {
-						cmd = button.cmd
+						cmd = model.press(index)
 						break
 					}
 				}
 			}
 		}
+	// The cursor of the input field blinks by its own events.
+	default:
+		if model.inputVisibled {
+			model.input, cmd = model.input.Update(msg)
+		}
 	}
 	return model, cmd
 }
@@ -90,16 +177,27 @@ func (model *Modal) View() string {
 		}
 	}
 
+	// The long title is wrapped by the width of the modal window.
+	titleStyle := model.titleStyle
+	if width := model.width - model.windowStyle.GetHorizontalFrameSize(); model.width > 0 && lipgloss.Width(titleStyle.Render(model.title)) > width {
+		titleStyle = titleStyle.Width(width)
+	}
+
+	parts := []string{titleStyle.Render(model.title)}
+	if model.inputVisibled {
+		parts = append(parts, model.inputStyle.Render(model.input.View()))
+		if model.inputError != nil {
+			parts = append(parts, model.errorStyle.Width(model.inputStyle.GetWidth()+model.inputStyle.GetHorizontalBorderSize()).Render(model.inputError.Error()))
+		}
+	}
+	parts = append(parts, lipgloss.JoinHorizontal(lipgloss.Center, buttons...))
+
 	return model.
 		windowStyle.
 		Render(
 			lipgloss.JoinVertical(
 				lipgloss.Center,
-				model.titleStyle.Render(model.title),
-				lipgloss.JoinHorizontal(
-					lipgloss.Center,
-					buttons...,
-				),
+				parts...,
 			),
 		)
 }
@@ -124,12 +222,23 @@ func NewModal() *Modal {
 			Foreground(lipgloss.Color("#3b82f6")).
 			Border(lipgloss.NormalBorder()).
 			BorderForeground(lipgloss.Color("#3b82f6")),
+		inputStyle: lipgloss.
+			NewStyle().
+			Width(MODAL_INPUT_WIDTH).
+			Border(lipgloss.NormalBorder()).
+			BorderForeground(lipgloss.Color("#3b82f6")),
+		errorStyle: lipgloss.
+			NewStyle().
+			Foreground(lipgloss.Color("#ef4444")),
 		windowStyle: lipgloss.
 			NewStyle().
-			Padding(2).
+			Padding(1, 2).
 			Align(lipgloss.Center).
 			Border(lipgloss.NormalBorder()).
 			BorderForeground(lipgloss.Color("#fff")),
 	}
+	modal.input = textinput.New()
+	modal.input.Prompt = ""
+	modal.input.Width = MODAL_INPUT_WIDTH - 1
 	return modal
 }
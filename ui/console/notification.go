@@ -0,0 +1,178 @@
+package console
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+const NotificationTimeout = 5 * time.Second // TODO. from settings?
+
+// Level of the notification.
+type NotificationLevel uint8
+
+const (
+	NotificationSuccess NotificationLevel = iota
+	NotificationWarning
+	NotificationError
+)
+
+// Returns a string representation of the notification level.
+func (level NotificationLevel) String() string {
+	switch level {
+	case NotificationSuccess:
+		return "OK"
+	case NotificationWarning:
+		return "WARN"
+	default:
+		return "ERROR"
+	}
+}
+
+// The notification about the result of a command.
+type Notification struct {
+	Level NotificationLevel
+	Text  string
+	Error error
+	Time  time.Time
+	// Count of the same notifications received one after another.
+	Count int
+}
+
+// Returns the text of the notification with the error.
+func (notification Notification) String() string {
+	if notification.Error != nil {
+		return notification.Text + ": " + notification.Error.Error()
+	}
+	return notification.Text
+}
+
+// The function checks that the notification has the same level, text and error.
+func (notification Notification) Same(other Notification) bool {
+	return notification.Level == other.Level && notification.String() == other.String()
+}
+
+// The event sends to show the notification.
+// The sticky notification is shown until it is dismissed or replaced by another sticky notification,
+// other notifications are hidden after the timeout.
+type NotificationMsg struct {
+	Level  NotificationLevel
+	Text   string
+	Error  error
+	Sticky bool
+}
+
+// The event sends after the timeout of the transient notification.
+type ExpireNotificationMsg struct {
+	Id int
+}
+
+// The event sends to hide the sticky notification.
+type DismissNotificationMsg struct{}
+
+// The function returns the command which shows the notification.
+func notify(level NotificationLevel, text string, err error, sticky bool) tea.Cmd {
+	return func() tea.Msg {
+		return NotificationMsg{Level: level, Text: text, Error: err, Sticky: sticky}
+	}
+}
+
+// The status bar shows the last notification, the transient notification overlaps the sticky one.
+type StatusBar struct {
+	style      lipgloss.Style
+	hintStyle  lipgloss.Style
+	levelStyle map[NotificationLevel]lipgloss.Style
+	transient  *Notification
+	sticky     *Notification
+	lastId     int
+	errors     int
+}
+
+func (model StatusBar) Init() tea.Cmd {
+	return nil
+}
+
+func (model StatusBar) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+
+	switch msg := msg.(type) {
+	case NotificationMsg:
+		notification := &Notification{Level: msg.Level, Text: msg.Text, Error: msg.Error, Time: time.Now(), Count: 1}
+		if msg.Level == NotificationError {
+			model.errors++
+		}
+
+		if msg.Sticky {
+			model.sticky = notification
+			model.transient = nil
+		} else {
+			model.lastId++
+			id := model.lastId
+			model.transient = notification
+			cmd = tea.Tick(NotificationTimeout, func(time.Time) tea.Msg { return ExpireNotificationMsg{Id: id} })
+		}
+	case ExpireNotificationMsg:
+		if msg.Id == model.lastId {
+			model.transient = nil
+		}
+	case DismissNotificationMsg:
+		model.transient = nil
+		model.sticky = nil
+	case ResizeMsg:
+		model.style = model.style.Width(msg.Width).MaxWidth(msg.Width)
+	}
+	return model, cmd
+}
+
+func (model StatusBar) View() string {
+	notification := model.transient
+	if notification == nil {
+		notification = model.sticky
+	}
+
+	hint := strings.Join([]string{HistoryActiveKeyMsg, ": history(", strconv.Itoa(model.errors), ")"}, "")
+	if notification == nil {
+		return model.style.Render(model.hintStyle.Render(hint))
+	}
+
+	if notification == model.sticky {
+		hint = DismissKeyMsg + ": dismiss, " + hint
+	}
+
+	text := model.levelStyle[notification.Level].Render("["+notification.Level.String()+"]") + " " + strings.ReplaceAll(notification.String(), "\n", " ")
+	if width := model.style.GetWidth() - (lipgloss.Width(hint) + 1); width > TOO_LONG_LINE_POSTFIX_WIDTH {
+		if lipgloss.Width(text) > width {
+			text = lipgloss.NewStyle().MaxWidth(width-TOO_LONG_LINE_POSTFIX_WIDTH).Render(text) + TOO_LONG_LINE_POSTFIX
+		}
+		text = lipgloss.NewStyle().Width(width).Render(text)
+	}
+
+	return model.style.Render(
+		lipgloss.JoinHorizontal(
+			lipgloss.Left,
+			text,
+			" ",
+			model.hintStyle.Render(hint),
+		),
+	)
+}
+
+// The function returns styles of the notification levels.
+func notificationLevelStyles() map[NotificationLevel]lipgloss.Style {
+	return map[NotificationLevel]lipgloss.Style{
+		NotificationSuccess: lipgloss.NewStyle().Foreground(lipgloss.Color("#22c55e")),
+		NotificationWarning: lipgloss.NewStyle().Foreground(lipgloss.Color("#f59e0b")),
+		NotificationError:   lipgloss.NewStyle().Foreground(lipgloss.Color("#ef4444")),
+	}
+}
+
+func NewStatusBar() tea.Model {
+	return StatusBar{
+		style:      lipgloss.NewStyle().Height(1),
+		hintStyle:  lipgloss.NewStyle().Foreground(lipgloss.Color("#9ca3af")),
+		levelStyle: notificationLevelStyles(),
+	}
+}
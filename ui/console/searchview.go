@@ -0,0 +1,371 @@
+package console
+
+import (
+	"errors"
+	"io"
+	"netfs/api"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// The syntax of the search query, the words without a prefix are the pattern of the file name.
+const SEARCH_QUERY_HELP = "*.log re:^app type:f|d size>1M size<1G newer:7d older:12h limit:100"
+
+// The event sends to start the search in the directory.
+type OpenSearchMsg struct {
+	Dir   *api.RemoteFile
+	Query api.SearchQuery
+	Text  string
+}
+
+// The event sends after the search is started or the next file is found.
+type UpdateSearchMsg struct {
+	Id     int
+	Search *api.FileSearch
+	File   *api.RemoteFile
+	// The search is finished, the error is nil if all files are found.
+	Done  bool
+	Error error
+}
+
+// The event sends to open the directory in the active pane.
+type OpenDirMsg struct {
+	Dir *api.RemoteFile
+}
+
+type SearchViewItemDelegate struct {
+	columnTypeStyle   lipgloss.Style
+	columnNameStyle   lipgloss.Style
+	columnSizeStyle   lipgloss.Style
+	itemStyle         lipgloss.Style
+	itemSelectedStyle lipgloss.Style
+	// The paths are shown relative to the directory of the search.
+	root string
+}
+
+func (delegate SearchViewItemDelegate) Render(writer io.Writer, model list.Model, index int, item list.Item) {
+	style := delegate.itemStyle
+	if model.Index() == index {
+		style = delegate.itemSelectedStyle
+	}
+
+	file := item.(*FileViewItem).File
+	path := file.Info.Path
+	if relative, err := filepath.Rel(delegate.root, path); err == nil && !strings.HasPrefix(relative, "..") {
+		path = relative
+	}
+
+	nameWidth := delegate.columnNameStyle.GetWidth()
+	if lipgloss.Width(path) > nameWidth && nameWidth > TOO_LONG_LINE_POSTFIX_WIDTH {
+		// The end of the path is more important than its beginning.
+		runes := []rune(path)
+		path = TOO_LONG_LINE_POSTFIX + string(runes[max(len(runes)-(nameWidth-TOO_LONG_LINE_POSTFIX_WIDTH), 0):])
+	}
+
+	writer.Write(
+		[]byte(
+			style.Render(
+				lipgloss.JoinHorizontal(
+					lipgloss.Left,
+					delegate.columnTypeStyle.Render(file.Info.Type.String()),
+					delegate.columnNameStyle.Render(path),
+					delegate.columnSizeStyle.Render(file.Info.Size.String()),
+				),
+			),
+		),
+	)
+}
+
+func (SearchViewItemDelegate) Height() int { return 1 }
+
+func (SearchViewItemDelegate) Spacing() int { return 0 }
+
+func (SearchViewItemDelegate) Update(msg tea.Msg, m *list.Model) tea.Cmd {
+	return nil
+}
+
+// The view of the files found by the search, the files are shown while the host walks the directory.
+type SearchView struct {
+	list        list.Model
+	delegate    *SearchViewItemDelegate
+	network     *api.Network
+	search      *api.FileSearch
+	dir         *api.RemoteFile
+	style       lipgloss.Style
+	headerStyle lipgloss.Style
+	text        string
+	// The identifier of the current search, the files of the previous searches are skipped.
+	id      int
+	running bool
+	err     error
+}
+
+func (model SearchView) Init() tea.Cmd {
+	return nil
+}
+
+func (model SearchView) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+	var listCmd tea.Cmd
+
+	switch msg := msg.(type) {
+	case OpenSearchMsg:
+		model.stop()
+		model.id++
+		model.dir = msg.Dir
+		model.text = msg.Text
+		model.running = true
+		model.err = nil
+		model.delegate.root = msg.Dir.Info.Path
+		cmd = tea.Sequence(model.list.SetItems([]list.Item{}), model.start(msg.Dir, msg.Query))
+	case UpdateSearchMsg:
+		if msg.Id != model.id {
+			// The search which is started after closing the view is stopped at once.
+			if msg.Search != nil && !msg.Done {
+				msg.Search.Close()
+			}
+			break
+		}
+
+		if msg.Search != nil {
+			model.search = msg.Search
+		}
+		if msg.File != nil {
+			cmd = model.list.InsertItem(len(model.list.Items()), &FileViewItem{File: msg.File})
+		}
+
+		if msg.Done {
+			model.stop()
+			model.running = false
+			model.err = msg.Error
+			if msg.Error != nil {
+				cmd = tea.Sequence(cmd, notify(NotificationError, "Failed to search in "+model.dir.Host.Name+":"+model.dir.Info.Path, msg.Error, false))
+			}
+		} else {
+			cmd = tea.Sequence(cmd, model.next(msg.Id, model.search))
+		}
+	case ChangeActiveViewMsg:
+		// The search is stopped after closing its view.
+		if !isOverlay(msg.View) && model.running {
+			model.stop()
+			model.id++
+			model.running = false
+		}
+	case ResizeMsg:
+		frameX, frameY := model.style.GetFrameSize()
+		width := msg.Width - frameX
+		height := msg.Height - frameY
+		model.style = model.
+			style.
+			Width(width).
+			Height(height)
+
+		delegate := model.delegate
+		delegate.columnTypeStyle = delegate.columnTypeStyle.Width(COLUMN_TYPE_WIDTH)
+		delegate.columnNameStyle = delegate.columnNameStyle.Width(max(width-(COLUMN_TYPE_WIDTH+COLUMN_SIZE_WIDTH), 0))
+		delegate.columnSizeStyle = delegate.columnSizeStyle.Width(COLUMN_SIZE_WIDTH)
+		delegate.itemStyle = delegate.itemStyle.Width(width)
+		delegate.itemSelectedStyle = delegate.itemSelectedStyle.Width(width)
+
+		model.headerStyle = model.headerStyle.Width(width).MaxWidth(width)
+		model.list.SetSize(width, height-model.headerStyle.GetHeight())
+	case tea.KeyMsg:
+		item, selected := model.list.SelectedItem().(*FileViewItem)
+		switch msg.Type {
+		// Opens the directory of the found file.
+		case tea.KeyEnter:
+			if selected {
+				dir := item.File
+				if dir.Info.Type != api.DIRECTORY {
+					path := filepath.Dir(dir.Info.Path)
+					dir = &api.RemoteFile{
+						Host: dir.Host,
+						Info: api.FileInfo{Id: api.FileId(path), Name: filepath.Base(path), Path: path, Type: api.DIRECTORY},
+					}
+				}
+				cmd = func() tea.Msg { return OpenDirMsg{Dir: dir} }
+			}
+		case tea.KeyF3:
+			if selected && item.File.Info.Type == api.FILE {
+				cmd = func() tea.Msg { return OpenViewerMsg{File: item.File} }
+			}
+		default:
+			model.list, listCmd = model.list.Update(msg)
+		}
+	}
+	return model, tea.Sequence(cmd, listCmd)
+}
+
+func (model SearchView) View() string {
+	if model.dir == nil {
+		return model.style.Render("No search")
+	}
+	return model.style.Render(
+		lipgloss.JoinVertical(
+			lipgloss.Left,
+			model.headerStyle.Render(model.header()),
+			model.list.View(),
+		),
+	)
+}
+
+// The function returns the header line with the query and the state of the search.
+func (model SearchView) header() string {
+	state := "done"
+	if model.running {
+		state = "searching..."
+	} else if model.err != nil {
+		state = "failed"
+	}
+	return "[" + model.text + "] in " + model.dir.Host.Name + ":" + model.dir.Info.Path +
+		" - " + strconv.Itoa(len(model.list.Items())) + " found, " + state
+}
+
+// The function closes the current search, the host stops walking the directory.
+func (model *SearchView) stop() {
+	if model.search != nil {
+		model.search.Close()
+		model.search = nil
+	}
+}
+
+// The function returns the command which starts the search.
+func (model SearchView) start(dir *api.RemoteFile, query api.SearchQuery) tea.Cmd {
+	id := model.id
+	return func() tea.Msg {
+		if !dir.Host.Supports(api.FeatureSearch) {
+			return UpdateSearchMsg{Id: id, Done: true, Error: errors.New("host " + dir.Host.Name + " can't search files")}
+		}
+
+		search, err := dir.Search(model.network.Transport(), query)
+		if err != nil {
+			return UpdateSearchMsg{Id: id, Done: true, Error: err}
+		}
+		return UpdateSearchMsg{Id: id, Search: search}
+	}
+}
+
+// The function returns the command which waits for the next found file.
+func (model SearchView) next(id int, search *api.FileSearch) tea.Cmd {
+	return func() tea.Msg {
+		file, err := search.Next()
+		if errors.Is(err, io.EOF) {
+			return UpdateSearchMsg{Id: id, Done: true}
+		} else if err != nil {
+			return UpdateSearchMsg{Id: id, Done: true, Error: err}
+		}
+		return UpdateSearchMsg{Id: id, File: file}
+	}
+}
+
+// The function parses the query of the search, see SEARCH_QUERY_HELP.
+func parseSearchQuery(text string, now time.Time) (api.SearchQuery, error) {
+	var err error
+	query := api.SearchQuery{}
+	patterns := []string{}
+
+	for _, word := range strings.Fields(text) {
+		switch {
+		case strings.HasPrefix(word, "re:"):
+			query.Regexp = strings.TrimPrefix(word, "re:")
+		case word == "type:f":
+			query.Type = api.FILE
+		case word == "type:d":
+			query.Type = api.DIRECTORY
+		case strings.HasPrefix(word, "size>"):
+			query.MinSize, err = parseSize(strings.TrimPrefix(word, "size>"))
+		case strings.HasPrefix(word, "size<"):
+			query.MaxSize, err = parseSize(strings.TrimPrefix(word, "size<"))
+		case strings.HasPrefix(word, "newer:"):
+			var age time.Duration
+			if age, err = parseAge(strings.TrimPrefix(word, "newer:")); err == nil {
+				query.After = now.Add(-age)
+			}
+		case strings.HasPrefix(word, "older:"):
+			var age time.Duration
+			if age, err = parseAge(strings.TrimPrefix(word, "older:")); err == nil {
+				query.Before = now.Add(-age)
+			}
+		case strings.HasPrefix(word, "limit:"):
+			query.Limit, err = strconv.Atoi(strings.TrimPrefix(word, "limit:"))
+		default:
+			patterns = append(patterns, word)
+		}
+
+		if err != nil {
+			return query, errors.Join(errors.New("["+word+"] is incorrect"), err)
+		}
+	}
+
+	// The pattern may contain spaces.
+	query.Pattern = strings.Join(patterns, " ")
+	if _, err = filepath.Match(query.Pattern, ""); err != nil {
+		return query, errors.Join(errors.New("["+query.Pattern+"] is incorrect"), err)
+	}
+	return query, nil
+}
+
+// The function parses the size with the optional unit, 1K is 1024 bytes.
+func parseSize(text string) (api.FileSize, error) {
+	text = strings.TrimSuffix(strings.ToUpper(text), "B")
+	multiplier := int64(1)
+	for index, unit := range []string{"K", "M", "G", "T"} {
+		if strings.HasSuffix(text, unit) {
+			text = strings.TrimSuffix(text, unit)
+			multiplier = int64(1) << (10 * (index + 1))
+			break
+		}
+	}
+
+	size, err := strconv.ParseInt(text, 10, 64)
+	return api.FileSize(size * multiplier), err
+}
+
+// The function parses the duration, the days are supported in addition to the units of time.ParseDuration.
+func parseAge(text string) (time.Duration, error) {
+	if days, ok := strings.CutSuffix(text, "d"); ok {
+		count, err := strconv.Atoi(days)
+		return time.Duration(count) * 24 * time.Hour, err
+	}
+	return time.ParseDuration(text)
+}
+
+func NewSearchView(network *api.Network) tea.Model {
+	delegate := &SearchViewItemDelegate{
+		columnTypeStyle:   lipgloss.NewStyle().AlignHorizontal(lipgloss.Left),
+		columnNameStyle:   lipgloss.NewStyle().AlignHorizontal(lipgloss.Left),
+		columnSizeStyle:   lipgloss.NewStyle().AlignHorizontal(lipgloss.Right),
+		itemStyle:         lipgloss.NewStyle(),
+		itemSelectedStyle: lipgloss.NewStyle().Background(lipgloss.Color("#3b82f6")),
+	}
+
+	lst := list.New([]list.Item{}, delegate, 0, 0)
+	lst.DisableQuitKeybindings()
+	lst.SetFilteringEnabled(false)
+	lst.SetShowFilter(false)
+	lst.SetShowHelp(false)
+	lst.SetShowTitle(false)
+	lst.SetShowStatusBar(false)
+	lst.SetShowPagination(false)
+
+	return SearchView{
+		list:     lst,
+		delegate: delegate,
+		network:  network,
+		style: lipgloss.
+			NewStyle().
+			Align(lipgloss.Left, lipgloss.Left).
+			BorderForeground(lipgloss.Color("#3b82f6")).
+			BorderStyle(lipgloss.NormalBorder()),
+		headerStyle: lipgloss.
+			NewStyle().
+			Height(1).
+			Bold(true),
+	}
+}
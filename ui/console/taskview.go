@@ -1,11 +1,13 @@
 package console
 
 import (
+	"errors"
 	"io"
 	"netfs/api"
 	"path/filepath"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/charmbracelet/bubbles/list"
 	tea "github.com/charmbracelet/bubbletea"
@@ -13,23 +15,126 @@ import (
 )
 
 const COLUMN_PROGRESS_WIDTH = 5
+const COLUMN_STATUS_WIDTH = 10
+const TASK_DETAIL_WIDTH = 48
 
 type UpdateTaskMsg struct {
 	Items []list.Item
+	Error error
+	// The tasks are received from all known hosts.
+	All bool
+}
+
+// The filter of the shown tasks by their status.
+type TaskFilter uint8
+
+const (
+	TaskFilterAll TaskFilter = iota
+	TaskFilterRunning
+	TaskFilterFailed
+	TaskFilterCancelled
+	TaskFilterCompleted
+)
+
+// Returns a string representation of the filter.
+func (filter TaskFilter) String() string {
+	switch filter {
+	case TaskFilterRunning:
+		return "running"
+	case TaskFilterFailed:
+		return "failed"
+	case TaskFilterCancelled:
+		return "cancelled"
+	case TaskFilterCompleted:
+		return "completed"
+	default:
+		return "all"
+	}
+}
+
+// The function checks that the task is shown by the filter.
+func (filter TaskFilter) Match(task *api.RemoteCopyTask) bool {
+	switch filter {
+	case TaskFilterRunning:
+		return task.Status == api.Running
+	case TaskFilterFailed:
+		return task.Status == api.Failed
+	case TaskFilterCancelled:
+		return task.Status == api.Cancelled
+	case TaskFilterCompleted:
+		return task.Status == api.Completed
+	default:
+		return true
+	}
+}
+
+// Returns a string representation of the task status, the paused task is shown as paused.
+func taskStatus(task *api.RemoteCopyTask) string {
+	switch {
+	case task.Status == api.Running && task.Paused:
+		return "paused"
+	case task.Status == api.Running:
+		return "running"
+	case task.Status == api.Cancelled:
+		return "cancelled"
+	case task.Status == api.Completed:
+		return "completed"
+	default:
+		return "failed"
+	}
+}
+
+// The function returns the styles of the task statuses.
+func taskStatusStyles() map[string]lipgloss.Style {
+	return map[string]lipgloss.Style{
+		"paused":    lipgloss.NewStyle().Foreground(lipgloss.Color("#f59e0b")),
+		"running":   lipgloss.NewStyle().Foreground(lipgloss.Color("#3b82f6")),
+		"cancelled": lipgloss.NewStyle().Foreground(lipgloss.Color("#9ca3af")),
+		"completed": lipgloss.NewStyle().Foreground(lipgloss.Color("#22c55e")),
+		"failed":    lipgloss.NewStyle().Foreground(lipgloss.Color("#ef4444")),
+	}
 }
 
 type TaskViewItem struct {
 	Task *api.RemoteCopyTask
+	// The host which runs the task.
+	Host *api.RemoteHost
 }
 
 func (item TaskViewItem) Title() string       { return item.Task.Source.Info.Name }
 func (item TaskViewItem) Description() string { return item.Task.Source.Info.Name }
 func (item TaskViewItem) FilterValue() string { return item.Task.Source.Info.Name }
 
+// The header of the tasks of one host, it is shown in the all hosts mode.
+type TaskViewHostItem struct {
+	Host  *api.RemoteHost
+	Count int
+	// The total rate of the running tasks of the host in bytes per second.
+	Throughput float64
+}
+
+func (item TaskViewHostItem) Title() string       { return item.Host.Name }
+func (item TaskViewHostItem) Description() string { return item.Host.IP.String() }
+func (item TaskViewHostItem) FilterValue() string { return item.Host.Name }
+
+// Returns the total rate of the running tasks in bytes per second, the paused tasks are skipped.
+func taskThroughput(tasks []*api.RemoteCopyTask) float64 {
+	throughput := 0.0
+	for _, task := range tasks {
+		if task.Status == api.Running && !task.Paused {
+			throughput += task.Throughput()
+		}
+	}
+	return throughput
+}
+
 type TaskViewItemDelegate struct {
 	columnTitleStyle    lipgloss.Style
 	columnCountStyle    lipgloss.Style
 	columnProgressStyle lipgloss.Style
+	columnStatusStyle   lipgloss.Style
+	statusStyles        map[string]lipgloss.Style
+	hostStyle           lipgloss.Style
 	itemStyle           lipgloss.Style
 	itemSelectedStyle   lipgloss.Style
 	isActive            bool
@@ -37,10 +142,26 @@ type TaskViewItemDelegate struct {
 
 func (delegate TaskViewItemDelegate) Render(writer io.Writer, model list.Model, index int, item list.Item) {
 	style := delegate.itemStyle
-	if delegate.isActive && model.Index() == index {
+	selected := delegate.isActive && model.Index() == index
+	if selected {
 		style = delegate.itemSelectedStyle
 	}
 
+	if hostItem, ok := item.(*TaskViewHostItem); ok {
+		header := strings.Join([]string{
+			hostItem.Host.Name,
+			" (",
+			hostItem.Host.IP.String(),
+			"): ",
+			strconv.Itoa(hostItem.Count),
+			" task(s), ",
+			api.FileSize(hostItem.Throughput).String(),
+			"/s",
+		}, "")
+		writer.Write([]byte(style.Width(model.Width()).Inherit(delegate.hostStyle).Render(header)))
+		return
+	}
+
 	taskItem := item.(*TaskViewItem)
 	source := taskItem.Task.Source
 	target := taskItem.Task.Target
@@ -63,8 +184,15 @@ func (delegate TaskViewItemDelegate) Render(writer io.Writer, model list.Model,
 		columnProgressStyle.
 		Render(strconv.Itoa(taskItem.Task.Progress) + "%")
 
+	status := taskStatus(taskItem.Task)
+	statusStyle := delegate.columnStatusStyle
+	// The status is colored only if the task is not selected, the selected task is highlighted by the background.
+	if !selected {
+		statusStyle = statusStyle.Inherit(delegate.statusStyles[status])
+	}
+
 	style = style.Width(model.Width())
-	delegate.columnTitleStyle = delegate.columnTitleStyle.Width(model.Width() - (lipgloss.Width(count) + lipgloss.Width(progress)))
+	delegate.columnTitleStyle = delegate.columnTitleStyle.Width(max(model.Width()-(lipgloss.Width(count)+lipgloss.Width(progress)+COLUMN_STATUS_WIDTH), 0))
 
 	writer.Write([]byte(
 		style.Render(
@@ -73,6 +201,7 @@ func (delegate TaskViewItemDelegate) Render(writer io.Writer, model list.Model,
 				delegate.columnTitleStyle.Render(title),
 				delegate.columnCountStyle.Render(count),
 				delegate.columnProgressStyle.Render(progress),
+				statusStyle.Render(status),
 			),
 		),
 	))
@@ -87,11 +216,26 @@ func (TaskViewItemDelegate) Update(msg tea.Msg, m *list.Model) tea.Cmd {
 }
 
 type TaskView struct {
-	list     list.Model
-	style    lipgloss.Style
-	host     *api.RemoteHost
-	network  *api.Network
-	delegate *TaskViewItemDelegate
+	list        list.Model
+	style       lipgloss.Style
+	headerStyle lipgloss.Style
+	detailStyle lipgloss.Style
+	labelStyle  lipgloss.Style
+	host        *api.RemoteHost
+	network     *api.Network
+	watcher     *api.HostWatcher
+	delegate    *TaskViewItemDelegate
+	// All received tasks, the list shows only the tasks matched by the filter.
+	items  []list.Item
+	filter TaskFilter
+	// The tasks of all known hosts are shown grouped by host instead of the tasks of the selected host.
+	all bool
+	// The detail pane of the selected task is shown.
+	detail bool
+	width  int
+	height int
+	// The flag is set after the failed receiving of the tasks, so the periodic error is shown once.
+	failed bool
 }
 
 func (model TaskView) Init() tea.Cmd {
@@ -103,11 +247,63 @@ func (model TaskView) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var listCmd tea.Cmd
 
 	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		item, selected := model.list.SelectedItem().(*TaskViewItem)
+		switch {
+		// Shows or hides the detail pane of the selected task.
+		case msg.Type == tea.KeyEnter:
+			model.detail = !model.detail
+			model.resize()
+			return model, nil
+		// Shows the tasks with the next status.
+		case msg.Type == tea.KeyRunes && string(msg.Runes) == "f":
+			model.filter = (model.filter + 1) % (TaskFilterCompleted + 1)
+			return model, model.arrange()
+		// Shows the tasks of all hosts or only of the selected host.
+		case msg.Type == tea.KeyRunes && string(msg.Runes) == "a":
+			model.all = !model.all
+			model.items = []list.Item{}
+			model.failed = false
+			return model, tea.Sequence(model.arrange(), model.resolveTasks())
+		case msg.Type == tea.KeyDelete || msg.Type == tea.KeyF8:
+			if selected {
+				cmd = model.cancelTask(item)
+			}
+			return model, cmd
+		// Starts the stopped task again.
+		case msg.Type == tea.KeyF5:
+			if selected {
+				cmd = model.retryTask(item)
+			}
+			return model, cmd
+		// Pauses the running task or resumes the paused one.
+		case msg.Type == tea.KeySpace:
+			if selected {
+				cmd = model.pauseTask(item)
+			}
+			return model, cmd
+		}
 	case ChangeActiveHostMsg:
 		model.host = msg.Host
-		cmd = model.resolveTasks()
+		if !model.all {
+			model.failed = false
+			cmd = model.resolveTasks()
+		}
 	case UpdateTaskMsg:
-		cmd = model.list.SetItems(msg.Items)
+		// The tasks received before switching the mode are skipped.
+		if msg.All != model.all {
+			break
+		}
+		model.items = msg.Items
+		cmd = model.arrange()
+		if msg.Error != nil && !model.failed {
+			text := "Failed to get tasks of all hosts"
+			if !model.all {
+				text = "Failed to get tasks of " + model.host.Name
+			}
+			cmd = tea.Sequence(cmd, notify(NotificationError, text, msg.Error, true))
+		}
+		model.failed = msg.Error != nil
 	case ChangeActiveViewMsg:
 		if msg.View == Task {
 			model.delegate.isActive = true
@@ -117,23 +313,16 @@ func (model TaskView) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			model.style = model.style.BorderForeground(lipgloss.Color("#ffffff"))
 		}
 	case RefreshMsg:
-		if model.host != nil {
-			cmd = model.resolveTasks()
-		}
+		cmd = model.resolveTasks()
 	case ResizeMsg:
 		frameX, frameY := model.style.GetFrameSize()
-		width := msg.Width - frameX
-		height := msg.Height - frameY
+		model.width = msg.Width - frameX
+		model.height = msg.Height - frameY
 		model.style = model.
 			style.
-			Width(width).
-			Height(height)
-
-		delegate := model.delegate
-		delegate.itemStyle = delegate.itemStyle.Width(width)
-		delegate.itemSelectedStyle = delegate.itemSelectedStyle.Width(width)
-
-		model.list.SetSize(width, height)
+			Width(model.width).
+			Height(model.height)
+		model.resize()
 	}
 	model.list, listCmd = model.list.Update(msg)
 
@@ -141,35 +330,268 @@ func (model TaskView) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 }
 
 func (model TaskView) View() string {
-	return model.style.Render(model.list.View())
+	view := model.list.View()
+	if item, ok := model.list.SelectedItem().(*TaskViewItem); ok && model.detail {
+		view = lipgloss.JoinHorizontal(lipgloss.Top, view, model.detailStyle.Render(model.details(item.Task)))
+	}
+
+	return model.style.Render(
+		lipgloss.JoinVertical(
+			lipgloss.Left,
+			model.headerStyle.Render(model.header()),
+			view,
+		),
+	)
 }
 
+// The function sets the size of the list and the detail pane, the detail pane takes at most half of the view.
+func (model *TaskView) resize() {
+	listWidth := model.width
+	if model.detail {
+		detailFrameX, _ := model.detailStyle.GetFrameSize()
+		detailWidth := min(TASK_DETAIL_WIDTH, model.width/2)
+		listWidth -= detailWidth
+		model.detailStyle = model.
+			detailStyle.
+			Width(max(detailWidth-detailFrameX, 0)).
+			MaxHeight(max(model.height-model.headerStyle.GetHeight(), 0))
+	}
+
+	delegate := model.delegate
+	delegate.itemStyle = delegate.itemStyle.Width(listWidth)
+	delegate.itemSelectedStyle = delegate.itemSelectedStyle.Width(listWidth)
+
+	model.headerStyle = model.headerStyle.Width(model.width).MaxWidth(model.width)
+	model.list.SetSize(listWidth, model.height-model.headerStyle.GetHeight())
+}
+
+// The function shows the received tasks which are matched by the filter.
+// In the all hosts mode the tasks are grouped by host, each group starts with the header of its host.
+func (model *TaskView) arrange() tea.Cmd {
+	items := []list.Item{}
+	var group *TaskViewHostItem
+	var groupTasks []*api.RemoteCopyTask
+	for _, item := range model.items {
+		taskItem := item.(*TaskViewItem)
+		if !model.filter.Match(taskItem.Task) {
+			continue
+		}
+
+		if model.all && (group == nil || !group.Host.IP.Equal(taskItem.Host.IP)) {
+			if group != nil {
+				group.Throughput = taskThroughput(groupTasks)
+			}
+			group = &TaskViewHostItem{Host: taskItem.Host}
+			groupTasks = nil
+			items = append(items, group)
+		}
+		if group != nil {
+			group.Count++
+			groupTasks = append(groupTasks, taskItem.Task)
+		}
+		items = append(items, item)
+	}
+	if group != nil {
+		group.Throughput = taskThroughput(groupTasks)
+	}
+	return model.list.SetItems(items)
+}
+
+// The function returns the header line with the host, the filter, the count of the tasks by their status and their total rate.
+func (model TaskView) header() string {
+	name := "all hosts"
+	if !model.all {
+		if model.host == nil {
+			return ""
+		}
+		name = model.host.Name
+	}
+
+	counts := map[string]int{}
+	tasks := make([]*api.RemoteCopyTask, len(model.items))
+	shown := 0
+	for index, item := range model.items {
+		tasks[index] = item.(*TaskViewItem).Task
+		counts[taskStatus(tasks[index])]++
+		if model.filter.Match(tasks[index]) {
+			shown++
+		}
+	}
+	parts := []string{name, ": ", model.filter.String(), " (", strconv.Itoa(shown), " of ", strconv.Itoa(len(model.items)), ")"}
+	for _, status := range []string{"running", "paused", "failed", "cancelled", "completed"} {
+		if counts[status] > 0 {
+			parts = append(parts, ", ", status, " ", strconv.Itoa(counts[status]))
+		}
+	}
+	if counts["running"] > 0 {
+		parts = append(parts, ", ", api.FileSize(taskThroughput(tasks)).String(), "/s")
+	}
+	return strings.Join(parts, "")
+}
+
+// The function returns the lines of the detail pane with information about the task.
+func (model TaskView) details(task *api.RemoteCopyTask) string {
+	size := "unknown"
+	if task.Size > 0 {
+		size = api.FileSize(task.Size).String()
+	}
+	codec := string(task.Stats.Codec)
+	if codec == "" {
+		codec = "none"
+	}
+
+	// The error is shown first, the detail pane may be cut by the height of the view.
+	lines := [][2]string{
+		{"Source", task.Source.Host.Name + ":" + task.Source.Info.Path},
+		{"Target", task.Target.Host.Name + ":" + task.Target.Info.Path},
+		{"Status", taskStatus(task)},
+	}
+	if task.Error != nil {
+		lines = append(lines, [2]string{"Error", task.Error.Error()})
+	}
+	lines = append(lines, [][2]string{
+		{"Progress", strconv.Itoa(task.Progress) + "%"},
+		{"Files", strconv.Itoa(task.Current) + "/" + strconv.Itoa(task.Count)},
+		{"Bytes", api.FileSize(task.Copied()).String() + " of " + size},
+		{"Sent", api.FileSize(task.Stats.Sent).String() + " (" + codec + ", x" + strconv.FormatFloat(task.Stats.Ratio(), 'f', 2, 64) + ")"},
+		{"Saved", api.FileSize(task.Stats.Saved).String()},
+		{"Speed", api.FileSize(task.Throughput()).String() + "/s"},
+		{"Elapsed", task.Elapsed.Round(time.Second).String()},
+	}...)
+	if remaining := task.Remaining(); remaining > 0 && !task.Paused {
+		lines = append(lines, [2]string{"ETA", remaining.Round(time.Second).String()})
+	}
+
+	rendered := make([]string, len(lines))
+	for index, line := range lines {
+		rendered[index] = model.labelStyle.Render(line[0]) + line[1]
+	}
+	return strings.Join(rendered, "\n")
+}
+
+// The function returns the command which loads the tasks of all hosts or of the selected host.
+// Nothing is loaded if the host is not selected yet.
 func (model TaskView) resolveTasks() tea.Cmd {
+	if model.all {
+		return model.resolveAllTasks()
+	}
+	if model.host == nil {
+		return nil
+	}
+
+	host := model.host
 	return func() tea.Msg {
-		// TODO. show error
-		tasks, err := model.host.Tasks(model.network.Transport())
+		var tasks []api.RemoteCopyTask
+		var err error
+		// The hosts which control the tasks keep the completed and the cancelled tasks too.
+		if host.Supports(api.FeatureTaskControl) {
+			tasks, err = host.TaskHistory(model.network.Transport())
+		} else {
+			tasks, err = host.Tasks(model.network.Transport())
+		}
+
 		if err == nil {
 			items := make([]list.Item, len(tasks))
 			for index := range items {
-				items[index] = &TaskViewItem{Task: &tasks[index]}
+				items[index] = &TaskViewItem{Task: &tasks[index], Host: host}
 			}
 			return UpdateTaskMsg{Items: items}
 		}
 
-		return UpdateTaskMsg{Items: []list.Item{}}
+		return UpdateTaskMsg{Items: []list.Item{}, Error: err}
+	}
+}
+
+// The function receives the tasks of all hosts known to the watcher concurrently.
+// The tasks of the available hosts are shown even if other hosts fail.
+func (model TaskView) resolveAllTasks() tea.Cmd {
+	return func() tea.Msg {
+		watched := model.watcher.Hosts()
+		hosts := make([]api.RemoteHost, len(watched))
+		for index := range watched {
+			hosts[index] = watched[index].Host
+		}
+
+		items := []list.Item{}
+		errs := []error{}
+		for _, result := range model.network.Tasks(hosts) {
+			if result.Error != nil {
+				errs = append(errs, errors.New(result.Host.Name+": "+result.Error.Error()))
+				continue
+			}
+			for index := range result.Tasks {
+				items = append(items, &TaskViewItem{Task: &result.Tasks[index], Host: &result.Host})
+			}
+		}
+		return UpdateTaskMsg{Items: items, Error: errors.Join(errs...), All: true}
+	}
+}
+
+// The function cancels the running task and receives the tasks again.
+func (model TaskView) cancelTask(item *TaskViewItem) tea.Cmd {
+	task := item.Task
+	if task.Status != api.Running {
+		return notify(NotificationWarning, "Only running tasks can be cancelled", nil, false)
+	}
+
+	return tea.Sequence(func() tea.Msg {
+		if err := task.Cancel(model.network.Transport()); err != nil {
+			return NotificationMsg{Level: NotificationError, Text: "Failed to cancel the copying of " + task.Source.Info.Name, Error: err}
+		}
+		return NotificationMsg{Level: NotificationSuccess, Text: "Copying of " + task.Source.Info.Name + " is cancelled"}
+	}, model.resolveTasks())
+}
+
+// The function starts the failed or cancelled task again and receives the tasks again.
+func (model TaskView) retryTask(item *TaskViewItem) tea.Cmd {
+	task := item.Task
+	if task.Status != api.Failed && task.Status != api.Cancelled {
+		return notify(NotificationWarning, "Only failed or cancelled tasks can be retried", nil, false)
+	}
+
+	return tea.Sequence(func() tea.Msg {
+		if _, err := task.Retry(model.network.Transport()); err != nil {
+			return NotificationMsg{Level: NotificationError, Text: "Failed to retry the copying of " + task.Source.Info.Name, Error: err}
+		}
+		return NotificationMsg{Level: NotificationSuccess, Text: "Copying of " + task.Source.Info.Name + " is started again"}
+	}, model.resolveTasks())
+}
+
+// The function pauses the running task or resumes the paused one and receives the tasks again.
+func (model TaskView) pauseTask(item *TaskViewItem) tea.Cmd {
+	task := item.Task
+	if !item.Host.Supports(api.FeatureTaskControl) {
+		return notify(NotificationWarning, "Host "+item.Host.Name+" can't pause tasks", nil, false)
 	}
+	if task.Status != api.Running {
+		return notify(NotificationWarning, "Only running tasks can be paused", nil, false)
+	}
+
+	return tea.Sequence(func() tea.Msg {
+		action, done := task.Pause, "paused"
+		if task.Paused {
+			action, done = task.Resume, "resumed"
+		}
+		if err := action(model.network.Transport()); err != nil {
+			return NotificationMsg{Level: NotificationError, Text: "Failed to change the copying of " + task.Source.Info.Name, Error: err}
+		}
+		return NotificationMsg{Level: NotificationSuccess, Text: "Copying of " + task.Source.Info.Name + " is " + done}
+	}, model.resolveTasks())
 }
 
-func NewTaskView(network *api.Network) tea.Model {
+func NewTaskView(network *api.Network, watcher *api.HostWatcher) tea.Model {
 	delegate := TaskViewItemDelegate{
 		columnTitleStyle:    lipgloss.NewStyle().AlignHorizontal(lipgloss.Left),
 		columnCountStyle:    lipgloss.NewStyle().AlignHorizontal(lipgloss.Right),
 		columnProgressStyle: lipgloss.NewStyle().AlignHorizontal(lipgloss.Right).Width(COLUMN_PROGRESS_WIDTH),
+		columnStatusStyle:   lipgloss.NewStyle().AlignHorizontal(lipgloss.Right).Width(COLUMN_STATUS_WIDTH),
+		statusStyles:        taskStatusStyles(),
+		hostStyle:           lipgloss.NewStyle().Bold(true),
 		itemStyle:           lipgloss.NewStyle(),
 		itemSelectedStyle:   lipgloss.NewStyle().Background(lipgloss.Color("#3b82f6")),
 	}
 
-	lst := list.New([]list.Item{}, delegate, 0, 0)
+	lst := list.New([]list.Item{}, &delegate, 0, 0)
 	lst.DisableQuitKeybindings()
 	lst.SetShowFilter(false)
 	lst.SetShowHelp(false)
@@ -179,6 +601,7 @@ func NewTaskView(network *api.Network) tea.Model {
 
 	return &TaskView{
 		network:  network,
+		watcher:  watcher,
 		list:     lst,
 		delegate: &delegate,
 		style: lipgloss.
@@ -186,5 +609,22 @@ func NewTaskView(network *api.Network) tea.Model {
 			Align(lipgloss.Left, lipgloss.Left).
 			BorderForeground(lipgloss.Color("#ffffff")).
 			BorderStyle(lipgloss.NormalBorder()),
+		headerStyle: lipgloss.
+			NewStyle().
+			Height(1).
+			Bold(true),
+		detailStyle: lipgloss.
+			NewStyle().
+			PaddingLeft(1).
+			BorderStyle(lipgloss.NormalBorder()).
+			BorderLeft(true).
+			BorderTop(false).
+			BorderRight(false).
+			BorderBottom(false).
+			BorderForeground(lipgloss.Color("#9ca3af")),
+		labelStyle: lipgloss.
+			NewStyle().
+			Width(10).
+			Foreground(lipgloss.Color("#9ca3af")),
 	}
 }
@@ -0,0 +1,496 @@
+package console
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"netfs/api"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// The size of the range of the file which is read at once, the viewer never keeps more than one range.
+const VIEWER_WINDOW_SIZE = 64 * 1024
+
+// The count of bytes of one line of the hex view.
+const VIEWER_HEX_WIDTH = 16
+
+// The count of bytes which are checked to detect a binary file.
+const VIEWER_PROBE_SIZE = 512
+const VIEWER_TAB = "    "
+const VIEWER_SCROLL_WIDTH = 8
+
+// The event sends to open the file in the viewer.
+type OpenViewerMsg struct {
+	File *api.RemoteFile
+}
+
+// The event sends after reading the range of the file.
+type UpdateViewerMsg struct {
+	File   *api.RemoteFile
+	Offset int64
+	Data   []byte
+	// The first line of the range is not complete and must be skipped.
+	Partial bool
+	// The last line of the range is shown at the bottom of the viewer.
+	Bottom bool
+	// The count of lines to scroll after showing the range.
+	Scroll int
+	// The mode of the viewer is detected by the content of the range.
+	Detect bool
+	Error  error
+}
+
+// The viewer of a remote file, the file is read lazily by ranges so a large file is never downloaded.
+type ViewerView struct {
+	network     *api.Network
+	file        *api.RemoteFile
+	style       lipgloss.Style
+	headerStyle lipgloss.Style
+	footerStyle lipgloss.Style
+	syntax      *syntax
+	// The lines of the current range and the offset of every line in the file.
+	lines   []string
+	offsets []int64
+	// The offset of the byte after the last line of the current range.
+	end    int64
+	top    int
+	left   int
+	width  int
+	height int
+	hex    bool
+	// The end of the file is reached by the current range.
+	eof bool
+}
+
+func (model ViewerView) Init() tea.Cmd {
+	return nil
+}
+
+func (model ViewerView) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+
+	switch msg := msg.(type) {
+	case OpenViewerMsg:
+		model.file = msg.File
+		model.syntax = syntaxOf(msg.File.Info.Name)
+		model.hex = false
+		model.lines, model.offsets = nil, nil
+		model.top, model.left, model.end, model.eof = 0, 0, 0, false
+		cmd = model.read(0, false, false, 0, true)
+	case UpdateViewerMsg:
+		// The range of the previous file is skipped.
+		if model.file == nil || msg.File != model.file {
+			break
+		}
+		if msg.Error != nil {
+			cmd = notify(NotificationError, "Failed to read "+msg.File.Host.Name+":"+msg.File.Info.Path, msg.Error, false)
+			break
+		}
+
+		if msg.Detect {
+			model.hex = isBinary(msg.Data)
+		}
+		model.show(msg)
+	case ResizeMsg:
+		frameX, frameY := model.style.GetFrameSize()
+		model.width = msg.Width - frameX
+		model.height = msg.Height - frameY
+		model.style = model.
+			style.
+			Width(model.width).
+			Height(model.height)
+	case tea.KeyMsg:
+		if model.file != nil {
+			cmd = model.key(msg)
+		}
+	}
+	return model, cmd
+}
+
+func (model ViewerView) View() string {
+	if model.file == nil {
+		return model.style.Render("No file")
+	}
+
+	width := max(model.width, 0)
+	lines := make([]string, 0, model.pageHeight()+2)
+	lines = append(lines, model.headerStyle.MaxWidth(width).Render(model.header()))
+	for index := model.top; index < min(model.top+model.pageHeight(), len(model.lines)); index++ {
+		lines = append(lines, model.line(index, width))
+	}
+	for len(lines) < model.pageHeight()+1 {
+		lines = append(lines, "")
+	}
+	lines = append(lines, model.footerStyle.MaxWidth(width).Render(model.footer()))
+
+	return model.style.Render(strings.Join(lines, "\n"))
+}
+
+// The function handles the navigation keys, the next range is read if the view leaves the current one.
+func (model *ViewerView) key(msg tea.KeyMsg) tea.Cmd {
+	page := max(model.pageHeight(), 1)
+
+	switch msg.Type {
+	case tea.KeyUp:
+		return model.scroll(-1)
+	case tea.KeyDown:
+		return model.scroll(1)
+	case tea.KeyPgUp:
+		return model.scroll(-page)
+	case tea.KeyPgDown, tea.KeySpace:
+		return model.scroll(page)
+	case tea.KeyLeft:
+		model.left = max(model.left-VIEWER_SCROLL_WIDTH, 0)
+	case tea.KeyRight:
+		if !model.hex {
+			model.left += VIEWER_SCROLL_WIDTH
+		}
+	case tea.KeyHome:
+		return model.read(0, false, false, 0, false)
+	case tea.KeyEnd:
+		start := max(int64(model.file.Info.Size)-VIEWER_WINDOW_SIZE, 0)
+		return model.read(start, start > 0, true, 0, false)
+	case tea.KeyRunes:
+		switch string(msg.Runes) {
+		// Switches between the text view and the hex view from the current line.
+		case "h":
+			model.hex = !model.hex
+			model.left = 0
+			offset := model.topOffset()
+			return model.read(offset, offset > 0, false, 0, false)
+		}
+	}
+	return nil
+}
+
+// The function moves the view by the count of lines.
+// If the view leaves the current range, the range around the current page is read and the rest of the lines is scrolled.
+func (model *ViewerView) scroll(count int) tea.Cmd {
+	page := model.pageHeight()
+	top := model.top + count
+
+	if top+page > len(model.lines) && !model.eof {
+		// The range which is shorter than the page is followed by the next range.
+		if model.top == 0 {
+			return model.read(model.end, false, false, 0, false)
+		}
+		return model.read(model.topOffset(), false, false, count, false)
+	} else if top < 0 && len(model.offsets) > 0 && model.offsets[0] > 0 {
+		start := max(model.endOffset()-VIEWER_WINDOW_SIZE, 0)
+		return model.read(start, start > 0, true, count, false)
+	}
+
+	model.top = max(min(top, len(model.lines)-page), 0)
+	return nil
+}
+
+// The function returns the command which reads the range of the file starting at the offset.
+func (model ViewerView) read(offset int64, partial bool, bottom bool, scroll int, detect bool) tea.Cmd {
+	file := model.file
+	if model.hex {
+		offset -= offset % VIEWER_HEX_WIDTH
+		partial = false
+	}
+
+	return func() tea.Msg {
+		msg := UpdateViewerMsg{File: file, Offset: offset, Partial: partial, Bottom: bottom, Scroll: scroll, Detect: detect}
+		if !file.Host.Supports(api.FeatureRead) {
+			msg.Error = errors.New("host " + file.Host.Name + " can't read files")
+			return msg
+		}
+
+		reader, err := file.Read(model.network.Transport(), offset, VIEWER_WINDOW_SIZE)
+		if err == nil {
+			msg.Data, err = io.ReadAll(io.LimitReader(reader, VIEWER_WINDOW_SIZE))
+			err = errors.Join(err, reader.Close())
+		}
+		msg.Error = err
+		return msg
+	}
+}
+
+// The function replaces the lines by the lines of the read range.
+func (model *ViewerView) show(msg UpdateViewerMsg) {
+	data := msg.Data
+	offset := msg.Offset
+	model.eof = len(data) < VIEWER_WINDOW_SIZE
+
+	if model.hex {
+		model.lines, model.offsets = hexLines(data, offset)
+		model.end = offset + int64(len(data))
+	} else {
+		// The incomplete lines at the edges of the range are shown by the next ranges.
+		if index := bytes.IndexByte(data, '\n'); msg.Partial && index >= 0 && index < len(data)-1 {
+			data = data[index+1:]
+			offset += int64(index + 1)
+		}
+		if index := bytes.LastIndexByte(data, '\n'); !model.eof && index >= 0 {
+			data = data[:index+1]
+		}
+		model.lines, model.offsets = textLines(data, offset)
+		model.end = offset + int64(len(data))
+	}
+
+	model.top = 0
+	if msg.Bottom {
+		model.top = max(len(model.lines)-model.pageHeight(), 0)
+	}
+	model.top = max(min(model.top+msg.Scroll, len(model.lines)-model.pageHeight()), 0)
+}
+
+// The function returns the rendered line of the current range.
+func (model ViewerView) line(index int, width int) string {
+	line := model.lines[index]
+	if model.hex {
+		return lipgloss.NewStyle().MaxWidth(width).Render(line)
+	}
+
+	runes := []rune(line)
+	if model.left >= len(runes) {
+		return ""
+	}
+	runes = runes[model.left:min(len(runes), model.left+width)]
+	return model.syntax.highlight(string(runes))
+}
+
+// The function returns the count of lines of one page.
+func (model ViewerView) pageHeight() int {
+	return max(model.height-2, 0)
+}
+
+// The function returns the offset of the first shown line.
+func (model ViewerView) topOffset() int64 {
+	if model.top < len(model.offsets) {
+		return model.offsets[model.top]
+	}
+	return model.end
+}
+
+// The function returns the offset of the byte after the last shown line.
+func (model ViewerView) endOffset() int64 {
+	if index := model.top + model.pageHeight(); index < len(model.offsets) {
+		return model.offsets[index]
+	}
+	return model.end
+}
+
+// The function returns the header line with the file and the shown range.
+func (model ViewerView) header() string {
+	mode := "TEXT"
+	if model.hex {
+		mode = "HEX"
+	}
+
+	position := model.endOffset()
+	percent := 100
+	if size := int64(model.file.Info.Size); size > 0 {
+		percent = int(min(position*100/size, 100))
+	}
+	return model.file.Host.Name + ":" + model.file.Info.Path +
+		" [" + mode + "] " + strconv.FormatInt(model.topOffset(), 10) + "-" + strconv.FormatInt(position, 10) +
+		" of " + model.file.Info.Size.String() + " (" + strconv.Itoa(percent) + "%)"
+}
+
+// The function returns the footer line with the keys of the viewer.
+func (model ViewerView) footer() string {
+	return "pgup/pgdown  home/end  h: text/hex  esc: close"
+}
+
+// The function splits the data to the lines and returns the lines with their offsets.
+func textLines(data []byte, offset int64) ([]string, []int64) {
+	lines := []string{}
+	offsets := []int64{}
+	for len(data) > 0 {
+		index := bytes.IndexByte(data, '\n')
+		if index < 0 {
+			index = len(data) - 1
+		}
+
+		line := strings.TrimRight(string(data[:index+1]), "\r\n")
+		line = strings.ReplaceAll(strings.ToValidUTF8(line, "�"), "\t", VIEWER_TAB)
+		lines = append(lines, strings.Map(printable, line))
+		offsets = append(offsets, offset)
+
+		data = data[index+1:]
+		offset += int64(index + 1)
+	}
+	return lines, offsets
+}
+
+// The function returns the lines of the hex view of the data with their offsets.
+func hexLines(data []byte, offset int64) ([]string, []int64) {
+	lines := []string{}
+	offsets := []int64{}
+	for start := 0; start < len(data); start += VIEWER_HEX_WIDTH {
+		chunk := data[start:min(start+VIEWER_HEX_WIDTH, len(data))]
+
+		builder := strings.Builder{}
+		fmt.Fprintf(&builder, "%08x  ", offset+int64(start))
+		for index := 0; index < VIEWER_HEX_WIDTH; index++ {
+			if index < len(chunk) {
+				fmt.Fprintf(&builder, "%02x ", chunk[index])
+			} else {
+				builder.WriteString("   ")
+			}
+			if index == VIEWER_HEX_WIDTH/2-1 {
+				builder.WriteString(" ")
+			}
+		}
+
+		builder.WriteString(" |")
+		for _, value := range chunk {
+			if value >= 0x20 && value < 0x7f {
+				builder.WriteByte(value)
+			} else {
+				builder.WriteByte('.')
+			}
+		}
+		builder.WriteString("|")
+
+		lines = append(lines, builder.String())
+		offsets = append(offsets, offset+int64(start))
+	}
+	return lines, offsets
+}
+
+// The function replaces the control characters, so they don't break the terminal.
+func printable(char rune) rune {
+	if unicode.IsControl(char) {
+		return '.'
+	}
+	return char
+}
+
+// The function returns true if the beginning of the data is not a text.
+func isBinary(data []byte) bool {
+	probe := data[:min(len(data), VIEWER_PROBE_SIZE)]
+	if bytes.IndexByte(probe, 0) >= 0 {
+		return true
+	}
+
+	if utf8.Valid(probe) {
+		return false
+	}
+
+	// The last character may be cut by the probe.
+	for cut := 1; len(probe) < len(data) && cut < utf8.UTFMax && cut < len(probe); cut++ {
+		if utf8.Valid(probe[:len(probe)-cut]) {
+			return false
+		}
+	}
+	return true
+}
+
+// The highlighting rules of the file format.
+type syntax struct {
+	keywords map[string]bool
+	// The prefix of a line comment.
+	comment string
+	quotes  string
+}
+
+var syntaxStyles = struct {
+	keyword lipgloss.Style
+	str     lipgloss.Style
+	number  lipgloss.Style
+	comment lipgloss.Style
+}{
+	keyword: lipgloss.NewStyle().Foreground(lipgloss.Color("#c084fc")),
+	str:     lipgloss.NewStyle().Foreground(lipgloss.Color("#4ade80")),
+	number:  lipgloss.NewStyle().Foreground(lipgloss.Color("#facc15")),
+	comment: lipgloss.NewStyle().Foreground(lipgloss.Color("#6b7280")),
+}
+
+// The function returns the highlighting rules by the extension of the file, nil means the plain text.
+func syntaxOf(name string) *syntax {
+	words := func(text string) map[string]bool {
+		result := map[string]bool{}
+		for _, word := range strings.Fields(text) {
+			result[word] = true
+		}
+		return result
+	}
+
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".go":
+		return &syntax{comment: "//", quotes: "\"'`", keywords: words("break case chan const continue default defer else fallthrough for func go goto if import interface map package range return select struct switch type var nil true false")}
+	case ".c", ".h", ".cpp", ".hpp", ".java", ".js", ".ts", ".rs", ".cs":
+		return &syntax{comment: "//", quotes: "\"'`", keywords: words("break case catch class const continue default do else enum export extends false final fn for function if impl import let match mod mut new null pub return static struct switch this throw true try typedef use var void while")}
+	case ".py":
+		return &syntax{comment: "#", quotes: "\"'", keywords: words("and as assert async await break class continue def del elif else except False finally for from global if import in is lambda None nonlocal not or pass raise return True try while with yield")}
+	case ".sh", ".bash", ".conf", ".ini", ".toml", ".yaml", ".yml":
+		return &syntax{comment: "#", quotes: "\"'", keywords: words("if then else elif fi for while do done case esac function in return export local true false null yes no")}
+	case ".json":
+		return &syntax{quotes: "\"", keywords: words("true false null")}
+	}
+	return nil
+}
+
+// The function returns the line with the highlighted keywords, strings, numbers and comments.
+// The rules are simple and don't cross the line, so a multiline string or comment is shown partially.
+func (rules *syntax) highlight(line string) string {
+	if rules == nil {
+		return line
+	}
+
+	builder := strings.Builder{}
+	runes := []rune(line)
+	for index := 0; index < len(runes); {
+		char := runes[index]
+		switch {
+		case rules.comment != "" && strings.HasPrefix(string(runes[index:]), rules.comment):
+			builder.WriteString(syntaxStyles.comment.Render(string(runes[index:])))
+			index = len(runes)
+		case strings.ContainsRune(rules.quotes, char):
+			end := index + 1
+			for end < len(runes) && runes[end] != char {
+				if runes[end] == '\\' {
+					end++
+				}
+				end++
+			}
+			end = min(end+1, len(runes))
+			builder.WriteString(syntaxStyles.str.Render(string(runes[index:end])))
+			index = end
+		case unicode.IsLetter(char) || char == '_' || unicode.IsDigit(char):
+			end := index
+			for end < len(runes) && (unicode.IsLetter(runes[end]) || runes[end] == '_' || unicode.IsDigit(runes[end]) || (unicode.IsDigit(char) && runes[end] == '.')) {
+				end++
+			}
+
+			word := string(runes[index:end])
+			if unicode.IsDigit(char) {
+				word = syntaxStyles.number.Render(word)
+			} else if rules.keywords[word] {
+				word = syntaxStyles.keyword.Render(word)
+			}
+			builder.WriteString(word)
+			index = end
+		default:
+			builder.WriteRune(char)
+			index++
+		}
+	}
+	return builder.String()
+}
+
+func NewViewerView(network *api.Network) tea.Model {
+	return ViewerView{
+		network: network,
+		style: lipgloss.
+			NewStyle().
+			Align(lipgloss.Left, lipgloss.Top).
+			BorderForeground(lipgloss.Color("#3b82f6")).
+			BorderStyle(lipgloss.NormalBorder()),
+		headerStyle: lipgloss.NewStyle().Bold(true),
+		footerStyle: lipgloss.NewStyle().Foreground(lipgloss.Color("#6b7280")),
+	}
+}